@@ -0,0 +1,68 @@
+// Package agex exports and imports SensitiveStrings in the age encryption
+// format (https://age-encryption.org), so a secret can be written to disk
+// or sent to another operator encrypted to their age or SSH public key and
+// opened there with the age CLI or ImportSealed.
+//
+// The plaintext only exists inside the SensitiveStrings at either end and
+// in buffers this package clears after use.
+package agex
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"filippo.io/age"
+
+	ss "github.com/earlye/sensitive-strings/golang/ss"
+)
+
+// ExportSealed encrypts the secret to recipients and returns the binary age
+// file. Wrap the output with filippo.io/age/armor for a text-safe form.
+func ExportSealed(s *ss.SensitiveString, recipients ...age.Recipient) ([]byte, error) {
+	if s == nil {
+		return nil, errors.New("agex: nil SensitiveString")
+	}
+	var out bytes.Buffer
+	w, err := age.Encrypt(&out, recipients...)
+	if err != nil {
+		return nil, err
+	}
+	s.Use(func(secret []byte) { _, err = w.Write(secret) })
+	if err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// ImportSealed decrypts an age file produced by ExportSealed, or by the age
+// CLI, with the first of identities that matches, and returns the secret
+// it holds.
+func ImportSealed(data []byte, identities ...age.Identity) (*ss.SensitiveString, error) {
+	r, err := age.Decrypt(bytes.NewReader(data), identities...)
+	if err != nil {
+		return nil, err
+	}
+	// The plaintext is shorter than the file, so reading into a buffer of
+	// that size never reallocates and leaves no stray copies behind.
+	buf := make([]byte, len(data))
+	defer clear(buf)
+	n := 0
+	for {
+		m, err := r.Read(buf[n:])
+		n += m
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if n == len(buf) {
+			return nil, errors.New("agex: plaintext larger than the age file")
+		}
+	}
+	return ss.NewFromBytes(buf[:n]), nil
+}
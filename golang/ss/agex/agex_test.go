@@ -0,0 +1,57 @@
+package agex
+
+import (
+	"bytes"
+	"testing"
+
+	"filippo.io/age"
+
+	ss "github.com/earlye/sensitive-strings/golang/ss"
+)
+
+// TestExportImport verifies a secret round-trips through an age file
+func TestExportImport(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sealed, err := ExportSealed(ss.New("hunter2"), identity.Recipient())
+	if err != nil {
+		t.Fatalf("ExportSealed() error = %v", err)
+	}
+	if bytes.Contains(sealed, []byte("hunter2")) {
+		t.Errorf("ExportSealed() output contains the plaintext")
+	}
+
+	got, err := ImportSealed(sealed, identity)
+	if err != nil {
+		t.Fatalf("ImportSealed() error = %v", err)
+	}
+	if got.Value() != "hunter2" {
+		t.Errorf("ImportSealed() = %q, want hunter2", got.Value())
+	}
+
+	other, _ := age.GenerateX25519Identity()
+	if _, err := ImportSealed(sealed, other); err == nil {
+		t.Errorf("ImportSealed() with the wrong identity error = nil")
+	}
+}
+
+// TestImportSealed_Passphrase verifies files encrypted by other age tools are accepted
+func TestImportSealed_Passphrase(t *testing.T) {
+	recipient, _ := age.NewScryptRecipient("correct horse")
+	recipient.SetWorkFactor(10)
+	var out bytes.Buffer
+	w, _ := age.Encrypt(&out, recipient)
+	w.Write([]byte("from the cli"))
+	w.Close()
+
+	identity, _ := age.NewScryptIdentity("correct horse")
+	got, err := ImportSealed(out.Bytes(), identity)
+	if err != nil {
+		t.Fatalf("ImportSealed() error = %v", err)
+	}
+	if got.Value() != "from the cli" {
+		t.Errorf("ImportSealed() = %q, want from the cli", got.Value())
+	}
+}
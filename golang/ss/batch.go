@@ -0,0 +1,97 @@
+package sensitivestring
+
+import "sort"
+
+// BatchEvent describes a NewBatch call. It carries the labels of the wrapped
+// secrets, never their values.
+type BatchEvent struct {
+	Count  int
+	Labels []string
+}
+
+// BatchOption configures NewBatch.
+type BatchOption func(*batchOptions)
+
+type batchOptions struct {
+	registry   *Registry
+	precompute bool
+	audit      func(BatchEvent)
+}
+
+// WithBatchRegistry registers every secret created by NewBatch in r with a
+// single registry update, instead of one update per secret.
+func WithBatchRegistry(r *Registry) BatchOption {
+	return func(o *batchOptions) { o.registry = r }
+}
+
+// WithPrecomputedHashes computes each secret's digest up front, so the
+// first String() or marshal call on a hot path does not pay for hashing.
+func WithPrecomputedHashes() BatchOption {
+	return func(o *batchOptions) { o.precompute = true }
+}
+
+// WithBatchAudit calls fn once with a BatchEvent describing the whole batch.
+func WithBatchAudit(fn func(BatchEvent)) BatchOption {
+	return func(o *batchOptions) { o.audit = fn }
+}
+
+// NewBatch wraps many secrets at once, e.g. when loading thousands of
+// secrets at startup. Each SensitiveString is labeled with its key.
+func NewBatch(values map[string]string, opts ...BatchOption) map[string]*SensitiveString {
+	var options batchOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	result := make(map[string]*SensitiveString, len(values))
+	secrets := make([]*SensitiveString, 0, len(values))
+	labels := make([]string, 0, len(values))
+	for label, value := range values {
+		ss := NewLabeled(label, value)
+		if options.precompute {
+			ss.precomputeDigest()
+		}
+		result[label] = ss
+		secrets = append(secrets, ss)
+		labels = append(labels, label)
+	}
+
+	if options.registry != nil {
+		options.registry.Register(secrets...)
+	}
+	if options.audit != nil {
+		sort.Strings(labels)
+		options.audit(BatchEvent{Count: len(result), Labels: labels})
+	}
+	return result
+}
+
+// digestCache holds a digest computed ahead of time together with the
+// storage it was computed from, so a value replaced since is detected
+// without keeping another copy of the plaintext. name and key record the
+// algorithm and fingerprint key or salt it was computed with; a digest
+// computed under a configuration changed since is not used.
+type digestCache struct {
+	store storage
+	name  string
+	key   []byte
+	sum   []byte
+}
+
+// precomputeDigest computes and caches the digest of the current value
+// under the current configuration. Values bound with Bind can change behind
+// s's back and are not cached.
+func (s *SensitiveString) precomputeDigest() {
+	if _, bound := s.store.(boundStorage); bound {
+		return
+	}
+	cfg := loadConfig()
+	alg := s.hashAlg
+	if alg == "" {
+		alg = cfg.hashAlgorithm
+	}
+	d := &digestCache{store: s.store}
+	_, d.key = cfg.digestKey()
+	s.usePlaintext(func(value []byte) { d.name, d.sum = digestWith(cfg, alg, value) })
+	s.digest = d
+}
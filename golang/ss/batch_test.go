@@ -0,0 +1,101 @@
+package sensitivestring
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestNewBatch verifies values and labels of a batch
+func TestNewBatch(t *testing.T) {
+	batch := NewBatch(map[string]string{"db": "foo", "api": "bar"})
+
+	if len(batch) != 2 {
+		t.Fatalf("len(NewBatch()) = %v, want 2", len(batch))
+	}
+	if got := batch["db"].Value(); got != "foo" {
+		t.Errorf("batch[db].Value() = %v, want foo", got)
+	}
+	if got := batch["db"].Label(); got != "db" {
+		t.Errorf("batch[db].Label() = %v, want db", got)
+	}
+	if got := batch["db"].String(); got != "sha256:"+fooHashHex {
+		t.Errorf("batch[db].String() = %v, want %v", got, "sha256:"+fooHashHex)
+	}
+}
+
+// TestNewBatch_Options verifies registry, precomputed hash and audit options
+func TestNewBatch_Options(t *testing.T) {
+	registry := NewRegistry()
+	var events []BatchEvent
+
+	batch := NewBatch(map[string]string{"db": "foo", "api": "bar"},
+		WithBatchRegistry(registry),
+		WithPrecomputedHashes(),
+		WithBatchAudit(func(e BatchEvent) { events = append(events, e) }),
+	)
+
+	if got := registry.Len(); got != 2 {
+		t.Errorf("registry.Len() = %v, want 2", got)
+	}
+	if batch["db"].digest == nil {
+		t.Errorf("WithPrecomputedHashes() did not precompute the digest")
+	}
+	if got := batch["db"].String(); got != "sha256:"+fooHashHex {
+		t.Errorf("precomputed String() = %v, want %v", got, "sha256:"+fooHashHex)
+	}
+
+	expected := []BatchEvent{{Count: 2, Labels: []string{"api", "db"}}}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("audit events = %+v, want %+v", events, expected)
+	}
+}
+
+// TestPrecomputedDigest_Stale verifies a value changed after precomputing is rehashed
+func TestPrecomputedDigest_Stale(t *testing.T) {
+	ss := New("initial")
+	ss.precomputeDigest()
+	if err := ss.Set("foo"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	if got := ss.String(); got != "sha256:"+fooHashHex {
+		t.Errorf("String() after Set() = %v, want %v", got, "sha256:"+fooHashHex)
+	}
+
+	bound := New("initial")
+	bound.precomputeDigest()
+	*bound.Bind() = "foo"
+
+	if got := bound.String(); got != "sha256:"+fooHashHex {
+		t.Errorf("String() after Bind() change = %v, want %v", got, "sha256:"+fooHashHex)
+	}
+}
+
+// TestPrecomputedDigest_Configuration verifies digests are precomputed with the configured algorithm and key and recomputed when those change
+func TestPrecomputedDigest_Configuration(t *testing.T) {
+	withHashAlgorithm(t, SHA512)
+	batch := NewBatch(map[string]string{"db": "foo"}, WithPrecomputedHashes())
+	ss := batch["db"]
+	if ss.digest == nil || ss.digest.name != string(SHA512) {
+		t.Fatalf("precomputed digest = %+v, want one computed with sha512", ss.digest)
+	}
+	if got, want := ss.String(), New("foo").String(); got != want || !strings.HasPrefix(got, "sha512:") {
+		t.Errorf("String() = %v, want %v", got, want)
+	}
+
+	SetHashAlgorithm(SHA256)
+	if got := ss.String(); got != "sha256:"+fooHashHex {
+		t.Errorf("String() after SetHashAlgorithm(SHA256) = %v, want %v", got, "sha256:"+fooHashHex)
+	}
+
+	withFingerprintKey(t, []byte("pepper"))
+	if got, want := ss.String(), New("foo").String(); got != want || !strings.HasPrefix(got, "hmac-sha256:") {
+		t.Errorf("String() after SetFingerprintKey = %v, want %v", got, want)
+	}
+	ss.precomputeDigest()
+	SetFingerprintKey([]byte("other"))
+	if got, want := ss.String(), New("foo").String(); got != want {
+		t.Errorf("String() after changing the key = %v, want %v", got, want)
+	}
+}
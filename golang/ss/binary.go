@@ -0,0 +1,140 @@
+package sensitivestring
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// binaryVersion is the first byte of the MarshalBinary encoding.
+const binaryVersion = 1
+
+// Kinds of payload in the MarshalBinary encoding.
+const (
+	binaryRedacted  = 0
+	binaryPlaintext = 1
+)
+
+var (
+	// ErrInvalidBinary is returned by UnmarshalBinary for malformed input.
+	ErrInvalidBinary = errors.New("sensitivestring: invalid binary encoding")
+	// ErrPlaintextRefused is returned for plaintext input where a redacted
+	// form is expected: by UnmarshalBinary for a plaintext payload, which
+	// only GobPlaintext accepts, and in DecodeRedacted mode.
+	ErrPlaintextRefused = errors.New("sensitivestring: plaintext payload refused")
+)
+
+func init() {
+	// Allow SensitiveStrings in interface-typed fields of gob values.
+	gob.Register(&SensitiveString{})
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, which gob and CBOR
+// use for SensitiveString values. It encodes the redacted form and the
+// label, so secrets sent over gob-based RPC or cached in gob-backed stores
+// are not exposed; see GobPlaintext to send the plaintext.
+// Uses a value receiver so it is callable on both value and pointer types.
+func (s SensitiveString) MarshalBinary() ([]byte, error) {
+	recordRedaction(FormatBinary, s.label, 1)
+	return appendBinary(nil, binaryRedacted, s.label, []byte(s.String())), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. A redacted
+// payload becomes the "alg:hash" value, as with UnmarshalJSON, and is
+// refused in DecodePlaintext mode. A plaintext payload is refused with
+// ErrPlaintextRefused; only GobPlaintext accepts one.
+func (s *SensitiveString) UnmarshalBinary(data []byte) error {
+	kind, label, payload, err := parseBinary(data)
+	if err != nil {
+		return err
+	}
+	if kind == binaryPlaintext {
+		return ErrPlaintextRefused
+	}
+	return s.setBinary(kind, label, payload)
+}
+
+// GobPlaintext wraps a SensitiveString so that gob carries its plaintext,
+// e.g. between the trusted ends of a gob-based RPC. Both ends must use it
+// as the field type: the sender to encode the plaintext, the receiver to
+// accept it. It implements only gob's own GobEncoder and GobDecoder, so
+// other encoders, such as CBOR via MarshalBinary or JSON, still see the
+// redacted form of the embedded SensitiveString.
+type GobPlaintext struct {
+	*SensitiveString
+}
+
+// GobEncode implements gob.GobEncoder, encoding the plaintext and the
+// label.
+func (p GobPlaintext) GobEncode() ([]byte, error) {
+	s := p.SensitiveString
+	if s == nil {
+		s = New("")
+	}
+	var out []byte
+	s.Use(func(plaintext []byte) {
+		out = appendBinary(nil, binaryPlaintext, s.label, plaintext)
+	})
+	return out, nil
+}
+
+// GobDecode implements gob.GobDecoder. It accepts the plaintext written by
+// GobEncode, refused in DecodeRedacted mode, as well as the redacted form
+// written by SensitiveString.MarshalBinary.
+func (p *GobPlaintext) GobDecode(data []byte) error {
+	kind, label, payload, err := parseBinary(data)
+	if err != nil {
+		return err
+	}
+	s := new(SensitiveString)
+	if err := s.setBinary(kind, label, payload); err != nil {
+		return err
+	}
+	p.SensitiveString = s
+	return nil
+}
+
+// appendBinary appends the binary encoding of a payload of kind to out.
+func appendBinary(out []byte, kind byte, label string, payload []byte) []byte {
+	out = slices.Grow(out, 2+binary.MaxVarintLen64+len(label)+len(payload))
+	out = append(out, binaryVersion, kind)
+	out = binary.AppendUvarint(out, uint64(len(label)))
+	out = append(out, label...)
+	return append(out, payload...)
+}
+
+// parseBinary splits a binary encoding into its kind, label and payload.
+func parseBinary(data []byte) (kind byte, label string, payload []byte, err error) {
+	if len(data) < 2 || data[0] != binaryVersion {
+		return 0, "", nil, fmt.Errorf("%w: unknown version", ErrInvalidBinary)
+	}
+	kind = data[1]
+	if kind != binaryRedacted && kind != binaryPlaintext {
+		return 0, "", nil, fmt.Errorf("%w: unknown kind %d", ErrInvalidBinary, kind)
+	}
+	labelLen, n := binary.Uvarint(data[2:])
+	if n <= 0 || labelLen > uint64(len(data)-2-n) {
+		return 0, "", nil, fmt.Errorf("%w: bad label", ErrInvalidBinary)
+	}
+	rest := data[2+n:]
+	return kind, string(rest[:labelLen]), rest[labelLen:], nil
+}
+
+// setBinary sets s from a parsed binary payload according to the
+// DecodeMode.
+func (s *SensitiveString) setBinary(kind byte, label string, payload []byte) error {
+	switch mode := loadConfig().decodeMode; {
+	case kind == binaryRedacted && mode == DecodePlaintext:
+		return ErrRedactedValue
+	case kind == binaryPlaintext && mode == DecodeRedacted:
+		return fmt.Errorf("%w: expected a redacted value", ErrPlaintextRefused)
+	}
+	s.label = label
+	s.store = &byteStorage{buf: bytes.Clone(payload)}
+	s.value = ""
+	s.digest = nil
+	return nil
+}
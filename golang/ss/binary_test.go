@@ -0,0 +1,105 @@
+package sensitivestring
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+type gobCreds struct {
+	User     string
+	Password *SensitiveString
+	Token    SensitiveString
+	Extra    interface{}
+}
+
+// TestGob_Redacted verifies gob carries the redacted form by default
+func TestGob_Redacted(t *testing.T) {
+	secret := NewLabeled("db", "hunter2")
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(gobCreds{User: "alice", Password: secret, Token: *New("tok"), Extra: New("extra")}); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if bytes.Contains(buf.Bytes(), []byte("hunter2")) {
+		t.Errorf("gob encoding contains plaintext")
+	}
+
+	var got gobCreds
+	if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if got.Password.Value() != secret.String() || got.Password.Label() != "db" {
+		t.Errorf("Password = %v/%v, want %v/db", got.Password.Value(), got.Password.Label(), secret.String())
+	}
+	if extra, ok := got.Extra.(*SensitiveString); !ok || extra.Value() != New("extra").String() {
+		t.Errorf("Extra = %#v, want the redacted SensitiveString", got.Extra)
+	}
+}
+
+type gobPlaintextCreds struct {
+	User     string
+	Password GobPlaintext
+}
+
+// TestGobPlaintext verifies plaintext round-trips only through the gob wrapper
+func TestGobPlaintext(t *testing.T) {
+	var buf bytes.Buffer
+	sent := gobPlaintextCreds{User: "alice", Password: GobPlaintext{NewLabeled("db", "hunter2")}}
+	if err := gob.NewEncoder(&buf).Encode(sent); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	data := bytes.Clone(buf.Bytes())
+
+	var got gobPlaintextCreds
+	if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if got.Password.Value() != "hunter2" || got.Password.Label() != "db" {
+		t.Errorf("Password = %v/%v, want hunter2/db", got.Password.Value(), got.Password.Label())
+	}
+
+	var redacted gobCreds
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&redacted); err == nil {
+		t.Errorf("Decode() into a SensitiveString accepted the plaintext: %v", redacted.Password.Value())
+	}
+	if out, _ := json.Marshal(sent); bytes.Contains(out, []byte("hunter2")) {
+		t.Errorf("json.Marshal(GobPlaintext) = %s, leaks the plaintext", out)
+	}
+	if out, _ := sent.Password.MarshalBinary(); bytes.Contains(out, []byte("hunter2")) {
+		t.Errorf("MarshalBinary() of GobPlaintext leaks the plaintext")
+	}
+
+	withDecodeMode(t, DecodeRedacted)
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&got); !errors.Is(err, ErrPlaintextRefused) {
+		t.Errorf("Decode() in DecodeRedacted mode error = %v, want ErrPlaintextRefused", err)
+	}
+}
+
+// TestUnmarshalBinary_DecodeMode verifies redacted payloads follow the DecodeMode
+func TestUnmarshalBinary_DecodeMode(t *testing.T) {
+	data, err := New("hunter2").MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+	withDecodeMode(t, DecodePlaintext)
+	var got SensitiveString
+	if err := got.UnmarshalBinary(data); !errors.Is(err, ErrRedactedValue) {
+		t.Errorf("UnmarshalBinary() in DecodePlaintext mode error = %v, want ErrRedactedValue", err)
+	}
+	var p GobPlaintext
+	if err := p.GobDecode(data); !errors.Is(err, ErrRedactedValue) {
+		t.Errorf("GobDecode() in DecodePlaintext mode error = %v, want ErrRedactedValue", err)
+	}
+}
+
+// TestUnmarshalBinary_Invalid verifies malformed input is rejected
+func TestUnmarshalBinary_Invalid(t *testing.T) {
+	for _, data := range [][]byte{nil, {9, 0, 0}, {binaryVersion, 7, 0}, {binaryVersion, 0, 5, 'a'}} {
+		var s SensitiveString
+		if err := s.UnmarshalBinary(data); !errors.Is(err, ErrInvalidBinary) {
+			t.Errorf("UnmarshalBinary(%v) error = %v, want ErrInvalidBinary", data, err)
+		}
+	}
+}
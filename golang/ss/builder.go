@@ -0,0 +1,74 @@
+package sensitivestring
+
+import "unicode/utf8"
+
+// Builder accumulates secret fragments, e.g. streamed from a provider or
+// assembled from parts, and produces a SensitiveString. Unlike
+// strings.Builder it zeroes every buffer it discards, so assembling a secret
+// does not leave plaintext fragments behind in reusable memory.
+//
+// The zero value is ready to use. A Builder must not be copied after first
+// use.
+type Builder struct {
+	buf []byte
+}
+
+// Len returns the number of accumulated bytes.
+func (b *Builder) Len() int {
+	return len(b.buf)
+}
+
+// Grow ensures room for another n bytes without reallocating.
+func (b *Builder) Grow(n int) {
+	if cap(b.buf)-len(b.buf) >= n {
+		return
+	}
+	grown := make([]byte, len(b.buf), 2*cap(b.buf)+n)
+	copy(grown, b.buf)
+	clear(b.buf[:cap(b.buf)])
+	b.buf = grown
+}
+
+// Write appends p, implementing io.Writer. It always returns len(p), nil.
+func (b *Builder) Write(p []byte) (int, error) {
+	b.Grow(len(p))
+	b.buf = append(b.buf, p...)
+	return len(p), nil
+}
+
+// WriteString appends s. It always returns len(s), nil.
+func (b *Builder) WriteString(s string) (int, error) {
+	b.Grow(len(s))
+	b.buf = append(b.buf, s...)
+	return len(s), nil
+}
+
+// WriteByte appends c. It always returns nil.
+func (b *Builder) WriteByte(c byte) error {
+	b.Grow(1)
+	b.buf = append(b.buf, c)
+	return nil
+}
+
+// WriteRune appends the UTF-8 encoding of r. It always returns its length
+// and nil.
+func (b *Builder) WriteRune(r rune) (int, error) {
+	b.Grow(utf8.UTFMax)
+	n := len(b.buf)
+	b.buf = utf8.AppendRune(b.buf, r)
+	return len(b.buf) - n, nil
+}
+
+// Finish returns a SensitiveString holding the accumulated fragments and
+// zeroes and resets the Builder.
+func (b *Builder) Finish() *SensitiveString {
+	ss := NewFromBytes(b.buf)
+	b.Reset()
+	return ss
+}
+
+// Reset zeroes the accumulated fragments and empties the Builder.
+func (b *Builder) Reset() {
+	clear(b.buf[:cap(b.buf)])
+	b.buf = nil
+}
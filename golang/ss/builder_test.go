@@ -0,0 +1,60 @@
+package sensitivestring
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+// TestBuilder_Finish verifies fragments are assembled and buffers are zeroed
+func TestBuilder_Finish(t *testing.T) {
+	var b Builder
+	b.WriteString("hun")
+	b.Write([]byte("te"))
+	b.WriteByte('r')
+	b.WriteRune('✓')
+
+	if got := b.Len(); got != 9 {
+		t.Errorf("Len() = %v, want 9", got)
+	}
+
+	buf := b.buf[:cap(b.buf)]
+	ss := b.Finish()
+	if got := ss.Value(); got != "hunter✓" {
+		t.Errorf("Finish().Value() = %v, want hunter✓", got)
+	}
+	if !bytes.Equal(buf, make([]byte, len(buf))) {
+		t.Errorf("Finish() did not zero the buffer: %q", buf)
+	}
+	if b.Len() != 0 {
+		t.Errorf("Len() after Finish() = %v, want 0", b.Len())
+	}
+}
+
+// TestBuilder_GrowZeroesOldBuffer verifies buffers discarded while growing are zeroed
+func TestBuilder_GrowZeroesOldBuffer(t *testing.T) {
+	var b Builder
+	b.WriteString("secret")
+	old := b.buf[:cap(b.buf)]
+
+	b.Grow(1024)
+	if !bytes.Equal(old, make([]byte, len(old))) {
+		t.Errorf("Grow() did not zero the old buffer: %q", old)
+	}
+	if got := string(b.buf); got != "secret" {
+		t.Errorf("buffer after Grow() = %q, want secret", got)
+	}
+}
+
+// TestBuilder_Copy verifies a Builder works as an io.Writer target
+func TestBuilder_Copy(t *testing.T) {
+	var b Builder
+	value := strings.Repeat("x", 10000)
+	if _, err := io.Copy(&b, strings.NewReader(value)); err != nil {
+		t.Fatalf("io.Copy() error = %v", err)
+	}
+	if got := b.Finish().Value(); got != value {
+		t.Errorf("Finish() after io.Copy() has length %v, want %v", len(got), len(value))
+	}
+}
@@ -0,0 +1,49 @@
+package sensitivestring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// newDefaultResolver returns a Resolver with the built-in env and file
+// providers registered.
+func newDefaultResolver() *Resolver {
+	r := NewResolver()
+	r.Register("env", EnvProvider{})
+	r.Register("file", FileProvider{})
+	return r
+}
+
+// EnvProvider resolves names to the values of environment variables. It is
+// registered as "env" with the DefaultResolver.
+type EnvProvider struct{}
+
+// Resolve implements Provider. An unset variable is reported as
+// ErrSecretNotFound; a variable set to the empty string resolves to an
+// empty secret.
+func (EnvProvider) Resolve(ctx context.Context, name string) (*SensitiveString, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: environment variable %s", ErrSecretNotFound, name)
+	}
+	return New(value, WithLabel(name)), nil
+}
+
+// FileProvider resolves names to the contents of the files at those paths,
+// read as by FromFile with WithTrimNewline: without the single trailing
+// newline editors and "echo" leave behind, and up to DefaultMaxSecretSize.
+// It is registered as "file" with the DefaultResolver, so "file:///path"
+// references the file at /path.
+type FileProvider struct{}
+
+// Resolve implements Provider.
+func (FileProvider) Resolve(ctx context.Context, name string) (*SensitiveString, error) {
+	s, err := FromFile(name, WithTrimNewline())
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: file %s", ErrSecretNotFound, name)
+	}
+	return s, err
+}
@@ -0,0 +1,73 @@
+package sensitivestring
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestParseRef_URI verifies the URI form parses to the scheme and the remainder
+func TestParseRef_URI(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want Ref
+	}{
+		{"env://DB_PASSWORD", Ref{Provider: "env", Name: "DB_PASSWORD"}},
+		{"file:///run/secrets/db", Ref{Provider: "file", Name: "/run/secrets/db"}},
+		{"vault://secret/data/db#password", Ref{Provider: "vault", Name: "secret/data/db#password"}},
+		{"aws-sm://arn:aws:secretsmanager:us-east-1:1:secret:db", Ref{Provider: "aws-sm", Name: "arn:aws:secretsmanager:us-east-1:1:secret:db"}},
+		{"static:db/password", Ref{Provider: "static", Name: "db/password"}},
+		{"static:http://example.com", Ref{Provider: "static", Name: "http://example.com"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseRef(tt.ref)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseRef(%q) = %+v, %v, want %+v", tt.ref, got, err, tt.want)
+		}
+	}
+	for _, ref := range []string{"env://", "://name"} {
+		if got, err := ParseRef(ref); err == nil {
+			t.Errorf("ParseRef(%q) = %+v, want an error", ref, got)
+		}
+	}
+	if got := (Ref{Provider: "file", Name: "/etc/key"}).URI(); got != "file:///etc/key" {
+		t.Errorf("URI() = %v, want file:///etc/key", got)
+	}
+}
+
+// TestDefaultResolver_Builtins verifies env and file references resolve without registration
+func TestDefaultResolver_Builtins(t *testing.T) {
+	withDefaultResolver(t, newDefaultResolver())
+	t.Setenv("SS_TEST_PASSWORD", "hunter2")
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("s3cret\r\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if s, err := Resolve(context.Background(), "env://SS_TEST_PASSWORD"); err != nil || s.Value() != "hunter2" {
+		t.Errorf("Resolve(env) = %v, %v, want hunter2", s, err)
+	}
+	if s, err := Resolve(context.Background(), "file://"+filepath.ToSlash(path)); err != nil || s.Value() != "s3cret" {
+		t.Errorf("Resolve(file) = %v, %v, want s3cret", s, err)
+	}
+	if _, err := Resolve(context.Background(), "env://SS_TEST_UNSET"); !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("Resolve(unset env) error = %v, want ErrSecretNotFound", err)
+	}
+	if _, err := Resolve(context.Background(), "file://"+filepath.Join(t.TempDir(), "missing")); !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("Resolve(missing file) error = %v, want ErrSecretNotFound", err)
+	}
+	large := filepath.Join(t.TempDir(), "large")
+	if err := os.WriteFile(large, make([]byte, DefaultMaxSecretSize+1), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Resolve(context.Background(), "file://"+filepath.ToSlash(large)); !errors.Is(err, ErrSecretTooLarge) {
+		t.Errorf("Resolve(oversized file) error = %v, want ErrSecretTooLarge", err)
+	}
+
+	got, err := ResolveAll(context.Background(), "env://SS_TEST_PASSWORD", "env:SS_TEST_PASSWORD")
+	if err != nil || got["env://SS_TEST_PASSWORD"].Value() != "hunter2" || got["env:SS_TEST_PASSWORD"].Value() != "hunter2" {
+		t.Errorf("ResolveAll() = %v, %v, want both spellings keyed as given", got, err)
+	}
+}
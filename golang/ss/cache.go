@@ -0,0 +1,155 @@
+package sensitivestring
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// CacheOption configures Cache.
+type CacheOption func(*cacheOptions)
+
+type cacheOptions struct {
+	refreshAhead time.Duration
+	maxStale     time.Duration
+}
+
+// WithRefreshAhead sets how long before expiry a Resolve that hits the
+// cache also starts a background refresh, so that secrets in steady use
+// never expire on the request path. The default is a fifth of the TTL.
+func WithRefreshAhead(d time.Duration) CacheOption {
+	return func(o *cacheOptions) { o.refreshAhead = d }
+}
+
+// WithMaxStale limits how long past expiry a secret is still served while
+// it is revalidated. Beyond it, Resolve waits for the provider and fails
+// with it. By default expired secrets are served for as long as an outage
+// lasts.
+func WithMaxStale(d time.Duration) CacheOption {
+	return func(o *cacheOptions) { o.maxStale = d }
+}
+
+// CachedProvider is the caching Provider returned by Cache.
+type CachedProvider struct {
+	provider Provider
+	ttl      time.Duration
+	options  cacheOptions
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	flights flightGroup
+}
+
+type cacheEntry struct {
+	secret     *SensitiveString
+	fetched    time.Time
+	refreshing bool
+}
+
+// Cache returns a Provider that memoizes the secrets provider resolves for
+// ttl, so hot request paths do not hit remote secret stores. Secrets are
+// refreshed in the background shortly before they expire (see
+// WithRefreshAhead). Once expired, a secret is still served immediately
+// while it is refreshed in the background (stale-while-revalidate; see
+// WithMaxStale), and for as long as provider fails, unless it reports
+// ErrSecretNotFound, which drops the secret. Time is read from the package
+// Clock.
+//
+// Concurrent misses for the same name share one resolution. Resolutions,
+// including background ones, are bounded by the resolve timeout the Cache
+// was reached through, e.g. the one it was registered with (see
+// WithResolveTimeout), or DefaultResolveTimeout. Every caller resolving a
+// name gets the same *SensitiveString until it is refreshed, so callers
+// must not Zero it.
+func Cache(provider Provider, ttl time.Duration, opts ...CacheOption) *CachedProvider {
+	options := cacheOptions{refreshAhead: ttl / 5}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &CachedProvider{provider: provider, ttl: ttl, options: options, entries: make(map[string]*cacheEntry)}
+}
+
+// Resolve implements Provider.
+func (c *CachedProvider) Resolve(ctx context.Context, name string) (*SensitiveString, error) {
+	now := GetClock().Now()
+	timeout := resolveTimeout(ctx)
+	c.mu.Lock()
+	if entry := c.entries[name]; entry != nil {
+		age := now.Sub(entry.fetched)
+		if age < c.ttl || c.options.maxStale == 0 || age < c.ttl+c.options.maxStale {
+			if age >= c.ttl-c.options.refreshAhead && !entry.refreshing {
+				entry.refreshing = true
+				go c.refresh(name, timeout)
+			}
+			c.mu.Unlock()
+			if age < c.ttl {
+				reportCacheStatus(ctx, CacheHit)
+			} else {
+				reportCacheStatus(ctx, CacheStale)
+			}
+			return entry.secret, nil
+		}
+	}
+	c.mu.Unlock()
+
+	s, err := c.resolve(ctx, name, timeout)
+	if err != nil {
+		return nil, err
+	}
+	reportCacheStatus(ctx, CacheMiss)
+	return s, nil
+}
+
+// resolve fetches name from the provider, within timeout and shared with
+// concurrent resolutions of it, and caches the result. ErrSecretNotFound
+// drops name from the cache.
+func (c *CachedProvider) resolve(ctx context.Context, name string, timeout time.Duration) (*SensitiveString, error) {
+	s, err := c.flights.resolve(ctx, name, func(ctx context.Context) (*SensitiveString, error) {
+		return resolveWithin(ctx, c.provider, name, timeout)
+	})
+	if errors.Is(err, ErrSecretNotFound) {
+		c.Invalidate(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.store(name, s)
+	return s, nil
+}
+
+// refresh resolves name in the background, within timeout. Other errors
+// than ErrSecretNotFound are dropped: the cached secret stays in place and
+// the next Resolve retries.
+func (c *CachedProvider) refresh(name string, timeout time.Duration) {
+	if _, err := c.resolve(context.Background(), name, timeout); err == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry := c.entries[name]; entry != nil {
+		entry.refreshing = false
+	}
+}
+
+// store caches s for name as of now.
+func (c *CachedProvider) store(name string, s *SensitiveString) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[name] = &cacheEntry{secret: s, fetched: GetClock().Now()}
+}
+
+// Invalidate drops name from the cache, so the next Resolve fetches it
+// from the provider, e.g. after a rotation is announced.
+func (c *CachedProvider) Invalidate(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, name)
+}
+
+// Purge drops every cached secret.
+func (c *CachedProvider) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+}
@@ -0,0 +1,166 @@
+package sensitivestring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// versionedProvider returns "v1", "v2", ... on successive calls, or err when set.
+type versionedProvider struct {
+	mu    sync.Mutex
+	calls int
+	err   error
+}
+
+func (p *versionedProvider) Resolve(ctx context.Context, name string) (*SensitiveString, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return nil, p.err
+	}
+	p.calls++
+	return New(fmt.Sprintf("v%d", p.calls)), nil
+}
+
+func (p *versionedProvider) fail(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.err = err
+}
+
+// withManualClock installs a ManualClock for the duration of a test.
+func withManualClock(t *testing.T) *ManualClock {
+	t.Helper()
+	clock := NewManualClock(time.Unix(1000, 0))
+	SetClock(clock)
+	t.Cleanup(func() { SetClock(nil) })
+	return clock
+}
+
+// cachedValue waits until the cache holds want for name.
+func cachedValue(t *testing.T, c *CachedProvider, name, want string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		c.mu.Lock()
+		entry := c.entries[name]
+		c.mu.Unlock()
+		if entry != nil && entry.secret.Value() == want {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("cache never held %s for %s", want, name)
+}
+
+// TestCache verifies memoization, background refresh ahead of expiry and invalidation
+func TestCache(t *testing.T) {
+	clock := withManualClock(t)
+	backend := &versionedProvider{}
+	c := Cache(backend, time.Minute)
+	ctx := context.Background()
+
+	for range 3 {
+		if s, err := c.Resolve(ctx, "db"); err != nil || s.Value() != "v1" {
+			t.Fatalf("Resolve() = %v, %v, want cached v1", s, err)
+		}
+	}
+
+	clock.Advance(50 * time.Second)
+	if s, _ := c.Resolve(ctx, "db"); s.Value() != "v1" {
+		t.Errorf("Resolve() during refresh-ahead = %v, want v1 served immediately", s.Value())
+	}
+	cachedValue(t, c, "db", "v2")
+	if s, _ := c.Resolve(ctx, "db"); s.Value() != "v2" {
+		t.Errorf("Resolve() after refresh = %v, want v2", s.Value())
+	}
+
+	c.Invalidate("db")
+	if s, _ := c.Resolve(ctx, "db"); s.Value() != "v3" {
+		t.Errorf("Resolve() after Invalidate = %v, want v3", s.Value())
+	}
+	c.Purge()
+	if s, _ := c.Resolve(ctx, "db"); s.Value() != "v4" {
+		t.Errorf("Resolve() after Purge = %v, want v4", s.Value())
+	}
+}
+
+// TestCache_StaleWhileOutage verifies expired secrets are served while the provider fails
+func TestCache_StaleWhileOutage(t *testing.T) {
+	clock := withManualClock(t)
+	backend := &versionedProvider{}
+	c := Cache(backend, time.Minute, WithRefreshAhead(0), WithMaxStale(time.Hour))
+	ctx := context.Background()
+	c.Resolve(ctx, "db")
+
+	backend.fail(errors.New("503 service unavailable"))
+	clock.Advance(2 * time.Minute)
+	if s, err := c.Resolve(ctx, "db"); err != nil || s.Value() != "v1" {
+		t.Errorf("Resolve() during outage = %v, %v, want stale v1", s, err)
+	}
+	clock.Advance(time.Hour)
+	if _, err := c.Resolve(ctx, "db"); err == nil {
+		t.Errorf("Resolve() beyond max stale error = nil")
+	}
+
+	backend.fail(fmt.Errorf("%w: db", ErrSecretNotFound))
+	c2 := Cache(&versionedProvider{}, time.Minute)
+	c2.Resolve(ctx, "db")
+	c2.provider = backend
+	clock.Advance(2 * time.Minute)
+	c2.Resolve(ctx, "db")
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, err := c2.Resolve(ctx, "db"); errors.Is(err, ErrSecretNotFound) {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Errorf("Resolve() of a deleted secret never failed with ErrSecretNotFound")
+}
+
+// TestCache_StaleWhileRevalidate verifies expired secrets are served without waiting for the provider
+func TestCache_StaleWhileRevalidate(t *testing.T) {
+	clock := withManualClock(t)
+	release := make(chan struct{})
+	calls := 0
+	c := Cache(ProviderFunc(func(ctx context.Context, name string) (*SensitiveString, error) {
+		calls++
+		if calls > 1 {
+			<-release
+		}
+		return New(fmt.Sprintf("v%d", calls)), nil
+	}), time.Minute, WithRefreshAhead(0))
+	ctx := context.Background()
+	c.Resolve(ctx, "db")
+
+	clock.Advance(2 * time.Minute)
+	if s, err := c.Resolve(ctx, "db"); err != nil || s.Value() != "v1" {
+		t.Errorf("Resolve() after expiry = %v, %v, want stale v1 served immediately", s, err)
+	}
+	close(release)
+	cachedValue(t, c, "db", "v2")
+}
+
+// TestCache_ResolveTimeout verifies provider calls are bounded by the timeout the cache was registered with
+func TestCache_ResolveTimeout(t *testing.T) {
+	deadlines := make(chan time.Time, 1)
+	c := Cache(ProviderFunc(func(ctx context.Context, name string) (*SensitiveString, error) {
+		deadline, _ := ctx.Deadline()
+		deadlines <- deadline
+		return New("foo"), nil
+	}), time.Minute)
+	r := NewResolver()
+	r.Register("cached", c, WithResolveTimeout(time.Hour))
+
+	if _, err := r.Resolve(context.Background(), "cached:db"); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if remaining := time.Until(<-deadlines); remaining < DefaultResolveTimeout {
+		t.Errorf("provider deadline in %v, want the registered hour", remaining)
+	}
+}
@@ -0,0 +1,53 @@
+// Package cborx integrates SensitiveString with github.com/fxamacker/cbor,
+// for secrets embedded in CBOR payloads such as COSE, CWT or IoT messages.
+//
+// A SensitiveString field is already redacted by that library, which
+// encodes it through MarshalBinary as an opaque byte string. Wrapping it in
+// Secret instead encodes the same value JSON uses: a text string holding
+// the hash form, or a map of the Redacted fields in MarshalStructured mode.
+package cborx
+
+import (
+	"github.com/fxamacker/cbor/v2"
+
+	ss "github.com/earlye/sensitive-strings/golang/ss"
+)
+
+// Secret wraps a SensitiveString so that it encodes as a native CBOR value.
+type Secret struct {
+	*ss.SensitiveString
+}
+
+// Wrap returns s as a Secret.
+func Wrap(s *ss.SensitiveString) Secret {
+	return Secret{s}
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+func (s Secret) MarshalCBOR() ([]byte, error) {
+	inner := s.SensitiveString
+	if inner == nil {
+		inner = ss.New("")
+	}
+	if r, ok := inner.RedactedForm(); ok && ss.GetMarshalMode() == ss.MarshalStructured {
+		return cbor.Marshal(r)
+	}
+	return cbor.Marshal(inner.String())
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler. Like UnmarshalJSON, a text
+// string becomes the value and a Redacted map becomes its "alg:hash" form
+// with the label preserved.
+func (s *Secret) UnmarshalCBOR(data []byte) error {
+	var text string
+	if err := cbor.Unmarshal(data, &text); err == nil {
+		s.SensitiveString = ss.New(text)
+		return nil
+	}
+	var r ss.Redacted
+	if err := cbor.Unmarshal(data, &r); err != nil {
+		return err
+	}
+	s.SensitiveString = ss.NewLabeled(r.Label, r.Alg+":"+r.Hash)
+	return nil
+}
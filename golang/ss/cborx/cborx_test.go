@@ -0,0 +1,79 @@
+package cborx
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+
+	ss "github.com/earlye/sensitive-strings/golang/ss"
+)
+
+type message struct {
+	Device string
+	Key    Secret
+	Raw    *ss.SensitiveString
+}
+
+// TestSecret_RoundTrip verifies secrets encode redacted and decode to their hash form
+func TestSecret_RoundTrip(t *testing.T) {
+	key := ss.NewLabeled("device-key", "hunter2")
+	data, err := cbor.Marshal(message{Device: "sensor-1", Key: Wrap(key), Raw: ss.New("hunter2")})
+	if err != nil {
+		t.Fatalf("cbor.Marshal() error = %v", err)
+	}
+	if bytes.Contains(data, []byte("hunter2")) {
+		t.Errorf("CBOR payload contains plaintext")
+	}
+
+	var got message
+	if err := cbor.Unmarshal(data, &got); err != nil {
+		t.Fatalf("cbor.Unmarshal() error = %v", err)
+	}
+	if got.Key.Value() != key.String() {
+		t.Errorf("Key = %v, want %v", got.Key.Value(), key.String())
+	}
+	if got.Raw.Value() != key.String() {
+		t.Errorf("Raw = %v, want %v", got.Raw.Value(), key.String())
+	}
+}
+
+// TestSecret_Structured verifies the structured mode encodes a Redacted map
+func TestSecret_Structured(t *testing.T) {
+	ss.SetMarshalMode(ss.MarshalStructured)
+	defer ss.SetMarshalMode(ss.MarshalHash)
+
+	key := ss.NewLabeled("device-key", "hunter2")
+	data, err := cbor.Marshal(Wrap(key))
+	if err != nil {
+		t.Fatalf("cbor.Marshal() error = %v", err)
+	}
+	var r ss.Redacted
+	if err := cbor.Unmarshal(data, &r); err != nil || r != key.Redacted() {
+		t.Errorf("decoded = %+v, %v, want %+v", r, err, key.Redacted())
+	}
+
+	var got Secret
+	if err := cbor.Unmarshal(data, &got); err != nil || got.Label() != "device-key" || got.Value() != key.String() {
+		t.Errorf("UnmarshalCBOR() = %v/%v, %v, want %v/device-key", got.Value(), got.Label(), err, key.String())
+	}
+}
+
+// TestSecret_StructuredRedactor verifies a Redactor suppresses the structured hash
+func TestSecret_StructuredRedactor(t *testing.T) {
+	ss.SetMarshalMode(ss.MarshalStructured)
+	ss.SetRedactor(ss.Placeholder(""))
+	t.Cleanup(func() {
+		ss.SetMarshalMode(ss.MarshalHash)
+		ss.SetRedactor(nil)
+	})
+
+	data, err := cbor.Marshal(Wrap(ss.New("hunter2")))
+	if err != nil {
+		t.Fatalf("cbor.Marshal() error = %v", err)
+	}
+	var text string
+	if err := cbor.Unmarshal(data, &text); err != nil || text != "[REDACTED]" {
+		t.Errorf("decoded = %q, %v, want [REDACTED]", text, err)
+	}
+}
@@ -0,0 +1,48 @@
+package sensitivestring
+
+import (
+	"context"
+	"errors"
+)
+
+// chainProvider is the Provider returned by ChainProvider.
+type chainProvider []Provider
+
+// ChainProvider returns a Provider that tries providers in order and
+// returns the first secret resolved, e.g. to fall back to environment
+// variables in local development while production resolves from Vault:
+//
+//	RegisterProvider("secrets", ChainProvider(vault, files, env))
+//
+// If every provider fails, the error wraps ErrSecretNotFound only if every
+// one of them reported it. Otherwise it joins the other errors, so that an
+// outage of one backend is not mistaken for a missing secret and stays
+// retryable. A canceled or expired ctx stops the chain at once.
+func ChainProvider(providers ...Provider) Provider {
+	return chainProvider(providers)
+}
+
+// Resolve implements Provider.
+func (c chainProvider) Resolve(ctx context.Context, name string) (*SensitiveString, error) {
+	var errs, failures []error
+	for _, p := range c {
+		s, err := p.Resolve(ctx, name)
+		if err == nil {
+			return s, nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		errs = append(errs, err)
+		if !errors.Is(err, ErrSecretNotFound) {
+			failures = append(failures, err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil, errors.New("sensitivestring: empty provider chain")
+	}
+	if len(failures) > 0 {
+		return nil, errors.Join(failures...)
+	}
+	return nil, errors.Join(errs...)
+}
@@ -0,0 +1,89 @@
+package sensitivestring
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestChainProvider verifies providers are tried in order and errors are aggregated
+func TestChainProvider(t *testing.T) {
+	down := errors.New("vault sealed")
+	vault := ProviderFunc(func(ctx context.Context, name string) (*SensitiveString, error) {
+		if name == "shared" {
+			return New("from-vault"), nil
+		}
+		return nil, down
+	})
+	env := MapProvider{"shared": New("from-env"), "local": New("from-env")}
+	chain := ChainProvider(vault, env)
+
+	for name, want := range map[string]string{"shared": "from-vault", "local": "from-env"} {
+		if s, err := chain.Resolve(context.Background(), name); err != nil || s.Value() != want {
+			t.Errorf("Resolve(%s) = %v, %v, want %s", name, s, err, want)
+		}
+	}
+	_, err := chain.Resolve(context.Background(), "missing")
+	if !errors.Is(err, down) || errors.Is(err, ErrSecretNotFound) || !IsRetryable(err) {
+		t.Errorf("Resolve(missing) with vault down error = %v, want the retryable vault error only", err)
+	}
+	_, err = ChainProvider(MapProvider{}, env).Resolve(context.Background(), "missing")
+	if !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("Resolve(missing) error = %v, want ErrSecretNotFound", err)
+	}
+	if _, err := ChainProvider().Resolve(context.Background(), "x"); err == nil {
+		t.Errorf("empty chain error = nil")
+	}
+}
+
+// TestChainProvider_Canceled verifies cancellation stops the chain
+func TestChainProvider_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	first := ProviderFunc(func(context.Context, string) (*SensitiveString, error) {
+		calls++
+		cancel()
+		return nil, errors.New("interrupted")
+	})
+	second := ProviderFunc(func(context.Context, string) (*SensitiveString, error) {
+		calls++
+		return New("late"), nil
+	})
+	if _, err := ChainProvider(first, second).Resolve(ctx, "x"); !errors.Is(err, context.Canceled) || calls != 1 {
+		t.Errorf("Resolve() = %v after %d calls, want context.Canceled after 1", err, calls)
+	}
+}
+
+// TestChainProvider_CachedOutage verifies a cached secret survives an outage of one provider while another lacks it
+func TestChainProvider_CachedOutage(t *testing.T) {
+	clock := withManualClock(t)
+	backend := &versionedProvider{}
+	refreshed := make(chan struct{}, 1)
+	failing := ProviderFunc(func(ctx context.Context, name string) (*SensitiveString, error) {
+		s, err := backend.Resolve(ctx, name)
+		if err != nil {
+			select {
+			case refreshed <- struct{}{}:
+			default:
+			}
+		}
+		return s, err
+	})
+	c := Cache(ChainProvider(failing, MapProvider{}), time.Minute, WithRefreshAhead(0), WithMaxStale(time.Hour))
+	ctx := context.Background()
+	c.Resolve(ctx, "db")
+
+	backend.fail(errors.New("503 service unavailable"))
+	clock.Advance(2 * time.Minute)
+	if s, err := c.Resolve(ctx, "db"); err != nil || s.Value() != "v1" {
+		t.Fatalf("Resolve() during outage = %v, %v, want stale v1", s, err)
+	}
+	<-refreshed
+	for range 20 {
+		time.Sleep(time.Millisecond)
+		if s, err := c.Resolve(ctx, "db"); err != nil || s.Value() != "v1" {
+			t.Fatalf("Resolve() after a failed refresh = %v, %v, want stale v1", s, err)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package sensitivestring
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidSecret is wrapped by every error returned by a Constraint.
+var ErrInvalidSecret = errors.New("sensitivestring: invalid secret")
+
+// Constraint validates a secret value. Errors returned by a Constraint wrap
+// ErrInvalidSecret and never include the value itself.
+type Constraint func(value string) error
+
+// NonEmpty rejects the empty string.
+func NonEmpty() Constraint {
+	return func(value string) error {
+		if value == "" {
+			return fmt.Errorf("%w: value is empty", ErrInvalidSecret)
+		}
+		return nil
+	}
+}
+
+// MinLength rejects values with fewer than n characters.
+func MinLength(n int) Constraint {
+	return func(value string) error {
+		if utf8.RuneCountInString(value) < n {
+			return fmt.Errorf("%w: shorter than %d characters", ErrInvalidSecret, n)
+		}
+		return nil
+	}
+}
+
+// MaxLength rejects values with more than n characters.
+func MaxLength(n int) Constraint {
+	return func(value string) error {
+		if utf8.RuneCountInString(value) > n {
+			return fmt.Errorf("%w: longer than %d characters", ErrInvalidSecret, n)
+		}
+		return nil
+	}
+}
+
+// Charset rejects values containing characters not in allowed.
+func Charset(allowed string) Constraint {
+	return func(value string) error {
+		for _, r := range value {
+			if !strings.ContainsRune(allowed, r) {
+				return fmt.Errorf("%w: contains a character outside the allowed set", ErrInvalidSecret)
+			}
+		}
+		return nil
+	}
+}
+
+// Format rejects values that do not match pattern. Anchor the pattern with
+// ^ and $ to require a full match.
+func Format(pattern *regexp.Regexp) Constraint {
+	return func(value string) error {
+		if !pattern.MatchString(value) {
+			return fmt.Errorf("%w: does not match the expected format", ErrInvalidSecret)
+		}
+		return nil
+	}
+}
+
+// NewChecked creates a new SensitiveString after validating value against
+// every constraint, so invalid secrets are rejected at load time instead of
+// failing deep in an authentication call. All violations are joined into
+// the returned error.
+func NewChecked(value string, constraints ...Constraint) (*SensitiveString, error) {
+	var errs []error
+	for _, constraint := range constraints {
+		if err := constraint(value); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return New(value), nil
+}
+
+// NewNonEmpty creates a new SensitiveString, rejecting the empty string.
+func NewNonEmpty(value string) (*SensitiveString, error) {
+	return NewChecked(value, NonEmpty())
+}
+
+// MustNonEmpty creates a new SensitiveString.
+// Panics if value is empty.
+func MustNonEmpty(value string) *SensitiveString {
+	ss, err := NewNonEmpty(value)
+	if err != nil {
+		panic("MustNonEmpty: " + err.Error())
+	}
+	return ss
+}
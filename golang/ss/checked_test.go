@@ -0,0 +1,76 @@
+package sensitivestring
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestNewChecked verifies each constraint accepts and rejects as expected
+func TestNewChecked(t *testing.T) {
+	hex := "0123456789abcdef"
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+	tests := []struct {
+		name        string
+		value       string
+		constraints []Constraint
+		valid       bool
+	}{
+		{"no constraints", "", nil, true},
+		{"non-empty ok", "x", []Constraint{NonEmpty()}, true},
+		{"non-empty fail", "", []Constraint{NonEmpty()}, false},
+		{"min ok", "abcd", []Constraint{MinLength(4)}, true},
+		{"min counts runes", "ééé", []Constraint{MinLength(4)}, false},
+		{"max ok", "ééé", []Constraint{MaxLength(3)}, true},
+		{"max fail", "abcd", []Constraint{MaxLength(3)}, false},
+		{"charset ok", "deadbeef", []Constraint{Charset(hex)}, true},
+		{"charset fail", "deadbeeg", []Constraint{Charset(hex)}, false},
+		{"format ok", "2cbd047f-005c-4dc6-ae66-5b9d8c1e709f", []Constraint{Format(uuid)}, true},
+		{"format fail", "2cbd047f", []Constraint{Format(uuid)}, false},
+	}
+	for _, tt := range tests {
+		ss, err := NewChecked(tt.value, tt.constraints...)
+		if tt.valid {
+			if err != nil || ss.Value() != tt.value {
+				t.Errorf("NewChecked(%s) = (%v, %v), want valid", tt.name, ss, err)
+			}
+			continue
+		}
+		if ss != nil || !errors.Is(err, ErrInvalidSecret) {
+			t.Errorf("NewChecked(%s) = (%v, %v), want ErrInvalidSecret", tt.name, ss, err)
+		}
+	}
+}
+
+// TestNewChecked_JoinsErrors verifies all violations are reported without the value
+func TestNewChecked_JoinsErrors(t *testing.T) {
+	_, err := NewChecked("secret!", MinLength(10), Charset("abcdefghijklmnopqrstuvwxyz"))
+	if err == nil {
+		t.Fatalf("NewChecked() error = nil, want error")
+	}
+	if got := strings.Count(err.Error(), ErrInvalidSecret.Error()); got != 2 {
+		t.Errorf("NewChecked() error should report 2 violations, got: %v", err)
+	}
+	if strings.Contains(err.Error(), "secret!") {
+		t.Errorf("NewChecked() error leaked the value: %v", err)
+	}
+}
+
+// TestMustNonEmpty verifies MustNonEmpty returns values and panics on empty input
+func TestMustNonEmpty(t *testing.T) {
+	if got := MustNonEmpty("foo").Value(); got != "foo" {
+		t.Errorf("MustNonEmpty(foo).Value() = %v, want foo", got)
+	}
+	if _, err := NewNonEmpty(""); !errors.Is(err, ErrInvalidSecret) {
+		t.Errorf("NewNonEmpty(\"\") error = %v, want ErrInvalidSecret", err)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("MustNonEmpty(\"\") should panic")
+		}
+	}()
+	MustNonEmpty("")
+}
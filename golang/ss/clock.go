@@ -0,0 +1,109 @@
+package sensitivestring
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the source of time for every time-dependent feature of the
+// package (expiry, leases, rotation schedules, cache TTLs). Install a
+// ManualClock with SetClock to test such behavior deterministically.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// systemClock is the Clock backed by the time package.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// SystemClock is the default Clock, backed by the time package.
+var SystemClock Clock = systemClock{}
+
+// SetClock sets the package-wide Clock. A nil clock restores SystemClock.
+func SetClock(c Clock) {
+	if c == nil {
+		c = SystemClock
+	}
+	updateConfig(func(cfg *config) { cfg.clock = c })
+}
+
+// GetClock returns the package-wide Clock.
+func GetClock() Clock {
+	return loadConfig().clock
+}
+
+// ManualClock is a Clock whose time only moves when Advance or Set is
+// called, for deterministic tests of time-dependent secret behavior.
+type ManualClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []manualWaiter
+}
+
+type manualWaiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+// NewManualClock creates a ManualClock set to now.
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{now: now}
+}
+
+// Now returns the clock's current time.
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After returns a channel that receives the clock's time once it has been
+// advanced by at least d.
+func (c *ManualClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, manualWaiter{deadline: c.now.Add(d), ch: ch})
+	return ch
+}
+
+// Advance moves the clock forward by d, firing any After channels whose
+// deadline has been reached.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	now := c.now.Add(d)
+	c.mu.Unlock()
+	c.Set(now)
+}
+
+// Set moves the clock to now, firing any After channels whose deadline has
+// been reached.
+func (c *ManualClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+	pending := c.waiters[:0]
+	for _, w := range c.waiters {
+		if now.Before(w.deadline) {
+			pending = append(pending, w)
+			continue
+		}
+		w.ch <- now
+	}
+	c.waiters = pending
+}
+
+// Waiters returns the number of After channels that have not fired yet,
+// so tests can wait until code under test is blocked on the clock.
+func (c *ManualClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
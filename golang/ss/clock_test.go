@@ -0,0 +1,69 @@
+package sensitivestring
+
+import (
+	"testing"
+	"time"
+)
+
+// TestSetClock verifies the package-wide clock can be replaced and restored
+func TestSetClock(t *testing.T) {
+	clock := NewManualClock(time.Unix(1000, 0))
+	SetClock(clock)
+	t.Cleanup(func() { SetClock(nil) })
+
+	if GetClock() != clock {
+		t.Errorf("GetClock() = %v, want the manual clock", GetClock())
+	}
+	SetClock(nil)
+	if GetClock() != SystemClock {
+		t.Errorf("SetClock(nil) should restore SystemClock")
+	}
+}
+
+// TestManualClock verifies Now, After and Advance
+func TestManualClock(t *testing.T) {
+	start := time.Unix(1000, 0)
+	clock := NewManualClock(start)
+
+	if got := clock.Now(); !got.Equal(start) {
+		t.Errorf("Now() = %v, want %v", got, start)
+	}
+
+	immediate := clock.After(0)
+	short := clock.After(time.Second)
+	long := clock.After(time.Minute)
+	if got := clock.Waiters(); got != 2 {
+		t.Errorf("Waiters() = %v, want 2", got)
+	}
+
+	select {
+	case <-immediate:
+	default:
+		t.Errorf("After(0) should fire immediately")
+	}
+
+	clock.Advance(2 * time.Second)
+	select {
+	case got := <-short:
+		if !got.Equal(start.Add(2 * time.Second)) {
+			t.Errorf("After(1s) fired with %v, want %v", got, start.Add(2*time.Second))
+		}
+	default:
+		t.Errorf("After(1s) should fire after Advance(2s)")
+	}
+	select {
+	case <-long:
+		t.Errorf("After(1m) should not fire after Advance(2s)")
+	default:
+	}
+
+	clock.Set(start.Add(time.Hour))
+	select {
+	case <-long:
+	default:
+		t.Errorf("After(1m) should fire after Set(+1h)")
+	}
+	if got := clock.Waiters(); got != 0 {
+		t.Errorf("Waiters() = %v, want 0", got)
+	}
+}
@@ -0,0 +1,206 @@
+package sensitivestring
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// SameSecret reports whether a and b refer to the same secret. Each of a
+// and b may be a *SensitiveString, a SensitiveString, a plaintext string, or
+// a digest with an unsalted algorithm, given as a Redacted (or *Redacted)
+// or as a string in redacted form such as "sha256:<hex>" (see
+// IsRedactedForm). A plaintext string that looks like a redacted form is
+// therefore compared as a digest. Plaintext is compared through its digest (SHA256, or the algorithm of a
+// Redacted input) using crypto/subtle, so neither the content nor the
+// length of the secrets leaks through timing. nil or unsupported inputs never
+// match.
+func SameSecret(a, b interface{}) bool {
+	return SameSecretNormalized(a, b)
+}
+
+// Equal reports whether s and other hold the same plaintext, in constant
+// time: both values are hashed with SHA256 and the digests compared with
+// crypto/subtle, so neither their content nor their lengths leak through
+// timing. Two nil secrets are equal; a nil and a non-nil one are not.
+func (s *SensitiveString) Equal(other *SensitiveString) bool {
+	if s == nil || other == nil {
+		return s == other
+	}
+	var a, b [sha256.Size]byte
+	s.usePlaintext(func(p []byte) { a = sha256.Sum256(p) })
+	other.usePlaintext(func(p []byte) { b = sha256.Sum256(p) })
+	return subtle.ConstantTimeCompare(a[:], b[:]) == 1
+}
+
+// EqualString reports whether s holds the plaintext value, in constant time
+// like Equal, e.g. to check a token presented by a client. A nil s equals
+// no string.
+func (s *SensitiveString) EqualString(value string) bool {
+	if s == nil {
+		return false
+	}
+	var a [sha256.Size]byte
+	s.usePlaintext(func(p []byte) { a = sha256.Sum256(p) })
+	b := sha256.Sum256([]byte(value))
+	return subtle.ConstantTimeCompare(a[:], b[:]) == 1
+}
+
+// VerifyHash reports whether fingerprint, a redacted form such as
+// "sha256:<hex>" persisted earlier, was computed from this secret, so
+// systems that stored only the redacted form can later confirm it is the
+// same secret. Every supported algorithm is accepted. Keyed ("hmac-…")
+// and salted ("salted-…") forms only verify under the same fingerprint key
+// or process salt that produced them. The comparison is constant-time.
+func (s *SensitiveString) VerifyHash(fingerprint string) bool {
+	if s == nil {
+		return false
+	}
+	var ok bool
+	s.usePlaintext(func(p []byte) { ok = verifyFingerprint(p, fingerprint) })
+	return ok
+}
+
+// Verify reports whether fingerprint was computed from the plaintext value.
+// See VerifyHash.
+func Verify(value string, fingerprint string) bool {
+	return verifyFingerprint([]byte(value), fingerprint)
+}
+
+// verifyFingerprint recomputes the digest of data named by fingerprint and
+// compares it with the one fingerprint carries.
+func verifyFingerprint(data []byte, fingerprint string) bool {
+	name, hexDigest, ok := strings.Cut(fingerprint, ":")
+	if !ok {
+		return false
+	}
+	want, err := hex.DecodeString(hexDigest)
+	if err != nil || len(want) != digestSizes[name] {
+		return false
+	}
+	got, ok := digestNamed(name, data)
+	return ok && subtle.ConstantTimeCompare(got, want) == 1
+}
+
+// Normalization transforms plaintext before comparison.
+type Normalization func(string) string
+
+var (
+	// FoldCase applies Unicode case folding, for secrets such as license
+	// keys that are case-insensitive by spec.
+	FoldCase Normalization = cases.Fold().String
+
+	// NFC converts to Unicode Normalization Form C, so composed and
+	// decomposed spellings of the same characters compare equal.
+	NFC Normalization = norm.NFC.String
+
+	// TrimTrailingSpace removes trailing whitespace, such as the newline
+	// left behind when a secret is read from a file.
+	TrimTrailingSpace Normalization = func(s string) string {
+		return strings.TrimRightFunc(s, unicode.IsSpace)
+	}
+)
+
+// SameSecretNormalized is like SameSecret, but applies normalizations in
+// order to every plaintext input before comparing. Digests are used as-is,
+// so they must have been computed over the normalized form. With no
+// normalizations it is identical to the strict SameSecret.
+func SameSecretNormalized(a, b interface{}, normalizations ...Normalization) bool {
+	alg := comparisonAlgorithm(a, b)
+	digestA, ok := secretDigest(a, alg, normalizations)
+	if !ok {
+		return false
+	}
+	digestB, ok := secretDigest(b, alg, normalizations)
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare(digestA, digestB) == 1
+}
+
+// comparisonAlgorithm returns the algorithm both inputs must be digested
+// with: that of a digest input, or SHA256.
+func comparisonAlgorithm(a, b interface{}) HashAlgorithm {
+	for _, input := range []interface{}{a, b} {
+		if r, ok := digestInput(input); ok {
+			return HashAlgorithm(r.Alg)
+		}
+	}
+	return SHA256
+}
+
+// digestInput returns the digest carried by input, if it is a Redacted, a
+// non-nil *Redacted or a string in redacted form.
+func digestInput(input interface{}) (Redacted, bool) {
+	switch v := input.(type) {
+	case Redacted:
+		return v, true
+	case *Redacted:
+		if v != nil {
+			return *v, true
+		}
+	case string:
+		if IsRedactedForm(v) {
+			alg, hash, _ := strings.Cut(v, ":")
+			return Redacted{Alg: alg, Hash: hash}, true
+		}
+	}
+	return Redacted{}, false
+}
+
+// secretDigest returns the digest under alg identifying input, normalizing
+// plaintext inputs first.
+func secretDigest(input interface{}, alg HashAlgorithm, normalizations []Normalization) ([]byte, bool) {
+	if r, ok := digestInput(input); ok {
+		return redactedDigest(r, alg)
+	}
+	switch v := input.(type) {
+	case *SensitiveString:
+		if v == nil {
+			return nil, false
+		}
+		return plaintextDigest(normalize(v.plaintext(), normalizations), alg)
+	case SensitiveString:
+		return plaintextDigest(normalize(v.plaintext(), normalizations), alg)
+	case string:
+		return plaintextDigest(normalize(v, normalizations), alg)
+	default:
+		return nil, false
+	}
+}
+
+// normalize applies normalizations to value in order.
+func normalize(value string, normalizations []Normalization) string {
+	for _, n := range normalizations {
+		value = n(value)
+	}
+	return value
+}
+
+// plaintextDigest returns the digest of value under alg.
+func plaintextDigest(value string, alg HashAlgorithm) ([]byte, bool) {
+	newHash := hashConstructors[alg]
+	if newHash == nil {
+		return nil, false
+	}
+	h := newHash()
+	h.Write([]byte(value))
+	return h.Sum(nil), true
+}
+
+// redactedDigest decodes the digest carried by r, which must use alg.
+func redactedDigest(r Redacted, alg HashAlgorithm) ([]byte, bool) {
+	if HashAlgorithm(r.Alg) != alg || hashConstructors[alg] == nil {
+		return nil, false
+	}
+	digest, err := hex.DecodeString(r.Hash)
+	if err != nil || len(digest) != digestSizes[r.Alg] {
+		return nil, false
+	}
+	return digest, true
+}
@@ -0,0 +1,158 @@
+package sensitivestring
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestSameSecret verifies every supported combination of inputs
+func TestSameSecret(t *testing.T) {
+	digest := New("foo").Redacted()
+	other := New("bar").Redacted()
+
+	tests := []struct {
+		name string
+		a, b interface{}
+		want bool
+	}{
+		{"secret/secret", New("foo"), New("foo"), true},
+		{"secret/value", New("foo"), *New("foo"), true},
+		{"secret/string", New("foo"), "foo", true},
+		{"string/string", "foo", "foo", true},
+		{"secret/digest", New("foo"), digest, true},
+		{"string/digest pointer", "foo", &digest, true},
+		{"digest/digest", digest, New("foo").Redacted(), true},
+		{"secret/redacted string", New("foo"), New("foo").String(), true},
+		{"string/redacted string", "foo", "sha256:" + fooHashHex, true},
+		{"redacted string/digest", New("foo").String(), digest, true},
+		{"sha512 redacted string", New("foo"), New("foo", WithHashAlgorithm(SHA512)).String(), true},
+		{"different redacted string", New("foo"), New("bar").String(), false},
+		{"salted redacted string", New("foo"), "salted-sha256:" + fooHashHex, false},
+		{"different secrets", New("foo"), New("bar"), false},
+		{"different lengths", New("foo"), "fooo", false},
+		{"different digest", New("foo"), other, false},
+		{"unknown algorithm", New("foo"), Redacted{Alg: "md5", Hash: digest.Hash}, false},
+		{"malformed digest", New("foo"), Redacted{Alg: "sha256", Hash: "zz"}, false},
+		{"nil", nil, New("foo"), false},
+		{"nil secret", (*SensitiveString)(nil), "", false},
+		{"nil digest", (*Redacted)(nil), "", false},
+		{"unsupported", 42, "42", false},
+	}
+	for _, tt := range tests {
+		if got := SameSecret(tt.a, tt.b); got != tt.want {
+			t.Errorf("SameSecret(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestSameSecretNormalized verifies each normalization and that the default stays strict
+func TestSameSecretNormalized(t *testing.T) {
+	tests := []struct {
+		name           string
+		a, b           string
+		normalizations []Normalization
+		want           bool
+	}{
+		{"strict case", "ABCD-efgh", "abcd-EFGH", nil, false},
+		{"fold case", "ABCD-efgh", "abcd-EFGH", []Normalization{FoldCase}, true},
+		{"fold case special", "STRASSE", "straße", []Normalization{FoldCase}, true},
+		{"strict nfc", "caf\u00e9", "cafe\u0301", nil, false},
+		{"nfc", "caf\u00e9", "cafe\u0301", []Normalization{NFC}, true},
+		{"strict trailing space", "token\n", "token", nil, false},
+		{"trailing space", "token \r\n", "token", []Normalization{TrimTrailingSpace}, true},
+		{"leading space kept", " token", "token", []Normalization{TrimTrailingSpace}, false},
+		{"combined", "CAFÉ\n", "café", []Normalization{NFC, FoldCase, TrimTrailingSpace}, true},
+	}
+	for _, tt := range tests {
+		if got := SameSecretNormalized(New(tt.a), tt.b, tt.normalizations...); got != tt.want {
+			t.Errorf("SameSecretNormalized(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	digest := New("abcd").Redacted()
+	if !SameSecretNormalized("ABCD", digest, FoldCase) {
+		t.Errorf("SameSecretNormalized(string, normalized digest) = false, want true")
+	}
+}
+
+// TestEqual verifies constant-time equality between secrets and strings
+func TestEqual(t *testing.T) {
+	foo := New("foo")
+	tests := []struct {
+		name string
+		a, b *SensitiveString
+		want bool
+	}{
+		{"same value", foo, New("foo"), true},
+		{"itself", foo, foo, true},
+		{"different value", foo, New("bar"), false},
+		{"prefix", foo, New("fo"), false},
+		{"different options", foo, New("foo", WithHashAlgorithm(SHA512), WithLabel("x")), true},
+		{"nil/nil", nil, nil, true},
+		{"nil/value", nil, foo, false},
+		{"value/nil", foo, nil, false},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Equal(tt.b); got != tt.want {
+			t.Errorf("%s: Equal() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if !foo.EqualString("foo") || foo.EqualString("foo ") || foo.EqualString("") {
+		t.Errorf("EqualString() does not match exactly the plaintext")
+	}
+	if (*SensitiveString)(nil).EqualString("") {
+		t.Errorf("nil EqualString(\"\") = true, want false")
+	}
+}
+
+// TestVerifyHash verifies stored fingerprints of every kind against their secret
+func TestVerifyHash(t *testing.T) {
+	foo := New("foo")
+	tests := []struct {
+		name        string
+		fingerprint string
+		want        bool
+	}{
+		{"sha256", "sha256:" + fooHashHex, true},
+		{"sha512", New("foo", WithHashAlgorithm(SHA512)).String(), true},
+		{"blake2b", New("foo", WithHashAlgorithm(BLAKE2b256)).String(), true},
+		{"other secret", New("bar").String(), false},
+		{"uppercase hex", "sha256:" + strings.ToUpper(fooHashHex), true},
+		{"truncated", "sha256:" + fooHashHex[:60], false},
+		{"unknown algorithm", "md5:acbd18db4cc2f85cedef654fccc4a4d8", false},
+		{"no separator", fooHashHex, false},
+		{"not hex", "sha256:" + strings.Repeat("z", 64), false},
+	}
+	for _, tt := range tests {
+		if got := foo.VerifyHash(tt.fingerprint); got != tt.want {
+			t.Errorf("%s: VerifyHash(%q) = %v, want %v", tt.name, tt.fingerprint, got, tt.want)
+		}
+		if got := Verify("foo", tt.fingerprint); got != tt.want {
+			t.Errorf("%s: Verify(%q) = %v, want %v", tt.name, tt.fingerprint, got, tt.want)
+		}
+	}
+	if (*SensitiveString)(nil).VerifyHash("sha256:" + fooHashHex) {
+		t.Errorf("nil VerifyHash() = true, want false")
+	}
+}
+
+// TestVerifyHash_Keyed verifies keyed fingerprints only verify under their key
+func TestVerifyHash_Keyed(t *testing.T) {
+	withFingerprintKey(t, []byte("pepper"))
+	keyed := New("foo").String()
+	if !Verify("foo", keyed) || Verify("bar", keyed) {
+		t.Errorf("Verify() of keyed fingerprint under its key is wrong")
+	}
+	SetFingerprintKey([]byte("other"))
+	if Verify("foo", keyed) {
+		t.Errorf("Verify() = true under a different key")
+	}
+	SetFingerprintKey(nil)
+	if Verify("foo", keyed) {
+		t.Errorf("Verify() = true without a key")
+	}
+	if !Verify("foo", "sha256:"+fooHashHex) {
+		t.Errorf("Verify() of a plain fingerprint = false")
+	}
+}
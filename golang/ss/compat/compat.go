@@ -0,0 +1,18 @@
+// Package compat provides type aliases under the names other secret-wrapper
+// libraries commonly use, so a codebase can switch its imports to this
+// package first and rename types later. The aliases are identical to
+// SensitiveString; values convert freely between the two names.
+package compat
+
+import ss "github.com/earlye/sensitive-strings/golang/ss"
+
+// Secret is an alias for SensitiveString.
+type Secret = ss.SensitiveString
+
+// SecretString is an alias for SensitiveString.
+type SecretString = ss.SensitiveString
+
+// NewSecret is like ss.New.
+func NewSecret(value string) *Secret {
+	return ss.New(value)
+}
@@ -0,0 +1,19 @@
+package compat
+
+import (
+	"testing"
+
+	ss "github.com/earlye/sensitive-strings/golang/ss"
+)
+
+// TestSecret_Alias verifies the aliases are interchangeable with SensitiveString
+func TestSecret_Alias(t *testing.T) {
+	var s *ss.SensitiveString = NewSecret("hunter2")
+	var alias *SecretString = s
+	if alias.Value() != "hunter2" {
+		t.Errorf("Value() = %v, want hunter2", alias.Value())
+	}
+	if alias.String() != ss.New("hunter2").String() {
+		t.Errorf("String() = %v, want the hash form", alias.String())
+	}
+}
@@ -0,0 +1,70 @@
+package sensitivestring
+
+import "sync/atomic"
+
+// config holds all package-level configuration. A config is never modified
+// once published; updates copy it, apply the change and swap the pointer, so
+// readers on hot paths only pay for an atomic load and never observe a
+// partially applied change.
+type config struct {
+	marshalMode MarshalMode
+	registry    *Registry
+	resolver    *Resolver
+	salt        []byte
+	clock       Clock
+	observer    RedactionObserver
+
+	resolveObserver ResolveObserver
+
+	unmarshalCompat UnmarshalCompat
+	decodeMode      DecodeMode
+	redactor        Redactor
+	maskOptions     MaskOptions
+	hashAlgorithm   HashAlgorithm
+	fingerprintKey  []byte
+
+	fingerprintLength int
+	hideLen           bool
+}
+
+// currentConfig is initialized in its declaration rather than in init() so
+// that it is ready for other package-level variables that create or render
+// SensitiveStrings.
+var currentConfig = newConfigPointer(&config{
+	marshalMode:   MarshalHash,
+	registry:      NewRegistry(),
+	resolver:      newDefaultResolver(),
+	clock:         SystemClock,
+	maskOptions:   MaskOptions{ShowLast: 4},
+	hashAlgorithm: SHA256,
+
+	fingerprintLength: DefaultFingerprintLength,
+})
+
+// newConfigPointer returns an atomic pointer holding c.
+func newConfigPointer(c *config) *atomic.Pointer[config] {
+	p := new(atomic.Pointer[config])
+	p.Store(c)
+	return p
+}
+
+// loadConfig returns the current package-level configuration. The result
+// must be treated as read-only.
+func loadConfig() *config {
+	return currentConfig.Load()
+}
+
+// updateConfig atomically replaces the package-level configuration with a
+// copy modified by update. update may be called more than once if another
+// goroutine changes the configuration concurrently, so it must not have side
+// effects.
+func updateConfig(update func(*config)) {
+	for {
+		previous := currentConfig.Load()
+		next := *previous
+		update(&next)
+		if currentConfig.CompareAndSwap(previous, &next) {
+			return
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package sensitivestring
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+)
+
+// TestUpdateConfig_Concurrent verifies concurrent updates are never lost
+func TestUpdateConfig_Concurrent(t *testing.T) {
+	previous := loadConfig()
+	t.Cleanup(func() { currentConfig.Store(previous) })
+
+	const goroutines = 8
+	const updates = 1000
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < updates; j++ {
+				updateConfig(func(c *config) { c.marshalMode++ })
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got, want := loadConfig().marshalMode, previous.marshalMode+goroutines*updates; got != want {
+		t.Errorf("marshalMode after concurrent updates = %v, want %v", got, want)
+	}
+	if previous.marshalMode != MarshalHash {
+		t.Errorf("updateConfig modified a published config")
+	}
+}
+
+// TestSetMarshalMode_ConcurrentMarshal verifies marshaling while the mode changes
+func TestSetMarshalMode_ConcurrentMarshal(t *testing.T) {
+	withMarshalMode(t, MarshalHash)
+	ss := New("foo")
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			SetMarshalMode(MarshalMode(i % 2))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			if _, err := json.Marshal(ss); err != nil {
+				t.Errorf("json.Marshal() error = %v", err)
+				return
+			}
+		}
+	}()
+	wg.Wait()
+}
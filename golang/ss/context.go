@@ -0,0 +1,30 @@
+package sensitivestring
+
+import "context"
+
+// secretContextKey namespaces secrets stored in a context.Context so that
+// they cannot collide with other packages' context keys.
+type secretContextKey struct {
+	name string
+}
+
+// String implements fmt.Stringer so that a logged context shows which
+// secret it carries.
+func (k secretContextKey) String() string {
+	return "sensitivestring.secret(" + k.name + ")"
+}
+
+// ContextWithSecret returns a copy of ctx carrying ss under key, for passing
+// request-scoped credentials through middleware stacks. If the context is
+// ever formatted (e.g. logged with %v), the secret renders as its hash
+// because context.Context uses the value's String method.
+func ContextWithSecret(ctx context.Context, key string, ss *SensitiveString) context.Context {
+	return context.WithValue(ctx, secretContextKey{name: key}, ss)
+}
+
+// SecretFromContext returns the secret stored in ctx under key by
+// ContextWithSecret, and whether one was found.
+func SecretFromContext(ctx context.Context, key string) (*SensitiveString, bool) {
+	ss, ok := ctx.Value(secretContextKey{name: key}).(*SensitiveString)
+	return ss, ok && ss != nil
+}
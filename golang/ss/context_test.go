@@ -0,0 +1,42 @@
+package sensitivestring
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestContextWithSecret verifies storage and retrieval by key
+func TestContextWithSecret(t *testing.T) {
+	ctx := ContextWithSecret(context.Background(), "upstream-token", New("foo"))
+
+	ss, ok := SecretFromContext(ctx, "upstream-token")
+	if !ok || ss.Value() != "foo" {
+		t.Errorf("SecretFromContext(upstream-token) = (%v, %v), want (foo, true)", ss, ok)
+	}
+	if _, ok := SecretFromContext(ctx, "other"); ok {
+		t.Errorf("SecretFromContext(other) found a secret")
+	}
+	if ctx.Value("upstream-token") != nil {
+		t.Errorf("plain string key should not collide with the secret key")
+	}
+	if _, ok := SecretFromContext(ContextWithSecret(ctx, "nil", nil), "nil"); ok {
+		t.Errorf("SecretFromContext() should not report a nil secret")
+	}
+}
+
+// TestContextWithSecret_Formatting verifies a logged context does not leak the value
+func TestContextWithSecret_Formatting(t *testing.T) {
+	ctx := ContextWithSecret(context.Background(), "upstream-token", New("context-plaintext"))
+
+	for _, format := range []string{"%v", "%+v", "%s"} {
+		got := fmt.Sprintf(format, ctx)
+		if strings.Contains(got, "context-plaintext") {
+			t.Errorf("fmt.Sprintf(%s, ctx) leaked the value: %v", format, got)
+		}
+		if !strings.Contains(got, "sha256:") || !strings.Contains(got, "upstream-token") {
+			t.Errorf("fmt.Sprintf(%s, ctx) = %v, want key name and hash", format, got)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package sensitivestring
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DecodeMode selects how UnmarshalJSON, UnmarshalYAML and Decode interpret
+// the data they decode.
+type DecodeMode int
+
+const (
+	// DecodeAmbiguous stores whatever string arrives as the value, whether
+	// it is a plaintext secret from a config file or the "sha256:…" form of
+	// a previously marshaled SensitiveString. This is the default.
+	//
+	// Deprecated: a round-tripped hash silently becomes the "secret". Use
+	// DecodePlaintext or DecodeRedacted to say which one is expected.
+	DecodeAmbiguous DecodeMode = iota
+
+	// DecodePlaintext treats incoming strings as plaintext secrets, e.g.
+	// when loading configuration, and rejects redacted forms with
+	// ErrRedactedValue.
+	DecodePlaintext
+
+	// DecodeRedacted expects the redacted forms written by MarshalJSON and
+	// MarshalYAML, e.g. when reading back logs or API responses, and
+	// rejects anything else with ErrPlaintextRefused.
+	DecodeRedacted
+)
+
+// ErrRedactedValue is returned in DecodePlaintext mode when the input is
+// a redacted form rather than a secret.
+var ErrRedactedValue = errors.New("sensitivestring: redacted value where plaintext was expected")
+
+// SetDecodeMode sets the package-wide DecodeMode.
+func SetDecodeMode(mode DecodeMode) {
+	updateConfig(func(c *config) { c.decodeMode = mode })
+}
+
+// GetDecodeMode returns the package-wide DecodeMode.
+func GetDecodeMode() DecodeMode {
+	return loadConfig().decodeMode
+}
+
+// IsRedactedForm reports whether str looks like the String form of a
+// SensitiveString, i.e. a known digest algorithm, a colon and a hex digest
+// of the right length.
+func IsRedactedForm(str string) bool {
+	alg, digest, ok := strings.Cut(str, ":")
+	if size, known := digestSizes[alg]; !ok || !known || len(digest) != 2*size {
+		return false
+	}
+	_, err := hex.DecodeString(digest)
+	return err == nil
+}
+
+// decodeString sets s from a decoded string according to the DecodeMode.
+func (s *SensitiveString) decodeString(str string) error {
+	switch loadConfig().decodeMode {
+	case DecodePlaintext:
+		if IsRedactedForm(str) {
+			return ErrRedactedValue
+		}
+	case DecodeRedacted:
+		if !IsRedactedForm(str) {
+			return fmt.Errorf("%w: expected a redacted value", ErrPlaintextRefused)
+		}
+		s.setPlaintext(str)
+		return nil
+	}
+	s.setPlaintext(compatString(str))
+	return nil
+}
+
+// decodeRedacted sets s from a decoded Redacted object.
+func (s *SensitiveString) decodeRedacted(r Redacted) error {
+	if loadConfig().decodeMode == DecodePlaintext {
+		return ErrRedactedValue
+	}
+	s.setPlaintext(r.Alg + ":" + r.Hash)
+	s.label = r.Label
+	return nil
+}
+
+// decodePlaintext sets s from a plaintext decoded from a compatibility
+// form.
+func (s *SensitiveString) decodePlaintext(value string) error {
+	if loadConfig().decodeMode == DecodeRedacted {
+		return fmt.Errorf("%w: expected a redacted value", ErrPlaintextRefused)
+	}
+	s.setPlaintext(value)
+	return nil
+}
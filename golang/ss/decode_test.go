@@ -0,0 +1,79 @@
+package sensitivestring
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// withDecodeMode sets the DecodeMode for the duration of a test
+func withDecodeMode(t *testing.T, mode DecodeMode) {
+	t.Helper()
+	previous := GetDecodeMode()
+	SetDecodeMode(mode)
+	t.Cleanup(func() { SetDecodeMode(previous) })
+}
+
+// TestIsRedactedForm verifies recognition of the String form
+func TestIsRedactedForm(t *testing.T) {
+	tests := map[string]bool{
+		New("foo").String():               true,
+		"salted-sha256:" + fooHashHex:     true,
+		"sha256:" + fooHashHex[:10]:       false,
+		"md5:" + fooHashHex:               false,
+		"sha256:" + fooHashHex[:63] + "z": false,
+		"hunter2":                         false,
+	}
+	for input, want := range tests {
+		if got := IsRedactedForm(input); got != want {
+			t.Errorf("IsRedactedForm(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
+
+// TestDecodeMode_Plaintext verifies redacted input is rejected when plaintext is expected
+func TestDecodeMode_Plaintext(t *testing.T) {
+	withDecodeMode(t, DecodePlaintext)
+	hashed, _ := json.Marshal(New("foo"))
+
+	var s SensitiveString
+	if err := json.Unmarshal([]byte(`"hunter2"`), &s); err != nil || s.Value() != "hunter2" {
+		t.Errorf("Unmarshal(plaintext) = %v, %v, want hunter2", s.Value(), err)
+	}
+	if err := json.Unmarshal(hashed, &s); !errors.Is(err, ErrRedactedValue) {
+		t.Errorf("Unmarshal(hash) error = %v, want ErrRedactedValue", err)
+	}
+	if err := json.Unmarshal([]byte(`{"alg":"sha256","hash":"abc","len":3}`), &s); !errors.Is(err, ErrRedactedValue) {
+		t.Errorf("Unmarshal(structured) error = %v, want ErrRedactedValue", err)
+	}
+	if err := yaml.Unmarshal(hashed, &s); !errors.Is(err, ErrRedactedValue) {
+		t.Errorf("yaml.Unmarshal(hash) error = %v, want ErrRedactedValue", err)
+	}
+}
+
+// TestDecodeMode_Redacted verifies plaintext input is rejected when a round-trip is expected
+func TestDecodeMode_Redacted(t *testing.T) {
+	withDecodeMode(t, DecodeRedacted)
+	hashed, _ := json.Marshal(New("foo"))
+
+	var s SensitiveString
+	if err := json.Unmarshal(hashed, &s); err != nil || s.Value() != New("foo").String() {
+		t.Errorf("Unmarshal(hash) = %v, %v, want %v", s.Value(), err, New("foo").String())
+	}
+	if err := json.Unmarshal([]byte(`{"alg":"sha256","hash":"abc","len":3,"label":"db"}`), &s); err != nil || s.Label() != "db" {
+		t.Errorf("Unmarshal(structured) = %v, %v, want label db", s.Label(), err)
+	}
+	if err := json.Unmarshal([]byte(`"hunter2"`), &s); !errors.Is(err, ErrPlaintextRefused) {
+		t.Errorf("Unmarshal(plaintext) error = %v, want ErrPlaintextRefused", err)
+	}
+	if err := yaml.Unmarshal([]byte(`hunter2`), &s); !errors.Is(err, ErrPlaintextRefused) {
+		t.Errorf("yaml.Unmarshal(plaintext) error = %v, want ErrPlaintextRefused", err)
+	}
+
+	withUnmarshalCompat(t, CompatValueObjects)
+	if err := json.Unmarshal([]byte(`{"value":"hunter2"}`), &s); !errors.Is(err, ErrPlaintextRefused) {
+		t.Errorf("Unmarshal(value object) error = %v, want ErrPlaintextRefused", err)
+	}
+}
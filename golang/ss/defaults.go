@@ -0,0 +1,57 @@
+package sensitivestring
+
+// Config gathers the package-wide rendering policy so that it can be set
+// in one place. The zero Config is the package's initial policy.
+type Config struct {
+	// Redactor renders values in place of the hash form, as with
+	// SetRedactor. nil means the hash form.
+	Redactor Redactor
+
+	// HashAlgorithm is the fingerprint algorithm, as with
+	// SetHashAlgorithm. Empty means SHA256.
+	HashAlgorithm HashAlgorithm
+
+	// Placeholder, if not empty, renders every value as this constant text
+	// (see the Placeholder Redactor). It is ignored when Redactor is set.
+	Placeholder string
+
+	// HideLen omits the length of values from structured output
+	// (Redacted, MarshalStructured, slog groups), since the length of a
+	// password narrows a brute-force search. Len() still reports it.
+	HideLen bool
+}
+
+// SetDefaults applies cfg as the package-wide policy in a single atomic
+// update, replacing the settings of SetRedactor and SetHashAlgorithm. It
+// affects every SensitiveString rendered afterwards, including existing
+// ones, except where an instance overrides a setting (see New's options).
+// It panics on an unsupported hash algorithm.
+func SetDefaults(cfg Config) {
+	alg := cfg.HashAlgorithm
+	if alg == "" {
+		alg = SHA256
+	}
+	if hashConstructors[alg] == nil {
+		panic("SetDefaults: unsupported algorithm " + string(alg))
+	}
+	redactor := cfg.Redactor
+	if redactor == nil && cfg.Placeholder != "" {
+		redactor = Placeholder(cfg.Placeholder)
+	}
+	updateConfig(func(c *config) {
+		c.redactor = redactor
+		c.hashAlgorithm = alg
+		c.hideLen = cfg.HideLen
+	})
+}
+
+// GetDefaults returns the current package-wide policy. A Placeholder
+// Redactor is reported through Config.Placeholder.
+func GetDefaults() Config {
+	c := loadConfig()
+	cfg := Config{Redactor: c.redactor, HashAlgorithm: c.hashAlgorithm, HideLen: c.hideLen}
+	if p, ok := c.redactor.(placeholder); ok {
+		cfg.Redactor, cfg.Placeholder = nil, string(p)
+	}
+	return cfg
+}
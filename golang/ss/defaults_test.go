@@ -0,0 +1,65 @@
+package sensitivestring
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// withDefaults applies cfg for the duration of a test.
+func withDefaults(t *testing.T, cfg Config) {
+	t.Helper()
+	previous := GetDefaults()
+	SetDefaults(cfg)
+	t.Cleanup(func() { SetDefaults(previous) })
+}
+
+// TestSetDefaults verifies the policy applies package-wide and round-trips through GetDefaults
+func TestSetDefaults(t *testing.T) {
+	withDefaults(t, Config{HashAlgorithm: SHA512, HideLen: true})
+	ss := NewLabeled("db", "foo")
+	if got := ss.String(); !strings.HasPrefix(got, "sha512:") {
+		t.Errorf("String() = %v, want sha512 prefix", got)
+	}
+	if got := ss.Redacted().Len; got != 0 {
+		t.Errorf("Redacted().Len = %d, want 0 when hidden", got)
+	}
+	if got := ss.Len(); got != 3 {
+		t.Errorf("Len() = %d, want 3", got)
+	}
+	withMarshalMode(t, MarshalStructured)
+	data, err := json.Marshal(ss)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if strings.Contains(string(data), `"len"`) {
+		t.Errorf("json.Marshal() = %s, want no len", data)
+	}
+	if got := GetDefaults(); got != (Config{HashAlgorithm: SHA512, HideLen: true}) {
+		t.Errorf("GetDefaults() = %+v", got)
+	}
+}
+
+// TestSetDefaults_Placeholder verifies the placeholder text and the Redactor precedence
+func TestSetDefaults_Placeholder(t *testing.T) {
+	withDefaults(t, Config{Placeholder: "(hidden)"})
+	if got := New("foo").String(); got != "(hidden)" {
+		t.Errorf("String() = %v, want (hidden)", got)
+	}
+	if got := GetDefaults().Placeholder; got != "(hidden)" {
+		t.Errorf("GetDefaults().Placeholder = %v, want (hidden)", got)
+	}
+	if got := New("foo", WithHashAlgorithm(SHA256), WithRedactor(Masked(1))).String(); got != "••o" {
+		t.Errorf("instance override String() = %v, want ••o", got)
+	}
+
+	SetDefaults(Config{Redactor: Masked(1), Placeholder: "(hidden)"})
+	if got := New("foo").String(); got != "••o" {
+		t.Errorf("String() = %v, want the Redactor to win over the placeholder", got)
+	}
+
+	SetDefaults(Config{})
+	if got := New("foo").String(); got != "sha256:"+fooHashHex {
+		t.Errorf("String() after SetDefaults(Config{}) = %v, want %v", got, "sha256:"+fooHashHex)
+	}
+}
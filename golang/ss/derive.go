@@ -0,0 +1,71 @@
+package sensitivestring
+
+import (
+	"crypto/hkdf"
+	"crypto/sha256"
+	"errors"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// DeriveKey derives a length-byte key from the secret with HKDF-SHA256,
+// for going from a high-entropy master secret to per-purpose keys: use a
+// distinct info string per purpose. salt may be nil. The derived key is
+// written straight into a SensitiveBytes, so no intermediate material is
+// held in plain types.
+func (s *SensitiveString) DeriveKey(salt []byte, info string, length int) (*SensitiveBytes, error) {
+	var key []byte
+	var err error
+	s.Use(func(secret []byte) { key, err = hkdf.Key(sha256.New, secret, salt, info, length) })
+	return derivedKey(key, err)
+}
+
+// DeriveKeyPBKDF2 derives a length-byte key from the secret with
+// PBKDF2-HMAC-SHA256, for low-entropy secrets such as passphrases. OWASP
+// currently recommends at least 600,000 iterations.
+func (s *SensitiveString) DeriveKeyPBKDF2(salt []byte, iterations int, length int) (*SensitiveBytes, error) {
+	if iterations < 1 || length < 1 {
+		return nil, errors.New("sensitivestring: PBKDF2 iterations and length must be positive")
+	}
+	var key []byte
+	s.Use(func(secret []byte) { key = pbkdf2.Key(secret, salt, iterations, length, sha256.New) })
+	return derivedKey(key, nil)
+}
+
+// DeriveKeyScrypt derives a length-byte key from the secret with scrypt,
+// a memory-hard function for low-entropy secrets. N must be a power of two
+// greater than 1; N=32768, r=8, p=1 is a common interactive choice.
+func (s *SensitiveString) DeriveKeyScrypt(salt []byte, n, r, p, length int) (*SensitiveBytes, error) {
+	var key []byte
+	var err error
+	s.Use(func(secret []byte) { key, err = scrypt.Key(secret, salt, n, r, p, length) })
+	return derivedKey(key, err)
+}
+
+// DeriveKey derives a key from the bytes like SensitiveString.DeriveKey.
+func (b *SensitiveBytes) DeriveKey(salt []byte, info string, length int) (*SensitiveBytes, error) {
+	return b.secret().DeriveKey(salt, info, length)
+}
+
+// DeriveKeyPBKDF2 derives a key from the bytes like
+// SensitiveString.DeriveKeyPBKDF2.
+func (b *SensitiveBytes) DeriveKeyPBKDF2(salt []byte, iterations int, length int) (*SensitiveBytes, error) {
+	return b.secret().DeriveKeyPBKDF2(salt, iterations, length)
+}
+
+// DeriveKeyScrypt derives a key from the bytes like
+// SensitiveString.DeriveKeyScrypt.
+func (b *SensitiveBytes) DeriveKeyScrypt(salt []byte, n, r, p, length int) (*SensitiveBytes, error) {
+	return b.secret().DeriveKeyScrypt(salt, n, r, p, length)
+}
+
+// derivedKey takes ownership of key, a freshly derived buffer, as a
+// SensitiveBytes.
+func derivedKey(key []byte, err error) (*SensitiveBytes, error) {
+	if err != nil {
+		clear(key)
+		return nil, err
+	}
+	return &SensitiveBytes{s: &SensitiveString{store: &byteStorage{buf: key}}}, nil
+}
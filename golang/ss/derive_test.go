@@ -0,0 +1,61 @@
+package sensitivestring
+
+import (
+	"crypto/hkdf"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+// TestDeriveKey verifies HKDF output, purpose separation and the SensitiveBytes variant
+func TestDeriveKey(t *testing.T) {
+	master := New("master secret")
+	key, err := master.DeriveKey([]byte("salt"), "encryption", 32)
+	if err != nil {
+		t.Fatalf("DeriveKey() error = %v", err)
+	}
+	want, _ := hkdf.Key(sha256.New, []byte("master secret"), []byte("salt"), "encryption", 32)
+	if got := key.Bytes(); hex.EncodeToString(got) != hex.EncodeToString(want) {
+		t.Errorf("DeriveKey() = %x, want %x", got, want)
+	}
+
+	signing, _ := master.DeriveKey([]byte("salt"), "signing", 32)
+	if signing.Equal(key) {
+		t.Errorf("keys for different purposes are equal")
+	}
+	fromBytes, _ := NewBytes([]byte("master secret")).DeriveKey([]byte("salt"), "encryption", 32)
+	if !fromBytes.Equal(key) {
+		t.Errorf("SensitiveBytes.DeriveKey() differs from SensitiveString.DeriveKey()")
+	}
+	if _, err := master.DeriveKey(nil, "x", 256*32); err == nil {
+		t.Errorf("DeriveKey() beyond the HKDF limit error = nil")
+	}
+}
+
+// TestDeriveKey_PasswordBased verifies PBKDF2 and scrypt derivations
+func TestDeriveKey_PasswordBased(t *testing.T) {
+	pass := New("password")
+	pbkdf, err := pass.DeriveKeyPBKDF2([]byte("salt"), 1, 20)
+	if err != nil {
+		t.Fatalf("DeriveKeyPBKDF2() error = %v", err)
+	}
+	// RFC 7914 section 11, PBKDF2-HMAC-SHA256 with c = 1, truncated to 20 bytes.
+	if got := hex.EncodeToString(pbkdf.Bytes()); got != "120fb6cffcf8b32c43e7225256c4f837a86548c9" {
+		t.Errorf("DeriveKeyPBKDF2() = %v", got)
+	}
+	if _, err := pass.DeriveKeyPBKDF2(nil, 0, 32); err == nil {
+		t.Errorf("DeriveKeyPBKDF2() with 0 iterations error = nil")
+	}
+
+	sk, err := NewBytes([]byte("password")).DeriveKeyScrypt([]byte("NaCl"), 1024, 8, 16, 16)
+	if err != nil {
+		t.Fatalf("DeriveKeyScrypt() error = %v", err)
+	}
+	// RFC 7914 section 12, truncated to 16 bytes.
+	if got := hex.EncodeToString(sk.Bytes()); got != "fdbabe1c9d3472007856e7190d01e9fe" {
+		t.Errorf("DeriveKeyScrypt() = %v", got)
+	}
+	if _, err := pass.DeriveKeyScrypt(nil, 3, 8, 1, 32); err == nil {
+		t.Errorf("DeriveKeyScrypt() with N=3 error = nil")
+	}
+}
@@ -0,0 +1,261 @@
+package sensitivestring
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha3"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// algSHA256 names the plain SHA256 digest of the value.
+	algSHA256 = "sha256"
+
+	// saltedPrefix prefixes the name of any algorithm used as an HMAC keyed
+	// with the per-process salt.
+	saltedPrefix = "salted-"
+
+	// keyedPrefix prefixes the name of any algorithm used as an HMAC keyed
+	// with the key set by SetFingerprintKey.
+	keyedPrefix = "hmac-"
+)
+
+// HashAlgorithm selects the digest used for fingerprints. Its value is the
+// prefix emitted before the hex digest, e.g. "sha512:…".
+type HashAlgorithm string
+
+// Supported hash algorithms.
+const (
+	SHA256     HashAlgorithm = algSHA256
+	SHA512     HashAlgorithm = "sha512"
+	SHA3_256   HashAlgorithm = "sha3-256"
+	BLAKE2b256 HashAlgorithm = "blake2b-256"
+)
+
+// hashConstructors maps each supported algorithm to its implementation.
+var hashConstructors = map[HashAlgorithm]func() hash.Hash{
+	SHA256:   sha256.New,
+	SHA512:   sha512.New,
+	SHA3_256: func() hash.Hash { return sha3.New256() },
+	BLAKE2b256: func() hash.Hash {
+		h, _ := blake2b.New256(nil)
+		return h
+	},
+}
+
+// SetHashAlgorithm sets the package-wide algorithm for fingerprints, for
+// organizations whose crypto policy forbids bare SHA-256 or needs longer
+// digests. It panics on an unsupported algorithm. Instances created with
+// WithHashAlgorithm are unaffected.
+func SetHashAlgorithm(alg HashAlgorithm) {
+	if hashConstructors[alg] == nil {
+		panic("SetHashAlgorithm: unsupported algorithm " + string(alg))
+	}
+	updateConfig(func(c *config) { c.hashAlgorithm = alg })
+}
+
+// GetHashAlgorithm returns the package-wide fingerprint algorithm.
+func GetHashAlgorithm() HashAlgorithm {
+	return loadConfig().hashAlgorithm
+}
+
+// WithHashAlgorithm returns a copy of s whose fingerprint uses alg instead
+// of the package-wide algorithm. It panics on an unsupported algorithm.
+func (s *SensitiveString) WithHashAlgorithm(alg HashAlgorithm) *SensitiveString {
+	if hashConstructors[alg] == nil {
+		panic("WithHashAlgorithm: unsupported algorithm " + string(alg))
+	}
+	if s == nil {
+		s = New("")
+	}
+	c := *s
+	c.hashAlg = alg
+	return &c
+}
+
+// digestSizes maps every algorithm name that can appear in a fingerprint,
+// salted or not, to its digest size in bytes.
+var digestSizes = func() map[string]int {
+	sizes := make(map[string]int)
+	for alg, newHash := range hashConstructors {
+		sizes[string(alg)] = newHash().Size()
+		sizes[saltedPrefix+string(alg)] = newHash().Size()
+		sizes[keyedPrefix+string(alg)] = newHash().Size()
+	}
+	return sizes
+}()
+
+var (
+	processSaltOnce sync.Once
+	processSalt     []byte
+)
+
+// getProcessSalt returns the random salt generated once per process.
+func getProcessSalt() []byte {
+	processSaltOnce.Do(func() {
+		processSalt = make([]byte, sha256.Size)
+		if _, err := rand.Read(processSalt); err != nil {
+			panic("sensitivestring: cannot generate process salt: " + err.Error())
+		}
+	})
+	return processSalt
+}
+
+// UseProcessSalt enables or disables salted hashes. When enabled, String(),
+// marshaling and scrubbing emit "salted-sha256:<hex>", an HMAC-SHA256 keyed
+// with a random value generated once per process. Identical secrets then
+// produce different digests in different services and across restarts, so
+// log aggregators cannot correlate shared credentials across systems.
+// Digests remain stable within a process.
+func UseProcessSalt(enabled bool) {
+	var salt []byte
+	if enabled {
+		salt = getProcessSalt()
+	}
+	updateConfig(func(c *config) { c.salt = salt })
+}
+
+// SetFingerprintKey makes String(), marshaling and scrubbing emit
+// "hmac-sha256:<hex>", an HMAC of the value keyed with key (a pepper),
+// instead of a bare digest. Without the key, fingerprints of low-entropy
+// secrets such as passwords cannot be reversed with a dictionary, yet they
+// stay stable across processes that share the key, so a deployment can
+// still correlate them. The HMAC uses the configured hash algorithm. The key
+// takes precedence over UseProcessSalt. key is copied; a nil or empty key
+// turns keyed fingerprints off again.
+func SetFingerprintKey(key []byte) {
+	var copied []byte
+	if len(key) > 0 {
+		copied = append([]byte(nil), key...)
+	}
+	updateConfig(func(c *config) { c.fingerprintKey = copied })
+}
+
+// computeDigest returns the algorithm name and digest of the value under the
+// current configuration.
+func (s SensitiveString) computeDigest() (string, []byte) {
+	cfg := loadConfig()
+	alg := s.hashAlg
+	if alg == "" {
+		alg = cfg.hashAlgorithm
+	}
+	if d := s.digest; d != nil && d.store == s.store {
+		prefix, key := cfg.digestKey()
+		if d.name == prefix+string(alg) && bytes.Equal(d.key, key) {
+			return d.name, d.sum
+		}
+	}
+	var name string
+	var sum []byte
+	s.usePlaintext(func(value []byte) { name, sum = digestWith(cfg, alg, value) })
+	return name, sum
+}
+
+// digestOf returns the algorithm name and digest of data under alg, keyed
+// with the fingerprint key or process salt if one is configured. An empty
+// alg means the package-wide algorithm.
+func digestOf(alg HashAlgorithm, data []byte) (string, []byte) {
+	cfg := loadConfig()
+	if alg == "" {
+		alg = cfg.hashAlgorithm
+	}
+	return digestWith(cfg, alg, data)
+}
+
+// digestWith returns the algorithm name and digest of data under alg and
+// the key configured in cfg.
+func digestWith(cfg *config, alg HashAlgorithm, data []byte) (string, []byte) {
+	newHash := hashConstructors[alg]
+	prefix, key := cfg.digestKey()
+	if key != nil {
+		mac := hmac.New(newHash, key)
+		mac.Write(data)
+		return prefix + string(alg), mac.Sum(nil)
+	}
+	h := newHash()
+	h.Write(data)
+	return string(alg), h.Sum(nil)
+}
+
+// digestKey returns the key digests are computed with, the fingerprint key
+// taking precedence over the process salt, and the prefix it adds to the
+// algorithm name. Both are empty when digests are not keyed.
+func (c *config) digestKey() (string, []byte) {
+	if c.fingerprintKey != nil {
+		return keyedPrefix, c.fingerprintKey
+	}
+	if c.salt != nil {
+		return saltedPrefix, c.salt
+	}
+	return "", nil
+}
+
+// digestNamed returns the digest of data under the algorithm name found in
+// a fingerprint, using the configured fingerprint key or process salt for
+// keyed and salted names. It fails for unknown names and for keyed or
+// salted names when no key or salt is configured.
+func digestNamed(name string, data []byte) ([]byte, bool) {
+	cfg := loadConfig()
+	var key []byte
+	if alg, ok := strings.CutPrefix(name, keyedPrefix); ok {
+		name, key = alg, cfg.fingerprintKey
+		if key == nil {
+			return nil, false
+		}
+	} else if alg, ok := strings.CutPrefix(name, saltedPrefix); ok {
+		name, key = alg, cfg.salt
+		if key == nil {
+			return nil, false
+		}
+	}
+	newHash := hashConstructors[HashAlgorithm(name)]
+	if newHash == nil {
+		return nil, false
+	}
+	h := newHash()
+	if key != nil {
+		h = hmac.New(newHash, key)
+	}
+	h.Write(data)
+	return h.Sum(nil), true
+}
+
+// Hash returns a copy of the raw digest behind the fingerprint, computed
+// like String() (algorithm, salt and fingerprint key included), for systems
+// that store binary digests. Redacted().Alg names the algorithm.
+// Uses a value receiver so it is callable on both value and pointer types.
+func (s SensitiveString) Hash() []byte {
+	_, sum := s.computeDigest()
+	return bytes.Clone(sum)
+}
+
+// HashHex returns the digest as lowercase hex, i.e. String() without the
+// algorithm prefix.
+// Uses a value receiver so it is callable on both value and pointer types.
+func (s SensitiveString) HashHex() string {
+	_, hash := s.hashHex()
+	return hash
+}
+
+// HashBase64 returns the digest in standard base64 with padding, as used
+// for example by Subresource Integrity.
+// Uses a value receiver so it is callable on both value and pointer types.
+func (s SensitiveString) HashBase64() string {
+	_, sum := s.computeDigest()
+	return base64.StdEncoding.EncodeToString(sum)
+}
+
+// hashHex returns the algorithm name and hex-encoded digest of the value.
+func (s SensitiveString) hashHex() (string, string) {
+	alg, sum := s.computeDigest()
+	return alg, hex.EncodeToString(sum)
+}
@@ -0,0 +1,179 @@
+package sensitivestring
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// withProcessSalt enables salted hashes for the duration of a test.
+func withProcessSalt(t *testing.T) {
+	t.Helper()
+	UseProcessSalt(true)
+	t.Cleanup(func() { UseProcessSalt(false) })
+}
+
+// TestUseProcessSalt verifies salted digests differ from plain ones but are stable
+func TestUseProcessSalt(t *testing.T) {
+	withProcessSalt(t)
+
+	ss := New("foo")
+	salted := ss.String()
+	if !strings.HasPrefix(salted, "salted-sha256:") {
+		t.Errorf("String() = %v, want salted-sha256 prefix", salted)
+	}
+	if strings.Contains(salted, fooHashHex) {
+		t.Errorf("String() = %v, should not contain the unsalted hash", salted)
+	}
+	if got := New("foo").String(); got != salted {
+		t.Errorf("salted String() is not stable: %v != %v", got, salted)
+	}
+	if got := ss.Redacted().Alg; got != "salted-sha256" {
+		t.Errorf("Redacted().Alg = %v, want salted-sha256", got)
+	}
+
+	UseProcessSalt(false)
+	if got := ss.String(); got != "sha256:"+fooHashHex {
+		t.Errorf("String() after disabling salt = %v, want %v", got, "sha256:"+fooHashHex)
+	}
+}
+
+// TestProcessSalt_GeneratedOnce verifies the salt is random and generated once
+func TestProcessSalt_GeneratedOnce(t *testing.T) {
+	salt := getProcessSalt()
+	if len(salt) != 32 || bytes.Equal(salt, make([]byte, 32)) {
+		t.Errorf("getProcessSalt() = %x, want 32 random bytes", salt)
+	}
+	if !bytes.Equal(getProcessSalt(), salt) {
+		t.Errorf("getProcessSalt() changed between calls")
+	}
+}
+
+// withHashAlgorithm sets the package-wide hash algorithm for the duration of a test.
+func withHashAlgorithm(t *testing.T, alg HashAlgorithm) {
+	t.Helper()
+	previous := GetHashAlgorithm()
+	SetHashAlgorithm(alg)
+	t.Cleanup(func() { SetHashAlgorithm(previous) })
+}
+
+// TestSetHashAlgorithm verifies each algorithm's prefix and digest length
+func TestSetHashAlgorithm(t *testing.T) {
+	for _, alg := range []HashAlgorithm{SHA256, SHA512, SHA3_256, BLAKE2b256} {
+		t.Run(string(alg), func(t *testing.T) {
+			withHashAlgorithm(t, alg)
+			got := New("foo").String()
+			digest, ok := strings.CutPrefix(got, string(alg)+":")
+			if !ok || len(digest) != 2*hashConstructors[alg]().Size() {
+				t.Errorf("String() = %v, want %s prefix and a full digest", got, alg)
+			}
+			if !IsRedactedForm(got) {
+				t.Errorf("IsRedactedForm(%v) = false, want true", got)
+			}
+		})
+	}
+
+	withHashAlgorithm(t, SHA512)
+	sum := sha512.Sum512([]byte("foo"))
+	if got, want := New("foo").String(), "sha512:"+hex.EncodeToString(sum[:]); got != want {
+		t.Errorf("String() = %v, want %v", got, want)
+	}
+	withProcessSalt(t)
+	if got := New("foo").Redacted().Alg; got != "salted-sha512" {
+		t.Errorf("salted Redacted().Alg = %v, want salted-sha512", got)
+	}
+}
+
+// TestWithHashAlgorithm verifies the per-instance algorithm overrides the default
+func TestWithHashAlgorithm(t *testing.T) {
+	original := New("foo")
+	custom := original.WithHashAlgorithm(SHA3_256)
+	if !strings.HasPrefix(custom.String(), "sha3-256:") {
+		t.Errorf("String() = %v, want sha3-256 prefix", custom.String())
+	}
+	if got := original.String(); got != "sha256:"+fooHashHex {
+		t.Errorf("original String() = %v, want unchanged", got)
+	}
+	if !SameSecret(original, custom.Redacted()) {
+		t.Errorf("SameSecret(plaintext, sha3-256 Redacted) = false, want true")
+	}
+	if SameSecret(New("bar"), custom.Redacted()) {
+		t.Errorf("SameSecret(other plaintext, sha3-256 Redacted) = true, want false")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("WithHashAlgorithm(md5) did not panic")
+		}
+	}()
+	original.WithHashAlgorithm("md5")
+}
+
+// withFingerprintKey sets the fingerprint key for the duration of a test.
+func withFingerprintKey(t *testing.T, key []byte) {
+	t.Helper()
+	SetFingerprintKey(key)
+	t.Cleanup(func() { SetFingerprintKey(nil) })
+}
+
+// TestSetFingerprintKey verifies keyed fingerprints are stable per key and hide the plain digest
+func TestSetFingerprintKey(t *testing.T) {
+	key := []byte("pepper")
+	withFingerprintKey(t, key)
+
+	mac := hmac.New(sha256.New, []byte("pepper"))
+	mac.Write([]byte("foo"))
+	want := "hmac-sha256:" + hex.EncodeToString(mac.Sum(nil))
+	key[0] = 'X'
+	if got := New("foo").String(); got != want {
+		t.Errorf("String() = %v, want %v", got, want)
+	}
+	if !IsRedactedForm(want) {
+		t.Errorf("IsRedactedForm(%v) = false, want true", want)
+	}
+
+	withProcessSalt(t)
+	if got := New("foo").String(); got != want {
+		t.Errorf("String() with process salt = %v, want the keyed form %v", got, want)
+	}
+
+	SetFingerprintKey([]byte("other"))
+	if got := New("foo").String(); got == want {
+		t.Errorf("String() did not change with the key")
+	}
+
+	SetFingerprintKey(nil)
+	UseProcessSalt(false)
+	if got := New("foo").String(); got != "sha256:"+fooHashHex {
+		t.Errorf("String() after clearing the key = %v, want %v", got, "sha256:"+fooHashHex)
+	}
+}
+
+// TestHash_Encodings verifies the raw, hex and base64 digest accessors agree
+func TestHash_Encodings(t *testing.T) {
+	ss := New("foo")
+	ss.precomputeDigest()
+	sum := ss.Hash()
+	if got := hex.EncodeToString(sum); got != fooHashHex {
+		t.Errorf("Hash() = %x, want %v", sum, fooHashHex)
+	}
+	if got := ss.HashHex(); got != fooHashHex {
+		t.Errorf("HashHex() = %v, want %v", got, fooHashHex)
+	}
+	if got, want := ss.HashBase64(), base64.StdEncoding.EncodeToString(sum); got != want {
+		t.Errorf("HashBase64() = %v, want %v", got, want)
+	}
+
+	sum[0] ^= 0xff
+	if got := ss.HashHex(); got != fooHashHex {
+		t.Errorf("modifying Hash() changed the cached digest: %v", got)
+	}
+	if got := len(New("foo", WithHashAlgorithm(SHA512)).Hash()); got != sha512.Size {
+		t.Errorf("len(Hash()) with SHA512 = %d, want %d", got, sha512.Size)
+	}
+}
@@ -0,0 +1,218 @@
+package sensitivestring
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+)
+
+// LoadDotenv reads a .env file into secrets keyed by variable name,
+// labeled with that name, so development environments can load secrets
+// without os.Setenv. The file holds one NAME=value assignment per line,
+// optionally prefixed with "export ". Blank lines and lines starting with
+// "#" are ignored, as is a "#" comment after whitespace in an unquoted
+// value. Single-quoted values are taken literally; double-quoted values
+// may use the escapes \n, \r, \t, \" and \\. Quoted values may span
+// lines. Variables are not expanded. A name assigned twice takes the
+// later value. Errors name the file and line but never the value.
+func LoadDotenv(path string) (map[string]*SensitiveString, error) {
+	data, err := os.ReadFile(path)
+	defer clear(data)
+	if err != nil {
+		return nil, err
+	}
+	secrets, line, err := parseDotenv(data)
+	if err != nil {
+		for _, s := range secrets {
+			s.Zero()
+		}
+		return nil, fmt.Errorf("sensitivestring: %s:%d: %w", path, line, err)
+	}
+	return secrets, nil
+}
+
+// dotenvParser scans a .env file held in data.
+type dotenvParser struct {
+	data []byte
+	pos  int
+	line int
+}
+
+// parseDotenv parses data, returning the line on which the first error
+// occurs or the failing value starts.
+func parseDotenv(data []byte) (map[string]*SensitiveString, int, error) {
+	p := &dotenvParser{data: data, line: 1}
+	secrets := make(map[string]*SensitiveString)
+	for {
+		p.skipBlank()
+		if p.pos == len(p.data) {
+			return secrets, p.line, nil
+		}
+		switch p.data[p.pos] {
+		case '\n':
+			p.pos++
+			p.line++
+			continue
+		case '#':
+			p.skipLine()
+			continue
+		}
+
+		name := p.name()
+		if name == "export" && p.pos < len(p.data) && (p.data[p.pos] == ' ' || p.data[p.pos] == '\t') {
+			p.skipBlank()
+			name = p.name()
+		}
+		if name == "" {
+			return secrets, p.line, fmt.Errorf("expected a variable name")
+		}
+		p.skipBlank()
+		if p.pos == len(p.data) || p.data[p.pos] != '=' {
+			return secrets, p.line, fmt.Errorf("expected = after %s", name)
+		}
+		p.pos++
+		p.skipBlank()
+
+		line := p.line
+		value, err := p.value()
+		if err != nil {
+			return secrets, line, fmt.Errorf("%s: %w", name, err)
+		}
+		if previous := secrets[name]; previous != nil {
+			previous.Zero()
+		}
+		secrets[name] = &SensitiveString{label: name, store: &byteStorage{buf: value}}
+	}
+}
+
+// value parses the value at the current position, through the end of its
+// line. The returned slice is owned by the caller.
+func (p *dotenvParser) value() ([]byte, error) {
+	var value []byte
+	var err error
+	switch {
+	case p.pos == len(p.data):
+		return []byte{}, nil
+	case p.data[p.pos] == '\'':
+		value, err = p.singleQuoted()
+	case p.data[p.pos] == '"':
+		value, err = p.doubleQuoted()
+	default:
+		return p.unquoted(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	p.skipBlank()
+	if p.pos < len(p.data) && p.data[p.pos] == '#' {
+		p.skipLine()
+	}
+	if p.pos < len(p.data) && p.data[p.pos] != '\n' && !bytes.HasPrefix(p.data[p.pos:], []byte("\r\n")) {
+		clear(value)
+		return nil, fmt.Errorf("unexpected text after closing quote")
+	}
+	return value, nil
+}
+
+// unquoted parses an unquoted value, dropping any trailing comment and
+// whitespace.
+func (p *dotenvParser) unquoted() []byte {
+	start := p.pos
+	end := start
+	for p.pos < len(p.data) && p.data[p.pos] != '\n' {
+		c := p.data[p.pos]
+		if c == '#' && p.pos > start && isBlank(p.data[p.pos-1]) {
+			p.skipLine()
+			break
+		}
+		p.pos++
+		if !isBlank(c) && c != '\r' {
+			end = p.pos
+		}
+	}
+	return bytes.Clone(p.data[start:end])
+}
+
+// singleQuoted parses a single-quoted value literally.
+func (p *dotenvParser) singleQuoted() ([]byte, error) {
+	start := p.pos + 1
+	end := bytes.IndexByte(p.data[start:], '\'')
+	if end < 0 {
+		return nil, fmt.Errorf("unterminated single-quoted value")
+	}
+	value := bytes.Clone(p.data[start : start+end])
+	p.line += bytes.Count(value, []byte("\n"))
+	p.pos = start + end + 1
+	return value, nil
+}
+
+// doubleQuoted parses a double-quoted value, interpreting escapes.
+func (p *dotenvParser) doubleQuoted() ([]byte, error) {
+	var value []byte
+	for p.pos++; p.pos < len(p.data); p.pos++ {
+		c := p.data[p.pos]
+		switch {
+		case c == '"':
+			p.pos++
+			return value, nil
+		case c == '\\' && p.pos+1 < len(p.data):
+			p.pos++
+			switch e := p.data[p.pos]; e {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case '"', '\\':
+				c = e
+			default:
+				value = append(value, '\\')
+				c = e
+			}
+		case c == '\n':
+			p.line++
+		}
+		if len(value) == cap(value) {
+			grown := append(make([]byte, 0, 2*cap(value)+16), value...)
+			clear(value)
+			value = grown
+		}
+		value = append(value, c)
+	}
+	clear(value)
+	return nil, fmt.Errorf("unterminated double-quoted value")
+}
+
+// name parses a variable name: letters, digits, "_" and ".".
+func (p *dotenvParser) name() string {
+	start := p.pos
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '_' || c == '.' || p.pos > start && '0' <= c && c <= '9') {
+			break
+		}
+		p.pos++
+	}
+	return string(p.data[start:p.pos])
+}
+
+// skipBlank advances past spaces and tabs.
+func (p *dotenvParser) skipBlank() {
+	for p.pos < len(p.data) && isBlank(p.data[p.pos]) {
+		p.pos++
+	}
+}
+
+// skipLine advances to the newline ending the current line.
+func (p *dotenvParser) skipLine() {
+	if i := bytes.IndexByte(p.data[p.pos:], '\n'); i >= 0 {
+		p.pos += i
+	} else {
+		p.pos = len(p.data)
+	}
+}
+
+func isBlank(c byte) bool {
+	return c == ' ' || c == '\t'
+}
@@ -0,0 +1,85 @@
+package sensitivestring
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeDotenv writes content to a .env file in a temporary directory.
+func writeDotenv(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestLoadDotenv verifies quoting, export prefixes and comments are handled
+func TestLoadDotenv(t *testing.T) {
+	path := writeDotenv(t, `# database
+DB_USER=admin
+export DB_PASSWORD = hunter2  # rotated monthly
+API_KEY='abc#def \n'
+TOKEN="line1\nline2\t\"quoted\" \\ \q"
+CERT="-----BEGIN-----
+body
+-----END-----"
+HASH=abc#def
+EMPTY=
+EMPTY_QUOTED=""
+CRLF=value`+"\r\n"+`
+	INDENTED=yes
+DB_USER=override
+`)
+	secrets, err := LoadDotenv(path)
+	if err != nil {
+		t.Fatalf("LoadDotenv() error = %v", err)
+	}
+	want := map[string]string{
+		"DB_USER":      "override",
+		"DB_PASSWORD":  "hunter2",
+		"API_KEY":      `abc#def \n`,
+		"TOKEN":        "line1\nline2\t\"quoted\" \\ \\q",
+		"CERT":         "-----BEGIN-----\nbody\n-----END-----",
+		"HASH":         "abc#def",
+		"EMPTY":        "",
+		"EMPTY_QUOTED": "",
+		"CRLF":         "value",
+		"INDENTED":     "yes",
+	}
+	if len(secrets) != len(want) {
+		t.Errorf("LoadDotenv() returned %d secrets, want %d", len(secrets), len(want))
+	}
+	for name, value := range want {
+		s := secrets[name]
+		if s == nil || s.Value() != value || s.Label() != name {
+			t.Errorf("secrets[%s] = %q labeled %q, want %q", name, s.Value(), s.Label(), value)
+		}
+	}
+}
+
+// TestLoadDotenv_Errors verifies malformed files are reported by line without the value
+func TestLoadDotenv_Errors(t *testing.T) {
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{"A=1\nnot an assignment\n", ":2: expected = after not"},
+		{"A=1\n=hunter2\n", ":2: expected a variable name"},
+		{"A=1\nB='hunter2\n", ":2: B: unterminated single-quoted"},
+		{"A=\"x\ny\"\nB=\"hunter2\n", ":3: B: unterminated double-quoted"},
+		{"A='x' hunter2\n", ":1: A: unexpected text"},
+	}
+	for _, tt := range tests {
+		_, err := LoadDotenv(writeDotenv(t, tt.content))
+		if err == nil || !strings.Contains(err.Error(), tt.want) || strings.Contains(err.Error(), "hunter2") {
+			t.Errorf("LoadDotenv(%q) error = %v, want %q without the value", tt.content, err, tt.want)
+		}
+	}
+	if _, err := LoadDotenv(filepath.Join(t.TempDir(), "missing")); !os.IsNotExist(err) {
+		t.Errorf("LoadDotenv(missing) error = %v, want not-exist", err)
+	}
+}
@@ -0,0 +1,119 @@
+package sensitivestring
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// minEncodedFragment is the shortest unaligned base64 fragment the scrubber
+// matches. Shorter fragments would match unrelated base64 data too often.
+const minEncodedFragment = 8
+
+// encodedForms returns the encodings under which value commonly appears in
+// logs: standard and URL-safe base64 (with and without padding), lower- and
+// upper-case hex, JSON string escaping, and the URL-encoded variants
+// returned by urlEncodedForms.
+//
+// A secret embedded in a larger base64 payload (e.g. "user:password" in a
+// Basic Authorization header, or a JWT segment) does not start on a 3-byte
+// boundary, so its encoding depends on the neighbouring bytes. For each of
+// the three possible alignments, the characters whose 6 bits come entirely
+// from the secret are independent of its neighbours; those fragments are
+// matched as well.
+func encodedForms(value string) []string {
+	var forms []string
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.URLEncoding} {
+		padded := enc.EncodeToString([]byte(value))
+		forms = append(forms, padded, strings.TrimRight(padded, "="))
+		for align := 0; align < 3; align++ {
+			if fragment := base64Fragment(enc, value, align); len(fragment) >= minEncodedFragment {
+				forms = append(forms, fragment)
+			}
+		}
+	}
+
+	lower := hex.EncodeToString([]byte(value))
+	forms = append(forms, lower, strings.ToUpper(lower))
+
+	for _, escapeHTML := range []bool{true, false} {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(escapeHTML)
+		if err := enc.Encode(value); err == nil {
+			quoted := strings.TrimSuffix(buf.String(), "\n")
+			forms = append(forms, quoted[1:len(quoted)-1])
+		}
+	}
+	return append(forms, urlEncodedForms(value)...)
+}
+
+// urlEncodedForms returns the percent-encoded variants of value seen in
+// query strings and form bodies: Go's query and path escaping, JavaScript's
+// encodeURIComponent, encoders that only escape spaces, and each of these
+// with either "+" or "%20" for spaces and with lower-case hex digits.
+func urlEncodedForms(value string) []string {
+	bases := []string{
+		url.QueryEscape(value),
+		url.PathEscape(value),
+		encodeURIComponent(value),
+		strings.ReplaceAll(value, " ", "+"),
+		strings.ReplaceAll(value, " ", "%20"),
+	}
+	var forms []string
+	for _, base := range bases {
+		for _, form := range []string{base, strings.ReplaceAll(base, "+", "%20"), strings.ReplaceAll(base, "%20", "+")} {
+			forms = append(forms, form, lowerPercentHex(form))
+		}
+	}
+	return forms
+}
+
+// encodeURIComponent escapes value the way JavaScript's encodeURIComponent
+// does, which leaves !'()* unescaped.
+func encodeURIComponent(value string) string {
+	escaped := url.QueryEscape(value)
+	escaped = strings.ReplaceAll(escaped, "+", "%20")
+	for _, c := range []string{"!", "'", "(", ")", "*"} {
+		escaped = strings.ReplaceAll(escaped, url.QueryEscape(c), c)
+	}
+	return escaped
+}
+
+// lowerPercentHex lower-cases the hex digits of every percent escape in s.
+func lowerPercentHex(s string) string {
+	b := []byte(s)
+	for i := 0; i+2 < len(b); i++ {
+		if b[i] == '%' {
+			b[i+1] = lowerHexDigit(b[i+1])
+			b[i+2] = lowerHexDigit(b[i+2])
+			i += 2
+		}
+	}
+	return string(b)
+}
+
+func lowerHexDigit(c byte) byte {
+	if 'A' <= c && c <= 'F' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
+
+// base64Fragment returns the base64 characters that encode only bytes of
+// value when value is preceded by align bytes.
+func base64Fragment(enc *base64.Encoding, value string, align int) string {
+	n := len(value)
+	first := (8*align + 5) / 6
+	last := (8*align+8*n)/6 - 1
+	if last < first {
+		return ""
+	}
+	padded := make([]byte, align+n+2)
+	copy(padded[align:], value)
+	encoded := enc.EncodeToString(padded)
+	return encoded[first : last+1]
+}
@@ -0,0 +1,86 @@
+package sensitivestring
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// TestScrubber_EncodedForms verifies encoded occurrences are scrubbed
+func TestScrubber_EncodedForms(t *testing.T) {
+	secret := New(`p@ss"w<rd>/?~~`)
+	sc := newScrubber([]*SensitiveString{secret})
+	value := secret.Value()
+
+	inputs := map[string]string{
+		"base64":        base64.StdEncoding.EncodeToString([]byte(value)),
+		"base64 raw":    base64.RawStdEncoding.EncodeToString([]byte(value)),
+		"base64url":     base64.URLEncoding.EncodeToString([]byte(value)),
+		"base64url raw": base64.RawURLEncoding.EncodeToString([]byte(value)),
+		"hex":           hex.EncodeToString([]byte(value)),
+		"HEX":           strings.ToUpper(hex.EncodeToString([]byte(value))),
+		"json":          `p@ss\"w<rd>/?~~`,
+		"json no html":  `p@ss\"w<rd>/?~~`,
+	}
+	for name, encoded := range inputs {
+		got := sc.scrub("value=" + encoded + ";")
+		if got != "value="+secret.String()+";" {
+			t.Errorf("scrub(%s) = %v, want the encoded secret replaced", name, got)
+		}
+	}
+}
+
+// TestScrubber_BasicAuthHeader verifies unaligned base64 fragments are scrubbed
+func TestScrubber_BasicAuthHeader(t *testing.T) {
+	secret := New("correct-horse-battery")
+	sc := newScrubber([]*SensitiveString{secret})
+
+	for _, user := range []string{"al", "bob", "carol"} {
+		header := "Authorization: Basic " + base64.StdEncoding.EncodeToString([]byte(user+":"+secret.Value()))
+		got := sc.scrub(header)
+		if !strings.Contains(got, secret.String()) {
+			t.Errorf("scrub(%q) = %v, want the password fragment replaced", header, got)
+		}
+	}
+}
+
+// TestBase64Fragment verifies fragments are independent of neighbouring bytes
+func TestBase64Fragment(t *testing.T) {
+	value := "correct-horse-battery"
+	for align := 0; align < 3; align++ {
+		fragment := base64Fragment(base64.StdEncoding, value, align)
+		for _, prefix := range []string{"\x00\x00", "\xff\xff", "ab"} {
+			encoded := base64.StdEncoding.EncodeToString([]byte(prefix[:align] + value + "\xff\xff"))
+			if !strings.Contains(encoded, fragment) {
+				t.Errorf("base64Fragment(align=%d) = %v not found in %v", align, fragment, encoded)
+			}
+		}
+	}
+	if got := base64Fragment(base64.StdEncoding, "", 1); got != "" {
+		t.Errorf("base64Fragment(empty) = %v, want empty", got)
+	}
+}
+
+// TestScrubber_URLEncodedForms verifies percent-encoded occurrences are scrubbed
+func TestScrubber_URLEncodedForms(t *testing.T) {
+	secret := New("p@ss w/rd!(x)&=é")
+	sc := newScrubber([]*SensitiveString{secret})
+
+	inputs := map[string]string{
+		"query":           url.QueryEscape(secret.Value()),
+		"path":            url.PathEscape(secret.Value()),
+		"query %20":       "p%40ss%20w%2Frd%21%28x%29%26%3D%C3%A9",
+		"lower hex":       "p%40ss+w%2frd%21%28x%29%26%3d%c3%a9",
+		"uri component":   "p%40ss%20w%2Frd!(x)%26%3D%C3%A9",
+		"only spaces +":   "p@ss+w/rd!(x)&=é",
+		"only spaces %20": "p@ss%20w/rd!(x)&=é",
+	}
+	for name, encoded := range inputs {
+		got := sc.scrub("GET /login?pw=" + encoded + "&next=1")
+		if got != "GET /login?pw="+secret.String()+"&next=1" {
+			t.Errorf("scrub(%s) = %v, want the encoded secret replaced", name, got)
+		}
+	}
+}
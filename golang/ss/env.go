@@ -0,0 +1,67 @@
+package sensitivestring
+
+import (
+	"fmt"
+	"os"
+)
+
+// EnvOption configures FromEnv.
+type EnvOption func(*envOptions)
+
+type envOptions struct {
+	defaultValue *string
+	required     bool
+	unset        bool
+}
+
+// WithEnvDefault uses value when the variable is not set.
+func WithEnvDefault(value string) EnvOption {
+	return func(o *envOptions) { o.defaultValue = &value }
+}
+
+// WithEnvRequired reports a variable that is unset or empty (and has no
+// default) as an error wrapping ErrMissingSecret.
+func WithEnvRequired() EnvOption {
+	return func(o *envOptions) { o.required = true }
+}
+
+// WithEnvUnset removes the variable from the environment once read, so
+// child processes and later os.Getenv calls no longer see it. On Linux the
+// initial environment block in /proc/self/environ is not rewritten, so
+// this shrinks the exposure window rather than closing it.
+func WithEnvUnset() EnvOption {
+	return func(o *envOptions) { o.unset = true }
+}
+
+// FromEnv reads the environment variable name into a SensitiveString
+// labeled with name. An unset variable yields the WithEnvDefault value, or
+// an empty secret, unless WithEnvRequired is given.
+func FromEnv(name string, opts ...EnvOption) (*SensitiveString, error) {
+	var options envOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	value, ok := os.LookupEnv(name)
+	if ok && options.unset {
+		if err := os.Unsetenv(name); err != nil {
+			return nil, fmt.Errorf("sensitivestring: unsetting %s: %w", name, err)
+		}
+	}
+	if !ok && options.defaultValue != nil {
+		value = *options.defaultValue
+	}
+	if options.required && value == "" {
+		return nil, fmt.Errorf("%w: environment variable %s", ErrMissingSecret, name)
+	}
+	return New(value, WithLabel(name)), nil
+}
+
+// MustFromEnv is like FromEnv but panics on error, for package-level
+// variables and main functions.
+func MustFromEnv(name string, opts ...EnvOption) *SensitiveString {
+	s, err := FromEnv(name, opts...)
+	if err != nil {
+		panic("MustFromEnv: " + err.Error())
+	}
+	return s
+}
@@ -0,0 +1,59 @@
+package sensitivestring
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+// TestFromEnv verifies values, defaults and labels read from the environment
+func TestFromEnv(t *testing.T) {
+	t.Setenv("SS_TEST_TOKEN", "hunter2")
+	t.Setenv("SS_TEST_EMPTY", "")
+
+	s, err := FromEnv("SS_TEST_TOKEN", WithEnvDefault("ignored"))
+	if err != nil || s.Value() != "hunter2" || s.Label() != "SS_TEST_TOKEN" {
+		t.Errorf("FromEnv(set) = %v (%q), %v, want hunter2 labeled SS_TEST_TOKEN", s, s.Label(), err)
+	}
+	if s, err := FromEnv("SS_TEST_UNSET", WithEnvDefault("fallback")); err != nil || s.Value() != "fallback" {
+		t.Errorf("FromEnv(unset, default) = %v, %v, want fallback", s, err)
+	}
+	if s, err := FromEnv("SS_TEST_EMPTY", WithEnvDefault("fallback")); err != nil || s.Value() != "" {
+		t.Errorf("FromEnv(empty, default) = %v, %v, want the empty value", s, err)
+	}
+	if s, err := FromEnv("SS_TEST_UNSET"); err != nil || s.Len() != 0 {
+		t.Errorf("FromEnv(unset) = %v, %v, want an empty secret", s, err)
+	}
+}
+
+// TestFromEnv_Required verifies unset and empty variables are rejected when required
+func TestFromEnv_Required(t *testing.T) {
+	t.Setenv("SS_TEST_EMPTY", "")
+	for _, name := range []string{"SS_TEST_UNSET", "SS_TEST_EMPTY"} {
+		if _, err := FromEnv(name, WithEnvRequired()); !errors.Is(err, ErrMissingSecret) {
+			t.Errorf("FromEnv(%s, required) error = %v, want ErrMissingSecret", name, err)
+		}
+	}
+	if s, err := FromEnv("SS_TEST_UNSET", WithEnvRequired(), WithEnvDefault("fallback")); err != nil || s.Value() != "fallback" {
+		t.Errorf("FromEnv(required, default) = %v, %v, want fallback", s, err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustFromEnv(unset, required) did not panic")
+		}
+	}()
+	MustFromEnv("SS_TEST_UNSET", WithEnvRequired())
+}
+
+// TestFromEnv_Unset verifies the variable is removed from the environment after reading
+func TestFromEnv_Unset(t *testing.T) {
+	t.Setenv("SS_TEST_TOKEN", "hunter2")
+	s := MustFromEnv("SS_TEST_TOKEN", WithEnvUnset())
+	if s.Value() != "hunter2" {
+		t.Errorf("MustFromEnv() = %v, want hunter2", s)
+	}
+	if _, ok := os.LookupEnv("SS_TEST_TOKEN"); ok {
+		t.Errorf("SS_TEST_TOKEN is still set after WithEnvUnset")
+	}
+}
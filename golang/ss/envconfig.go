@@ -0,0 +1,21 @@
+package sensitivestring
+
+// Decode implements the Decoder interface of
+// github.com/kelseyhightower/envconfig, and of the libraries modeled on
+// it, so SensitiveString and *SensitiveString struct fields are populated
+// straight from environment variables. The value is interpreted according
+// to the DecodeMode, as UnmarshalJSON does.
+func (s *SensitiveString) Decode(value string) error {
+	if err := s.decodeString(value); err != nil {
+		return err
+	}
+	s.digest = nil
+	return nil
+}
+
+// Set implements the Setter interface of envconfig. Together with String,
+// it also makes *SensitiveString a flag.Value whose default prints as the
+// hash form. See Decode.
+func (s *SensitiveString) Set(value string) error {
+	return s.Decode(value)
+}
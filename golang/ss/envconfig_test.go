@@ -0,0 +1,57 @@
+package sensitivestring
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"testing"
+)
+
+// envconfigDecoder and envconfigSetter mirror the interfaces of
+// github.com/kelseyhightower/envconfig.
+type (
+	envconfigDecoder interface{ Decode(value string) error }
+	envconfigSetter  interface{ Set(value string) error }
+)
+
+var (
+	_ envconfigDecoder = (*SensitiveString)(nil)
+	_ envconfigSetter  = (*SensitiveString)(nil)
+	_ flag.Value       = (*SensitiveString)(nil)
+)
+
+// TestDecode verifies Decode and Set replace the value and its cached digest
+func TestDecode(t *testing.T) {
+	var s SensitiveString
+	if err := s.Decode("foo"); err != nil || s.Value() != "foo" {
+		t.Fatalf("Decode() = %q, %v, want foo", s.Value(), err)
+	}
+	if got := s.String(); got != "sha256:"+fooHashHex {
+		t.Errorf("String() = %v, want the hash of foo", got)
+	}
+	if err := s.Set("bar"); err != nil || s.Value() != "bar" {
+		t.Fatalf("Set() = %q, %v, want bar", s.Value(), err)
+	}
+	if got := s.String(); got == "sha256:"+fooHashHex {
+		t.Errorf("String() after Set() still shows the previous digest")
+	}
+
+	withDecodeMode(t, DecodePlaintext)
+	if err := s.Decode(New("foo").String()); !errors.Is(err, ErrRedactedValue) {
+		t.Errorf("Decode(redacted) error = %v, want ErrRedactedValue", err)
+	}
+}
+
+// TestSet_Flag verifies a SensitiveString can be a command-line flag
+func TestSet_Flag(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	token := New("")
+	fs.Var(token, "token", "API token")
+	if err := fs.Parse([]string{"-token", "hunter2"}); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if token.Value() != "hunter2" {
+		t.Errorf("token = %q, want hunter2", token.Value())
+	}
+}
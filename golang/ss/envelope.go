@@ -0,0 +1,237 @@
+package sensitivestring
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// EnvelopeVersion is the newest envelope version this package writes and
+// reads. Readers accept any version from 1 up to EnvelopeVersion.
+const EnvelopeVersion = 1
+
+// CipherAES256GCM identifies AES-256-GCM with a 12-byte random nonce.
+const CipherAES256GCM = "aes-256-gcm"
+
+// KDFNone means the envelope key is used as-is rather than derived.
+const KDFNone = "none"
+
+// envelopeMagic prefixes every binary envelope.
+var envelopeMagic = []byte("SSEV")
+
+var (
+	// ErrInvalidEnvelope is returned when an envelope cannot be parsed.
+	ErrInvalidEnvelope = errors.New("sensitivestring: invalid envelope")
+	// ErrUnsupportedEnvelope is returned for envelopes written by a newer
+	// version of this package, or using an unknown cipher or KDF.
+	ErrUnsupportedEnvelope = errors.New("sensitivestring: unsupported envelope")
+)
+
+// Envelope is the versioned container for every sealed or encrypted output
+// of this package. It records everything needed to open the ciphertext
+// besides the key itself, so artifacts sealed today stay readable after
+// the defaults change.
+//
+// Envelopes have a binary form (MarshalBinary) and a JSON form
+// (json.Marshal); ParseEnvelope accepts either. Unknown header fields are
+// ignored, so later minor additions do not break older readers.
+type Envelope struct {
+	Version    int               `json:"v"`
+	Cipher     string            `json:"cipher"`
+	KDF        string            `json:"kdf"`
+	KDFParams  map[string]string `json:"kdfParams,omitempty"`
+	Nonce      []byte            `json:"nonce"`
+	Ciphertext []byte            `json:"ct"`
+}
+
+// envelopeHeader is the JSON header of the binary form.
+type envelopeHeader struct {
+	Cipher    string            `json:"cipher"`
+	KDF       string            `json:"kdf"`
+	KDFParams map[string]string `json:"kdfParams,omitempty"`
+	Nonce     []byte            `json:"nonce"`
+}
+
+// NegotiateEnvelopeVersion returns the envelope version to write for a
+// peer that reads versions up to peerMax, so newer writers can keep
+// talking to older readers. A reader advertises its EnvelopeVersion; see
+// SealFor.
+func NegotiateEnvelopeVersion(peerMax int) (int, error) {
+	if peerMax < 1 {
+		return 0, fmt.Errorf("%w: peer supports no envelope version", ErrUnsupportedEnvelope)
+	}
+	return min(peerMax, EnvelopeVersion), nil
+}
+
+// MarshalBinary encodes e as magic, version byte, big-endian header length,
+// JSON header, then ciphertext.
+func (e *Envelope) MarshalBinary() ([]byte, error) {
+	if err := e.check(); err != nil {
+		return nil, err
+	}
+	header, err := json.Marshal(envelopeHeader{Cipher: e.Cipher, KDF: e.KDF, KDFParams: e.KDFParams, Nonce: e.Nonce})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0, len(envelopeMagic)+5+len(header)+len(e.Ciphertext))
+	out = append(out, envelopeMagic...)
+	out = append(out, byte(e.Version))
+	out = binary.BigEndian.AppendUint32(out, uint32(len(header)))
+	out = append(out, header...)
+	return append(out, e.Ciphertext...), nil
+}
+
+// UnmarshalBinary decodes the binary form written by MarshalBinary.
+func (e *Envelope) UnmarshalBinary(data []byte) error {
+	if !bytes.HasPrefix(data, envelopeMagic) {
+		return fmt.Errorf("%w: missing magic", ErrInvalidEnvelope)
+	}
+	data = data[len(envelopeMagic):]
+	if len(data) < 5 {
+		return fmt.Errorf("%w: truncated header", ErrInvalidEnvelope)
+	}
+	version := int(data[0])
+	size := binary.BigEndian.Uint32(data[1:5])
+	data = data[5:]
+	if uint64(size) > uint64(len(data)) {
+		return fmt.Errorf("%w: truncated header", ErrInvalidEnvelope)
+	}
+	var header envelopeHeader
+	if err := json.Unmarshal(data[:size], &header); err != nil {
+		return fmt.Errorf("%w: malformed header", ErrInvalidEnvelope)
+	}
+	parsed := Envelope{
+		Version:    version,
+		Cipher:     header.Cipher,
+		KDF:        header.KDF,
+		KDFParams:  header.KDFParams,
+		Nonce:      header.Nonce,
+		Ciphertext: data[size:],
+	}
+	if err := parsed.check(); err != nil {
+		return err
+	}
+	*e = parsed
+	return nil
+}
+
+// ParseEnvelope decodes an envelope in either its binary or JSON form and
+// checks that this package can open it.
+func ParseEnvelope(data []byte) (*Envelope, error) {
+	e := &Envelope{}
+	if bytes.HasPrefix(data, envelopeMagic) {
+		if err := e.UnmarshalBinary(data); err != nil {
+			return nil, err
+		}
+		return e, nil
+	}
+	if err := json.Unmarshal(data, e); err != nil {
+		return nil, fmt.Errorf("%w: neither binary nor JSON", ErrInvalidEnvelope)
+	}
+	if err := e.check(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// check reports whether e is well-formed and readable by this package.
+func (e *Envelope) check() error {
+	switch {
+	case e.Version < 1 || e.Version > 255:
+		return fmt.Errorf("%w: version %d", ErrInvalidEnvelope, e.Version)
+	case e.Version > EnvelopeVersion:
+		return fmt.Errorf("%w: version %d is newer than %d", ErrUnsupportedEnvelope, e.Version, EnvelopeVersion)
+	case e.Cipher != CipherAES256GCM:
+		return fmt.Errorf("%w: cipher %q", ErrUnsupportedEnvelope, e.Cipher)
+	case e.KDF != KDFNone:
+		return fmt.Errorf("%w: kdf %q", ErrUnsupportedEnvelope, e.KDF)
+	}
+	return nil
+}
+
+// additionalData binds the ciphertext to the envelope parameters so they
+// cannot be swapped without failing authentication.
+func (e *Envelope) additionalData() []byte {
+	return []byte("sensitivestring/v" + strconv.Itoa(e.Version) + "/" + e.Cipher + "/" + e.KDF)
+}
+
+// sealEnvelope encrypts plaintext under rawKey into a current-version
+// envelope.
+func sealEnvelope(rawKey, plaintext []byte) (*Envelope, error) {
+	return sealEnvelopeVersion(rawKey, plaintext, EnvelopeVersion)
+}
+
+// sealEnvelopeVersion encrypts plaintext under rawKey into an envelope of
+// the given version.
+func sealEnvelopeVersion(rawKey, plaintext []byte, version int) (*Envelope, error) {
+	aead, err := newBundleAEAD(rawKey)
+	if err != nil {
+		return nil, err
+	}
+	e := &Envelope{Version: version, Cipher: CipherAES256GCM, KDF: KDFNone, Nonce: make([]byte, aead.NonceSize())}
+	if _, err := rand.Read(e.Nonce); err != nil {
+		return nil, err
+	}
+	e.Ciphertext = aead.Seal(nil, e.Nonce, plaintext, e.additionalData())
+	return e, nil
+}
+
+// open decrypts the envelope with rawKey.
+func (e *Envelope) open(rawKey []byte) ([]byte, error) {
+	if err := e.check(); err != nil {
+		return nil, err
+	}
+	aead, err := newBundleAEAD(rawKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEnvelope, err)
+	}
+	if len(e.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("%w: bad nonce", ErrInvalidEnvelope)
+	}
+	plaintext, err := aead.Open(nil, e.Nonce, e.Ciphertext, e.additionalData())
+	if err != nil {
+		return nil, fmt.Errorf("%w: authentication failed", ErrInvalidEnvelope)
+	}
+	return plaintext, nil
+}
+
+// Seal encrypts the value under a 32-byte AES-256-GCM key and returns it
+// as a binary Envelope, for persisting or transmitting the secret itself
+// rather than its hash.
+func (s *SensitiveString) Seal(key []byte) ([]byte, error) {
+	return s.SealFor(key, EnvelopeVersion)
+}
+
+// SealFor is like Seal, but writes the envelope version negotiated with
+// NegotiateEnvelopeVersion for a peer that reads versions up to peerMax.
+func (s *SensitiveString) SealFor(key []byte, peerMax int) ([]byte, error) {
+	version, err := NegotiateEnvelopeVersion(peerMax)
+	if err != nil {
+		return nil, err
+	}
+	plaintext := []byte(s.Value())
+	defer clear(plaintext)
+	e, err := sealEnvelopeVersion(key, plaintext, version)
+	if err != nil {
+		return nil, err
+	}
+	return e.MarshalBinary()
+}
+
+// Unseal opens an envelope produced by Seal.
+func Unseal(sealed, key []byte) (*SensitiveString, error) {
+	e, err := ParseEnvelope(sealed)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := e.open(key)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(plaintext)
+	return New(string(plaintext)), nil
+}
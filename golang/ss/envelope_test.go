@@ -0,0 +1,183 @@
+package sensitivestring
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// testEnvelope seals plaintext under a fresh key for envelope tests
+func testEnvelope(t *testing.T, plaintext string) (*Envelope, []byte) {
+	t.Helper()
+	key := make([]byte, 32)
+	rand.Read(key)
+	e, err := sealEnvelope(key, []byte(plaintext))
+	if err != nil {
+		t.Fatalf("sealEnvelope() error = %v", err)
+	}
+	return e, key
+}
+
+// TestEnvelope_BinaryRoundTrip verifies the binary form parses back and opens
+func TestEnvelope_BinaryRoundTrip(t *testing.T) {
+	e, key := testEnvelope(t, "hunter2")
+	data, err := e.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+	if !bytes.HasPrefix(data, []byte("SSEV\x01")) {
+		t.Errorf("MarshalBinary() = %q, want magic and version prefix", data[:5])
+	}
+
+	parsed, err := ParseEnvelope(data)
+	if err != nil {
+		t.Fatalf("ParseEnvelope() error = %v", err)
+	}
+	plaintext, err := parsed.open(key)
+	if err != nil || string(plaintext) != "hunter2" {
+		t.Errorf("open() = %q, %v, want hunter2", plaintext, err)
+	}
+}
+
+// TestEnvelope_JSONRoundTrip verifies the JSON form parses back and opens
+func TestEnvelope_JSONRoundTrip(t *testing.T) {
+	e, key := testEnvelope(t, "hunter2")
+	data, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	parsed, err := ParseEnvelope(data)
+	if err != nil {
+		t.Fatalf("ParseEnvelope() error = %v", err)
+	}
+	plaintext, err := parsed.open(key)
+	if err != nil || string(plaintext) != "hunter2" {
+		t.Errorf("open() = %q, %v, want hunter2", plaintext, err)
+	}
+}
+
+// TestEnvelope_UnknownFieldsIgnored verifies forward-compatible header parsing
+func TestEnvelope_UnknownFieldsIgnored(t *testing.T) {
+	e, key := testEnvelope(t, "hunter2")
+	data := []byte(`{"v":1,"cipher":"aes-256-gcm","kdf":"none","future":{"x":1},"nonce":"` +
+		base64.StdEncoding.EncodeToString(e.Nonce) + `","ct":"` + base64.StdEncoding.EncodeToString(e.Ciphertext) + `"}`)
+
+	parsed, err := ParseEnvelope(data)
+	if err != nil {
+		t.Fatalf("ParseEnvelope() error = %v", err)
+	}
+	if plaintext, err := parsed.open(key); err != nil || string(plaintext) != "hunter2" {
+		t.Errorf("open() = %q, %v, want hunter2", plaintext, err)
+	}
+}
+
+// TestParseEnvelope_Errors verifies malformed and unsupported envelopes are rejected
+func TestParseEnvelope_Errors(t *testing.T) {
+	e, _ := testEnvelope(t, "hunter2")
+	valid, _ := e.MarshalBinary()
+	newer := bytes.Clone(valid)
+	newer[4] = EnvelopeVersion + 1
+
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"garbage", []byte("garbage"), ErrInvalidEnvelope},
+		{"truncated", valid[:6], ErrInvalidEnvelope},
+		{"bad header length", append([]byte("SSEV\x01\xff\xff\xff\xff"), valid[9:]...), ErrInvalidEnvelope},
+		{"version zero", []byte(`{"v":0,"cipher":"aes-256-gcm","kdf":"none"}`), ErrInvalidEnvelope},
+		{"newer version", newer, ErrUnsupportedEnvelope},
+		{"unknown cipher", []byte(`{"v":1,"cipher":"rot13","kdf":"none"}`), ErrUnsupportedEnvelope},
+		{"unknown kdf", []byte(`{"v":1,"cipher":"aes-256-gcm","kdf":"md5"}`), ErrUnsupportedEnvelope},
+	}
+	for _, tt := range tests {
+		if _, err := ParseEnvelope(tt.data); !errors.Is(err, tt.want) {
+			t.Errorf("ParseEnvelope(%s) error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+// TestNegotiateEnvelopeVersion verifies writers fall back to the highest version a reader supports
+func TestNegotiateEnvelopeVersion(t *testing.T) {
+	if got, err := NegotiateEnvelopeVersion(EnvelopeVersion + 5); err != nil || got != EnvelopeVersion {
+		t.Errorf("NegotiateEnvelopeVersion(newer) = %v, %v, want %v", got, err, EnvelopeVersion)
+	}
+	if got, err := NegotiateEnvelopeVersion(1); err != nil || got != 1 {
+		t.Errorf("NegotiateEnvelopeVersion(1) = %v, %v, want 1", got, err)
+	}
+	if _, err := NegotiateEnvelopeVersion(0); !errors.Is(err, ErrUnsupportedEnvelope) {
+		t.Errorf("NegotiateEnvelopeVersion(0) error = %v, want ErrUnsupportedEnvelope", err)
+	}
+}
+
+// TestSealFor_VersionMismatch verifies a reader rejects newer envelopes and opens negotiated ones
+func TestSealFor_VersionMismatch(t *testing.T) {
+	key := make([]byte, 32)
+	rand.Read(key)
+
+	// A writer that is a version ahead produces envelopes this reader
+	// cannot open...
+	sealed, err := New("hunter2").Seal(key)
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+	newer := bytes.Clone(sealed)
+	newer[4] = EnvelopeVersion + 1
+	if _, err := Unseal(newer, key); !errors.Is(err, ErrUnsupportedEnvelope) {
+		t.Errorf("Unseal(newer) error = %v, want ErrUnsupportedEnvelope", err)
+	}
+
+	// ...until it negotiates down to the version the reader advertises.
+	sealed, err = New("hunter2").SealFor(key, EnvelopeVersion)
+	if err != nil {
+		t.Fatalf("SealFor() error = %v", err)
+	}
+	if sealed[4] != EnvelopeVersion {
+		t.Errorf("SealFor() wrote version %d, want %d", sealed[4], EnvelopeVersion)
+	}
+	if s, err := Unseal(sealed, key); err != nil || s.Value() != "hunter2" {
+		t.Errorf("Unseal(negotiated) = %v, %v, want hunter2", s, err)
+	}
+	if _, err := New("hunter2").SealFor(key, 0); !errors.Is(err, ErrUnsupportedEnvelope) {
+		t.Errorf("SealFor(no common version) error = %v, want ErrUnsupportedEnvelope", err)
+	}
+}
+
+// TestOpenBundle_RejectsBareCiphertext verifies bundles must be envelopes
+func TestOpenBundle_RejectsBareCiphertext(t *testing.T) {
+	rawKey := make([]byte, 32)
+	rand.Read(rawKey)
+	aead, _ := newBundleAEAD(rawKey)
+	nonce := make([]byte, aead.NonceSize())
+	sealed := aead.Seal(nonce, nonce, []byte(`{"db":"hunter2"}`), nil)
+
+	if _, err := OpenBundle(sealed, New(base64.RawURLEncoding.EncodeToString(rawKey))); !errors.Is(err, ErrInvalidBundle) {
+		t.Errorf("OpenBundle(bare ciphertext) error = %v, want ErrInvalidBundle", err)
+	}
+}
+
+// TestSeal_RoundTrip verifies a sealed secret opens with its key only
+func TestSeal_RoundTrip(t *testing.T) {
+	key := make([]byte, 32)
+	rand.Read(key)
+	sealed, err := New("hunter2").Seal(key)
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+	if bytes.Contains(sealed, []byte("hunter2")) {
+		t.Errorf("Seal() output contains plaintext")
+	}
+
+	opened, err := Unseal(sealed, key)
+	if err != nil || opened.Value() != "hunter2" {
+		t.Errorf("Unseal() = %v, %v, want hunter2", opened.Value(), err)
+	}
+	if _, err := Unseal(sealed, make([]byte, 32)); !errors.Is(err, ErrInvalidEnvelope) {
+		t.Errorf("Unseal(wrong key) error = %v, want ErrInvalidEnvelope", err)
+	}
+}
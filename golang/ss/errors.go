@@ -0,0 +1,55 @@
+package sensitivestring
+
+import "fmt"
+
+// ErrorfWithSecret is like fmt.Errorf, but every SensitiveString argument is
+// replaced by its identity: its label, if any, followed by its hash, e.g.
+// "db-password(sha256:…)". Error messages can then still say which
+// credential failed without leaking it.
+func ErrorfWithSecret(format string, args ...interface{}) error {
+	return fmt.Errorf(format, identifySecrets(args)...)
+}
+
+// identifySecrets returns a copy of args with SensitiveStrings replaced by
+// their identity.
+func identifySecrets(args []interface{}) []interface{} {
+	result := make([]interface{}, len(args))
+	for i, arg := range args {
+		switch v := arg.(type) {
+		case *SensitiveString:
+			if v == nil {
+				result[i] = "<nil>"
+				continue
+			}
+			result[i] = v.identity()
+		case SensitiveString:
+			result[i] = v.identity()
+		default:
+			result[i] = arg
+		}
+	}
+	return result
+}
+
+// identity returns the label and hash of the value.
+func (s SensitiveString) identity() string {
+	if s.label == "" {
+		return s.String()
+	}
+	return s.label + "(" + s.String() + ")"
+}
+
+// scrubbedError carries a scrubbed message in place of the message of err,
+// while keeping err reachable through errors.Is and errors.As.
+type scrubbedError struct {
+	msg string
+	err error
+}
+
+func (e *scrubbedError) Error() string {
+	return e.msg
+}
+
+func (e *scrubbedError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,36 @@
+package sensitivestring
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// TestErrorfWithSecret verifies secrets are identified by label and hash
+func TestErrorfWithSecret(t *testing.T) {
+	ss := NewLabeled("db-password", "foo")
+	err := ErrorfWithSecret("authentication with %s failed: %w", ss, io.ErrUnexpectedEOF)
+
+	expected := "authentication with db-password(sha256:" + fooHashHex + ") failed: unexpected EOF"
+	if got := err.Error(); got != expected {
+		t.Errorf("ErrorfWithSecret() = %v, want %v", got, expected)
+	}
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("ErrorfWithSecret() should wrap %%w arguments")
+	}
+}
+
+// TestErrorfWithSecret_Unlabeled verifies value, unlabeled and nil secrets
+func TestErrorfWithSecret_Unlabeled(t *testing.T) {
+	var missing *SensitiveString
+	err := ErrorfWithSecret("%v %v %q", *New("foo"), missing, New("bar"))
+
+	got := err.Error()
+	if !strings.HasPrefix(got, "sha256:"+fooHashHex+" <nil> ") {
+		t.Errorf("ErrorfWithSecret() = %v", got)
+	}
+	if strings.Contains(got, "foo") || strings.Contains(got, "\"bar\"") {
+		t.Errorf("ErrorfWithSecret() leaked a value: %v", got)
+	}
+}
@@ -0,0 +1,116 @@
+package sensitivestring
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+// DefaultMaxSecretSize is the largest secret FromFile and FromReader read
+// unless WithMaxSize says otherwise.
+const DefaultMaxSecretSize = 64 << 10
+
+// ErrSecretTooLarge is returned by FromFile and FromReader for input over
+// the maximum size.
+var ErrSecretTooLarge = errors.New("sensitivestring: secret exceeds maximum size")
+
+// FileOption configures FromFile and FromReader.
+type FileOption func(*fileOptions)
+
+type fileOptions struct {
+	trimNewline bool
+	maxSize     int64
+}
+
+// WithTrimNewline drops a single trailing "\n" or "\r\n", such as the one
+// editors and "echo" leave at the end of a password file.
+func WithTrimNewline() FileOption {
+	return func(o *fileOptions) { o.trimNewline = true }
+}
+
+// WithMaxSize rejects input over n bytes with ErrSecretTooLarge. The
+// default is DefaultMaxSecretSize; n <= 0 or math.MaxInt64 means no limit.
+func WithMaxSize(n int64) FileOption {
+	return func(o *fileOptions) { o.maxSize = n }
+}
+
+// FromFile reads the secret held in the file at path, e.g. a password file
+// mounted by an orchestrator. See FromReader.
+func FromFile(path string, opts ...FileOption) (*SensitiveString, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	s, err := FromReader(f, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("sensitivestring: reading %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// FromReader reads r to EOF into a SensitiveString. The content is read
+// into buffers that are cleared once copied into the secret, so it never
+// exists as a plain string or stray byte slice.
+func FromReader(r io.Reader, opts ...FileOption) (*SensitiveString, error) {
+	options := fileOptions{maxSize: DefaultMaxSecretSize}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	data, err := readLimited(r, options.maxSize)
+	defer clear(data)
+	if err != nil {
+		return nil, err
+	}
+	if options.trimNewline {
+		data = trimNewline(data)
+	}
+	return NewFromBytes(data), nil
+}
+
+// readLimited reads r to EOF, failing once more than limit bytes arrive; a
+// limit <= 0 means none. Unlike io.ReadAll, it clears each buffer it
+// outgrows.
+func readLimited(r io.Reader, limit int64) ([]byte, error) {
+	if limit <= 0 {
+		limit = math.MaxInt64
+	}
+	// Room for one byte over the limit, so that exceeding it shows.
+	bound := limit
+	if limit < math.MaxInt64 {
+		bound = limit + 1
+	}
+	buf := make([]byte, 0, min(512, bound))
+	for {
+		if len(buf) == cap(buf) {
+			grown := make([]byte, len(buf), min(2*int64(cap(buf)), bound))
+			copy(grown, buf)
+			clear(buf)
+			buf = grown
+		}
+		n, err := r.Read(buf[len(buf):cap(buf)])
+		buf = buf[:len(buf)+n]
+		if int64(len(buf)) > limit {
+			clear(buf)
+			return nil, fmt.Errorf("%w of %d bytes", ErrSecretTooLarge, limit)
+		}
+		if err == io.EOF {
+			return buf, nil
+		}
+		if err != nil {
+			clear(buf)
+			return nil, err
+		}
+	}
+}
+
+// trimNewline drops a single trailing "\n" or "\r\n" from data.
+func trimNewline(data []byte) []byte {
+	if trimmed, ok := bytes.CutSuffix(data, []byte("\n")); ok {
+		data, _ = bytes.CutSuffix(trimmed, []byte("\r"))
+	}
+	return data
+}
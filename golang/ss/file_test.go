@@ -0,0 +1,84 @@
+package sensitivestring
+
+import (
+	"errors"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// TestFromReader verifies content is read whole and the trailing newline trimmed on request
+func TestFromReader(t *testing.T) {
+	long := strings.Repeat("x", 5000)
+	tests := []struct {
+		name  string
+		input string
+		opts  []FileOption
+		want  string
+	}{
+		{"verbatim", "hunter2\n", nil, "hunter2\n"},
+		{"trim", "hunter2\n", []FileOption{WithTrimNewline()}, "hunter2"},
+		{"trim crlf", "hunter2\r\n", []FileOption{WithTrimNewline()}, "hunter2"},
+		{"trim once", "hunter2\n\n", []FileOption{WithTrimNewline()}, "hunter2\n"},
+		{"empty", "", []FileOption{WithTrimNewline()}, ""},
+		{"grows", long, nil, long},
+		{"at limit", "12345", []FileOption{WithMaxSize(5)}, "12345"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := iotest.OneByteReader(strings.NewReader(tt.input))
+			s, err := FromReader(r, tt.opts...)
+			if err != nil || s.Value() != tt.want {
+				t.Errorf("FromReader() = %q, %v, want %q", s.Value(), err, tt.want)
+			}
+		})
+	}
+}
+
+// TestFromReader_Errors verifies oversized input and read errors are reported
+func TestFromReader_Errors(t *testing.T) {
+	if _, err := FromReader(strings.NewReader("123456"), WithMaxSize(5)); !errors.Is(err, ErrSecretTooLarge) {
+		t.Errorf("FromReader(oversized) error = %v, want ErrSecretTooLarge", err)
+	}
+	big := strings.NewReader(strings.Repeat("x", DefaultMaxSecretSize+1))
+	if _, err := FromReader(big); !errors.Is(err, ErrSecretTooLarge) {
+		t.Errorf("FromReader(over default) error = %v, want ErrSecretTooLarge", err)
+	}
+	failing := errors.New("disk on fire")
+	if _, err := FromReader(iotest.ErrReader(failing)); !errors.Is(err, failing) {
+		t.Errorf("FromReader(failing) error = %v, want %v", err, failing)
+	}
+}
+
+// TestFromReader_NoLimit verifies sizes of zero or less and math.MaxInt64 lift the limit
+func TestFromReader_NoLimit(t *testing.T) {
+	large := strings.Repeat("x", DefaultMaxSecretSize+1)
+	for _, n := range []int64{0, -1, -5, math.MaxInt64} {
+		s, err := FromReader(strings.NewReader(large), WithMaxSize(n))
+		if err != nil || s.Value() != large {
+			t.Errorf("FromReader(WithMaxSize(%d)) = %d bytes, %v, want %d bytes", n, s.Len(), err, len(large))
+		}
+	}
+}
+
+// TestFromFile verifies password files are read and missing ones reported
+func TestFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(path, []byte("hunter2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if s, err := FromFile(path, WithTrimNewline()); err != nil || s.Value() != "hunter2" {
+		t.Errorf("FromFile() = %v, %v, want hunter2", s, err)
+	}
+	_, err := FromFile(path, WithMaxSize(4))
+	if !errors.Is(err, ErrSecretTooLarge) || !strings.Contains(err.Error(), path) {
+		t.Errorf("FromFile(oversized) error = %v, want ErrSecretTooLarge naming the path", err)
+	}
+	if _, err := FromFile(filepath.Join(t.TempDir(), "missing")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("FromFile(missing) error = %v, want fs.ErrNotExist", err)
+	}
+}
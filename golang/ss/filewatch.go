@@ -0,0 +1,126 @@
+package sensitivestring
+
+import (
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// WatchedFile is a secret read from a file and kept in step with it, e.g.
+// a mounted Kubernetes secret or a certificate key that an agent rotates.
+// Create one with NewFromFileWatched.
+type WatchedFile struct {
+	path    string
+	options watchOptions
+	current atomic.Pointer[SensitiveString]
+	watcher *fsnotify.Watcher
+	done    chan struct{}
+	close   sync.Once
+}
+
+// NewFromFileWatched reads the secret at path and keeps reloading it when
+// the file changes, so Current always reflects the content on disk.
+//
+// The file's directory is watched with fsnotify rather than the file
+// itself, so replacements are seen however they are made: editors and
+// config agents that write a temporary file and rename it over the
+// original, and kubelet, which swaps a "..data" symlink that the file
+// points through. Where fsnotify is unavailable, the file is polled every
+// WithPollInterval instead. WithWatchErrors reports failed reloads, e.g.
+// the file being briefly absent; the last content read stays current.
+// The file is read as by FromFile with WithTrimNewline, so a trailing
+// newline is dropped and content over DefaultMaxSecretSize is an error.
+// Call Close to stop watching.
+func NewFromFileWatched(path string, opts ...WatchOption) (*WatchedFile, error) {
+	options := watchOptions{interval: DefaultPollInterval}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	w := &WatchedFile{path: path, options: options, done: make(chan struct{})}
+	s, err := FromFile(path, WithTrimNewline())
+	if err != nil {
+		return nil, err
+	}
+	w.current.Store(s)
+
+	if watcher, err := fsnotify.NewWatcher(); err == nil {
+		if err := watcher.Add(filepath.Dir(path)); err == nil {
+			w.watcher = watcher
+			go w.watchEvents()
+			return w, nil
+		}
+		watcher.Close()
+	}
+	go w.poll()
+	return w, nil
+}
+
+// Current returns the secret as last read from the file.
+func (w *WatchedFile) Current() *SensitiveString {
+	return w.current.Load()
+}
+
+// Close stops watching the file. Current keeps returning the last content
+// read.
+func (w *WatchedFile) Close() error {
+	var err error
+	w.close.Do(func() {
+		close(w.done)
+		if w.watcher != nil {
+			err = w.watcher.Close()
+		}
+	})
+	return err
+}
+
+// watchEvents reloads the file on every change in its directory.
+func (w *WatchedFile) watchEvents() {
+	for {
+		select {
+		case _, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			w.reload()
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			w.reportError(err)
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// poll reloads the file every poll interval.
+func (w *WatchedFile) poll() {
+	for {
+		select {
+		case <-GetClock().After(w.options.interval):
+			w.reload()
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// reload reads the file and makes its content current if it changed.
+func (w *WatchedFile) reload() {
+	s, err := FromFile(w.path, WithTrimNewline())
+	if err != nil {
+		w.reportError(err)
+		return
+	}
+	if !s.Equal(w.current.Load()) {
+		w.current.Store(s)
+	}
+}
+
+func (w *WatchedFile) reportError(err error) {
+	if w.options.onError != nil {
+		w.options.onError(err)
+	}
+}
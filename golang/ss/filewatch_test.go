@@ -0,0 +1,102 @@
+package sensitivestring
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// eventually waits for w to hold want.
+func eventually(t *testing.T, w *WatchedFile, want string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for w.Current().Value() != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("Current() = %q, want %q", w.Current().Value(), want)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// TestNewFromFileWatched verifies in-place writes and atomic renames are picked up
+func TestNewFromFileWatched(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "token")
+	os.WriteFile(path, []byte("v1"), 0o600)
+
+	w, err := NewFromFileWatched(path)
+	if err != nil {
+		t.Fatalf("NewFromFileWatched() error = %v", err)
+	}
+	defer w.Close()
+	if w.Current().Value() != "v1" {
+		t.Fatalf("Current() = %q, want v1", w.Current().Value())
+	}
+
+	os.WriteFile(path, []byte("v2\n"), 0o600)
+	eventually(t, w, "v2")
+
+	tmp := filepath.Join(dir, ".token.tmp")
+	os.WriteFile(tmp, []byte("v3"), 0o600)
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+	eventually(t, w, "v3")
+
+	if _, err := NewFromFileWatched(filepath.Join(dir, "absent")); err == nil {
+		t.Errorf("NewFromFileWatched(absent) error = nil")
+	}
+}
+
+// TestNewFromFileWatched_SymlinkSwap verifies kubelet-style "..data" swaps are picked up
+func TestNewFromFileWatched_SymlinkSwap(t *testing.T) {
+	dir := t.TempDir()
+	for version, value := range map[string]string{"..v1": "old", "..v2": "new"} {
+		os.Mkdir(filepath.Join(dir, version), 0o755)
+		os.WriteFile(filepath.Join(dir, version, "password"), []byte(value), 0o600)
+	}
+	if err := os.Symlink("..v1", filepath.Join(dir, "..data")); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+	os.Symlink(filepath.Join("..data", "password"), filepath.Join(dir, "password"))
+
+	w, err := NewFromFileWatched(filepath.Join(dir, "password"))
+	if err != nil {
+		t.Fatalf("NewFromFileWatched() error = %v", err)
+	}
+	defer w.Close()
+	if w.Current().Value() != "old" {
+		t.Fatalf("Current() = %q, want old", w.Current().Value())
+	}
+	os.Symlink("..v2", filepath.Join(dir, "..data_tmp"))
+	if err := os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")); err != nil {
+		t.Fatal(err)
+	}
+	eventually(t, w, "new")
+}
+
+// TestWatchedFile_Poll verifies the polling fallback reloads on the clock and reports errors
+func TestWatchedFile_Poll(t *testing.T) {
+	clock := withManualClock(t)
+	path := filepath.Join(t.TempDir(), "token")
+	os.WriteFile(path, []byte("v1"), 0o600)
+	errs := make(chan error, 1)
+	w := &WatchedFile{path: path, options: watchOptions{interval: time.Minute, onError: func(err error) { errs <- err }}, done: make(chan struct{})}
+	w.current.Store(New("v1"))
+	go w.poll()
+	defer w.Close()
+
+	os.WriteFile(path, []byte("v2"), 0o600)
+	pollOnce(t, clock)
+	eventually(t, w, "v2")
+
+	os.Remove(path)
+	pollOnce(t, clock)
+	if err := <-errs; err == nil {
+		t.Errorf("reload of a missing file reported nil")
+	}
+	if w.Current().Value() != "v2" {
+		t.Errorf("Current() after a failed reload = %q, want v2", w.Current().Value())
+	}
+}
@@ -0,0 +1,45 @@
+package sensitivestring
+
+// DefaultFingerprintLength is the number of hex characters returned by
+// Fingerprint unless changed with SetFingerprintLength.
+const DefaultFingerprintLength = 8
+
+// HashPrefix returns the first n hex characters of the value's digest,
+// computed like String() (algorithm, salt and fingerprint key included) but
+// without the algorithm prefix. n <= 0 or n beyond the digest returns the
+// whole digest.
+//
+// Short prefixes are meant for correlating log lines and labeling metrics,
+// not for identifying secrets: n hex characters leave 16^n possible values,
+// so among k distinct secrets a collision becomes likely once k approaches
+// 16^(n/2) (about 65,000 secrets for the default of 8). Use a longer prefix
+// when many secrets share the same log stream.
+// Uses a value receiver so it is callable on both value and pointer types.
+func (s SensitiveString) HashPrefix(n int) string {
+	_, hash := s.hashHex()
+	if n <= 0 || n > len(hash) {
+		return hash
+	}
+	return hash[:n]
+}
+
+// Fingerprint returns a short, stable identifier for the value: its
+// HashPrefix of the package-wide fingerprint length.
+// Uses a value receiver so it is callable on both value and pointer types.
+func (s SensitiveString) Fingerprint() string {
+	return s.HashPrefix(loadConfig().fingerprintLength)
+}
+
+// SetFingerprintLength sets the number of hex characters returned by
+// Fingerprint. It panics if n is not positive.
+func SetFingerprintLength(n int) {
+	if n <= 0 {
+		panic("SetFingerprintLength: length must be positive")
+	}
+	updateConfig(func(c *config) { c.fingerprintLength = n })
+}
+
+// GetFingerprintLength returns the package-wide fingerprint length.
+func GetFingerprintLength() int {
+	return loadConfig().fingerprintLength
+}
@@ -0,0 +1,55 @@
+package sensitivestring
+
+import "testing"
+
+// TestHashPrefix verifies prefixes of the digest and the full-digest fallback
+func TestHashPrefix(t *testing.T) {
+	ss := New("foo")
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{1, fooHashHex[:1]},
+		{12, fooHashHex[:12]},
+		{0, fooHashHex},
+		{-1, fooHashHex},
+		{100, fooHashHex},
+	}
+	for _, tt := range tests {
+		if got := ss.HashPrefix(tt.n); got != tt.want {
+			t.Errorf("HashPrefix(%d) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+}
+
+// TestFingerprint verifies the default and configured fingerprint lengths
+func TestFingerprint(t *testing.T) {
+	ss := New("foo")
+	if got := ss.Fingerprint(); got != fooHashHex[:DefaultFingerprintLength] {
+		t.Errorf("Fingerprint() = %v, want %v", got, fooHashHex[:DefaultFingerprintLength])
+	}
+
+	SetFingerprintLength(16)
+	t.Cleanup(func() { SetFingerprintLength(DefaultFingerprintLength) })
+	if got := GetFingerprintLength(); got != 16 {
+		t.Errorf("GetFingerprintLength() = %d, want 16", got)
+	}
+	if got := ss.Fingerprint(); got != fooHashHex[:16] {
+		t.Errorf("Fingerprint() = %v, want %v", got, fooHashHex[:16])
+	}
+
+	withFingerprintKey(t, []byte("pepper"))
+	if got := ss.Fingerprint(); got == fooHashHex[:16] || len(got) != 16 {
+		t.Errorf("Fingerprint() with a key = %v, want a different 16 character prefix", got)
+	}
+}
+
+// TestSetFingerprintLength_Invalid verifies non-positive lengths panic
+func TestSetFingerprintLength_Invalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("SetFingerprintLength(0) did not panic")
+		}
+	}()
+	SetFingerprintLength(0)
+}
@@ -0,0 +1,9 @@
+package sensitivestring
+
+import "bytes"
+
+// NewFromBytes creates a new SensitiveString from a copy of b. The caller
+// may zero b as soon as NewFromBytes returns.
+func NewFromBytes(b []byte) *SensitiveString {
+	return &SensitiveString{store: &byteStorage{buf: bytes.Clone(b)}}
+}
@@ -0,0 +1,30 @@
+package sensitivestring
+
+import (
+	"testing"
+	"unsafe"
+)
+
+// TestNew_AliasedSource verifies zeroing the caller's buffer does not affect the secret
+func TestNew_AliasedSource(t *testing.T) {
+	buf := []byte("hunter2")
+	aliased := unsafe.String(&buf[0], len(buf))
+
+	copied := New(aliased)
+	clear(buf)
+
+	if got := copied.Value(); got != "hunter2" {
+		t.Errorf("New().Value() after zeroing source = %q, want hunter2", got)
+	}
+}
+
+// TestNewFromBytes verifies the secret is independent of the source slice
+func TestNewFromBytes(t *testing.T) {
+	buf := []byte("hunter2")
+	ss := NewFromBytes(buf)
+	clear(buf)
+
+	if got := ss.Value(); got != "hunter2" {
+		t.Errorf("NewFromBytes().Value() after zeroing source = %q, want hunter2", got)
+	}
+}
@@ -1,4 +1,16 @@
 module github.com/earlye/sensitive-strings/golang/ss
+
 go 1.25.3
 
-require gopkg.in/yaml.v3 v3.0.1
+require (
+	github.com/awnumar/memguard v0.23.0
+	github.com/fsnotify/fsnotify v1.10.1
+	github.com/rivo/uniseg v0.4.7
+	golang.org/x/crypto v0.55.0
+	golang.org/x/sync v0.22.0
+	golang.org/x/sys v0.47.0
+	golang.org/x/text v0.41.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require github.com/awnumar/memcall v0.4.0 // indirect
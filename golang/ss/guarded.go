@@ -0,0 +1,68 @@
+//go:build memguard
+
+package sensitivestring
+
+import (
+	"sync"
+
+	"github.com/awnumar/memguard"
+)
+
+// guardedStorage is a storage backend that keeps the plaintext in a
+// memguard Enclave: encrypted in memory, with the key itself held in
+// guarded pages, and decrypted into a locked buffer only while in use.
+type guardedStorage struct {
+	mu      sync.Mutex
+	enclave *memguard.Enclave
+	n       int
+}
+
+// NewGuarded creates a new SensitiveString whose plaintext is sealed in a
+// memguard Enclave, for processes running alongside untrusted tenants that
+// need secrets encrypted while resident. The plaintext is only decrypted
+// for the duration of Use, or of Value() and the other methods that need a
+// string. It is only available when built with the memguard build tag.
+func NewGuarded(value string) *SensitiveString {
+	// NewEnclave wipes its argument, so hand it a copy.
+	return &SensitiveString{store: &guardedStorage{enclave: memguard.NewEnclave([]byte(value)), n: len(value)}}
+}
+
+// open calls fn with the decrypted plaintext in a locked buffer that is
+// destroyed when fn returns.
+func (g *guardedStorage) open(fn func([]byte)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.enclave == nil {
+		fn(nil)
+		return
+	}
+	buf, err := g.enclave.Open()
+	if err != nil {
+		panic("sensitivestring: cannot open memguard enclave: " + err.Error())
+	}
+	defer buf.Destroy()
+	fn(buf.Bytes())
+}
+
+func (g *guardedStorage) reveal() string {
+	var value string
+	g.open(func(b []byte) { value = string(b) })
+	return value
+}
+
+func (g *guardedStorage) size() int {
+	return g.n
+}
+
+// isLocked reports true: memguard keeps its keys and decrypted buffers in
+// locked memory.
+func (g *guardedStorage) isLocked() bool {
+	return true
+}
+
+func (g *guardedStorage) wipe() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.enclave = nil
+	g.n = 0
+}
@@ -0,0 +1,29 @@
+//go:build memguard
+
+package sensitivestring
+
+import "testing"
+
+// TestNewGuarded verifies guarded secrets round-trip and hash like plain ones
+func TestNewGuarded(t *testing.T) {
+	ss := NewGuarded("foo")
+	if got := ss.Value(); got != "foo" {
+		t.Errorf("Value() = %q, want foo", got)
+	}
+	if got := ss.Len(); got != 3 {
+		t.Errorf("Len() = %d, want 3", got)
+	}
+	if got := ss.String(); got != "sha256:"+fooHashHex {
+		t.Errorf("String() = %v, want %v", got, "sha256:"+fooHashHex)
+	}
+	var used string
+	ss.Use(func(b []byte) { used = string(b) })
+	if used != "foo" {
+		t.Errorf("Use() got %q, want foo", used)
+	}
+
+	ss.Zero()
+	if got := ss.Value(); got != "" {
+		t.Errorf("Value() after Zero = %q, want empty", got)
+	}
+}
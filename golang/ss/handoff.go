@@ -0,0 +1,128 @@
+package sensitivestring
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// maxBundleSize bounds the sealed bundle ReceiveBundle will accept.
+const maxBundleSize = 16 << 20
+
+// ErrInvalidBundle is returned when a sealed bundle cannot be opened.
+var ErrInvalidBundle = errors.New("sensitivestring: invalid sealed bundle")
+
+// SealBundle encrypts secrets with a fresh ephemeral AES-256-GCM key, for
+// handing credentials from a supervisor to a worker process without
+// environment variables or disk. The sealed bundle can travel over any
+// channel (a pipe, shared memory); the returned key must be delivered
+// out-of-band, e.g. over a separate inherited file descriptor.
+func SealBundle(secrets map[string]*SensitiveString) ([]byte, *SensitiveString, error) {
+	rawKey := make([]byte, 32)
+	if _, err := rand.Read(rawKey); err != nil {
+		return nil, nil, err
+	}
+	defer clear(rawKey)
+
+	plaintext, err := json.Marshal(UnwrapMapValues(secrets))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer clear(plaintext)
+
+	envelope, err := sealEnvelope(rawKey, plaintext)
+	if err != nil {
+		return nil, nil, err
+	}
+	sealed, err := envelope.MarshalBinary()
+	if err != nil {
+		return nil, nil, err
+	}
+	return sealed, NewLabeled("bundle-key", base64.RawURLEncoding.EncodeToString(rawKey)), nil
+}
+
+// OpenBundle decrypts a bundle produced by SealBundle. Each secret is
+// labeled with its name.
+func OpenBundle(sealed []byte, key *SensitiveString) (map[string]*SensitiveString, error) {
+	rawKey, err := base64.RawURLEncoding.DecodeString(key.Value())
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed key", ErrInvalidBundle)
+	}
+	defer clear(rawKey)
+
+	plaintext, err := openSealed(sealed, rawKey)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(plaintext)
+
+	var values map[string]string
+	if err := json.Unmarshal(plaintext, &values); err != nil {
+		return nil, fmt.Errorf("%w: malformed contents", ErrInvalidBundle)
+	}
+	return WrapMapValues(values), nil
+}
+
+// SendBundle seals secrets and writes the length-prefixed bundle to w. The
+// returned key must be delivered to the receiver out-of-band.
+func SendBundle(w io.Writer, secrets map[string]*SensitiveString) (*SensitiveString, error) {
+	sealed, key, err := SealBundle(secrets)
+	if err != nil {
+		return nil, err
+	}
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(sealed)))
+	if _, err := w.Write(length[:]); err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(sealed); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// ReceiveBundle reads a bundle written by SendBundle from r and opens it
+// with key.
+func ReceiveBundle(r io.Reader, key *SensitiveString) (map[string]*SensitiveString, error) {
+	var length [4]byte
+	if _, err := io.ReadFull(r, length[:]); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(length[:])
+	if size > maxBundleSize {
+		return nil, fmt.Errorf("%w: too large", ErrInvalidBundle)
+	}
+	sealed := make([]byte, size)
+	if _, err := io.ReadFull(r, sealed); err != nil {
+		return nil, err
+	}
+	return OpenBundle(sealed, key)
+}
+
+// openSealed decrypts the binary envelope of a bundle.
+func openSealed(sealed, rawKey []byte) ([]byte, error) {
+	envelope := &Envelope{}
+	if err := envelope.UnmarshalBinary(sealed); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+	}
+	plaintext, err := envelope.open(rawKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+	}
+	return plaintext, nil
+}
+
+// newBundleAEAD returns the AES-GCM cipher for a bundle key.
+func newBundleAEAD(rawKey []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(rawKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,98 @@
+package sensitivestring
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// TestSealBundle_RoundTrip verifies sealing and opening a bundle
+func TestSealBundle_RoundTrip(t *testing.T) {
+	secrets := WrapMapValues(map[string]string{"db": "hunter2", "api": "token-123"})
+
+	sealed, key, err := SealBundle(secrets)
+	if err != nil {
+		t.Fatalf("SealBundle() error = %v", err)
+	}
+	if bytes.Contains(sealed, []byte("hunter2")) {
+		t.Errorf("sealed bundle contains plaintext")
+	}
+
+	opened, err := OpenBundle(sealed, key)
+	if err != nil {
+		t.Fatalf("OpenBundle() error = %v", err)
+	}
+	if got, want := UnwrapMapValues(opened), UnwrapMapValues(secrets); !reflect.DeepEqual(got, want) {
+		t.Errorf("OpenBundle() = %v, want %v", got, want)
+	}
+	if got := opened["db"].Label(); got != "db" {
+		t.Errorf("OpenBundle()[db].Label() = %v, want db", got)
+	}
+}
+
+// TestOpenBundle_Invalid verifies wrong keys and tampering are rejected
+func TestOpenBundle_Invalid(t *testing.T) {
+	sealed, key, err := SealBundle(WrapMapValues(map[string]string{"db": "hunter2"}))
+	if err != nil {
+		t.Fatalf("SealBundle() error = %v", err)
+	}
+	_, otherKey, _ := SealBundle(nil)
+
+	tampered := bytes.Clone(sealed)
+	tampered[len(tampered)-1] ^= 1
+
+	tests := []struct {
+		name   string
+		sealed []byte
+		key    *SensitiveString
+	}{
+		{"wrong key", sealed, otherKey},
+		{"malformed key", sealed, New("!!")},
+		{"short key", sealed, New("AAAA")},
+		{"tampered", tampered, key},
+		{"truncated", sealed[:4], key},
+	}
+	for _, tt := range tests {
+		if _, err := OpenBundle(tt.sealed, tt.key); !errors.Is(err, ErrInvalidBundle) {
+			t.Errorf("OpenBundle(%s) error = %v, want ErrInvalidBundle", tt.name, err)
+		}
+	}
+}
+
+// TestSendBundle_Pipe verifies a handoff over an OS pipe
+func TestSendBundle_Pipe(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() error = %v", err)
+	}
+	defer r.Close()
+
+	keys := make(chan *SensitiveString, 1)
+	go func() {
+		defer w.Close()
+		key, err := SendBundle(w, WrapMapValues(map[string]string{"db": "hunter2"}))
+		if err != nil {
+			t.Errorf("SendBundle() error = %v", err)
+		}
+		keys <- key
+	}()
+
+	key := <-keys
+	received, err := ReceiveBundle(r, key)
+	if err != nil {
+		t.Fatalf("ReceiveBundle() error = %v", err)
+	}
+	if got := received["db"].Value(); got != "hunter2" {
+		t.Errorf("ReceiveBundle()[db] = %v, want hunter2", got)
+	}
+
+	if _, err := ReceiveBundle(bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff}), key); !errors.Is(err, ErrInvalidBundle) {
+		t.Errorf("ReceiveBundle(oversized) error = %v, want ErrInvalidBundle", err)
+	}
+	if _, err := ReceiveBundle(bytes.NewReader(nil), key); err != io.EOF {
+		t.Errorf("ReceiveBundle(empty) error = %v, want EOF", err)
+	}
+}
@@ -0,0 +1,174 @@
+package sensitivestring
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// HealthChecker is implemented by providers that can report whether their
+// backend is usable, e.g. that a Vault token is still valid or cloud
+// credentials have not expired, without resolving a secret.
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// Checker aggregates the health checks of several providers, e.g. for a
+// /healthz endpoint. The zero value is not usable; create checkers with
+// NewChecker or Resolver.Checker.
+type Checker struct {
+	mu     sync.RWMutex
+	checks map[string]HealthChecker
+}
+
+// NewChecker creates a Checker with no checks.
+func NewChecker() *Checker {
+	return &Checker{checks: make(map[string]HealthChecker)}
+}
+
+// Add registers the health check of provider under name. Providers that
+// do not implement HealthChecker are ignored.
+func (c *Checker) Add(name string, provider Provider) {
+	hc, ok := provider.(HealthChecker)
+	if !ok {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks[name] = hc
+}
+
+// CheckAll runs every check concurrently and returns each one's result by
+// name; nil means healthy.
+func (c *Checker) CheckAll(ctx context.Context) map[string]error {
+	c.mu.RLock()
+	checks := make(map[string]HealthChecker, len(c.checks))
+	for name, hc := range c.checks {
+		checks[name] = hc
+	}
+	c.mu.RUnlock()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := make(map[string]error, len(checks))
+	for name, hc := range checks {
+		wg.Go(func() {
+			err := hc.HealthCheck(ctx)
+			mu.Lock()
+			defer mu.Unlock()
+			results[name] = err
+		})
+	}
+	wg.Wait()
+	return results
+}
+
+// Check runs every check and returns the failures joined, each naming its
+// provider, or nil if all are healthy.
+func (c *Checker) Check(ctx context.Context) error {
+	results := c.CheckAll(ctx)
+	names := make([]string, 0, len(results))
+	for name := range results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var errs []error
+	for _, name := range names {
+		if err := results[name]; err != nil {
+			errs = append(errs, fmt.Errorf("sensitivestring: provider %s unhealthy: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ServeHTTP implements http.Handler for health endpoints: it responds 200
+// when every check passes and 503 otherwise, with a JSON body giving each
+// provider's status, "ok" or "unhealthy". Error messages can reveal
+// backend addresses or account details, so they are not sent to the client
+// but logged with the default slog logger.
+func (c *Checker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	results := c.CheckAll(r.Context())
+	body := struct {
+		Status    string            `json:"status"`
+		Providers map[string]string `json:"providers"`
+	}{Status: "ok", Providers: make(map[string]string, len(results))}
+	status := http.StatusOK
+	for name, err := range results {
+		body.Providers[name] = "ok"
+		if err != nil {
+			slog.WarnContext(r.Context(), "sensitivestring: provider unhealthy", "provider", name, "error", err)
+			body.Providers[name] = "unhealthy"
+			body.Status = "unhealthy"
+			status = http.StatusServiceUnavailable
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// Checker returns a Checker for every provider registered with r that
+// implements HealthChecker, as of the call.
+func (r *Resolver) Checker() *Checker {
+	c := NewChecker()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for name, registered := range r.providers {
+		c.Add(name, registered.provider)
+	}
+	return c
+}
+
+// HealthCheck implements HealthChecker by checking the underlying
+// provider, if it can be checked.
+func (c *CachedProvider) HealthCheck(ctx context.Context) error {
+	return checkHealth(ctx, c.provider)
+}
+
+// HealthCheck implements HealthChecker by checking the underlying
+// provider, if it can be checked, once.
+func (r retryProvider) HealthCheck(ctx context.Context) error {
+	return checkHealth(ctx, r.provider)
+}
+
+// HealthCheck implements HealthChecker by checking the underlying
+// provider, if it can be checked, within the same timeout as resolutions.
+func (t timeoutProvider) HealthCheck(ctx context.Context) error {
+	_, err := within(ctx, t.timeout, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, checkHealth(ctx, t.provider)
+	})
+	return err
+}
+
+// HealthCheck implements HealthChecker by checking the underlying
+// provider, if it can be checked.
+func (d dedupeProvider) HealthCheck(ctx context.Context) error {
+	return checkHealth(ctx, d.provider)
+}
+
+// checkHealth runs the health check of p, or reports p healthy if it has
+// none.
+func checkHealth(ctx context.Context, p Provider) error {
+	if hc, ok := p.(HealthChecker); ok {
+		return hc.HealthCheck(ctx)
+	}
+	return nil
+}
+
+// HealthCheck implements HealthChecker by checking every provider in the
+// chain that can be checked, so a broken primary is reported even while
+// a fallback keeps resolution working.
+func (c chainProvider) HealthCheck(ctx context.Context) error {
+	var errs []error
+	for _, p := range c {
+		if hc, ok := p.(HealthChecker); ok {
+			errs = append(errs, hc.HealthCheck(ctx))
+		}
+	}
+	return errors.Join(errs...)
+}
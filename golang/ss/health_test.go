@@ -0,0 +1,86 @@
+package sensitivestring
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// checkedProvider is a MapProvider with a configurable health check.
+type checkedProvider struct {
+	MapProvider
+	err error
+}
+
+func (p checkedProvider) HealthCheck(ctx context.Context) error { return p.err }
+
+// TestChecker verifies failures are aggregated by provider and forwarded through decorators
+func TestChecker(t *testing.T) {
+	expired := errors.New("token expired")
+	r := NewResolver()
+	r.Register("vault", Cache(checkedProvider{err: expired}, 0))
+	r.Register("files", checkedProvider{})
+	r.Register("env", MapProvider{})
+	r.Register("chain", ChainProvider(checkedProvider{err: expired}, MapProvider{}))
+	r.Register("wrapped", Retry(Timeout(Dedupe(checkedProvider{err: expired}), time.Second)))
+
+	c := r.Checker()
+	results := c.CheckAll(context.Background())
+	if len(results) != 4 || results["files"] != nil || !errors.Is(results["vault"], expired) ||
+		!errors.Is(results["chain"], expired) || !errors.Is(results["wrapped"], expired) {
+		t.Errorf("CheckAll() = %v", results)
+	}
+	err := c.Check(context.Background())
+	if !errors.Is(err, expired) || !strings.Contains(err.Error(), "provider vault unhealthy") {
+		t.Errorf("Check() error = %v", err)
+	}
+
+	healthy := NewChecker()
+	healthy.Add("files", checkedProvider{})
+	healthy.Add("env", MapProvider{})
+	if err := healthy.Check(context.Background()); err != nil {
+		t.Errorf("Check() of healthy providers error = %v", err)
+	}
+}
+
+// TestChecker_ServeHTTP verifies the health endpoint status code and body
+func TestChecker_ServeHTTP(t *testing.T) {
+	c := NewChecker()
+	c.Add("files", checkedProvider{})
+	rec := httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("ServeHTTP() status = %d, want 200", rec.Code)
+	}
+
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	c.Add("vault", checkedProvider{err: errors.New("permission denied for role admin at 10.0.0.7")})
+	rec = httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	var body struct {
+		Status    string
+		Providers map[string]string
+	}
+	json.Unmarshal(rec.Body.Bytes(), &body)
+	if rec.Code != http.StatusServiceUnavailable || body.Status != "unhealthy" ||
+		body.Providers["files"] != "ok" || body.Providers["vault"] != "unhealthy" {
+		t.Errorf("ServeHTTP() = %d %s", rec.Code, rec.Body)
+	}
+	if strings.Contains(rec.Body.String(), "10.0.0.7") {
+		t.Errorf("ServeHTTP() body exposes the provider error: %s", rec.Body)
+	}
+	if got := logs.String(); !strings.Contains(got, "provider=vault") || !strings.Contains(got, "10.0.0.7") {
+		t.Errorf("ServeHTTP() logged %q, want the provider error", got)
+	}
+}
@@ -0,0 +1,64 @@
+package sensitivestring
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// NewProxyClient returns an *http.Client that sends requests through the
+// proxy at proxyURL, authenticating with username and password. proxyURL
+// must not carry credentials itself.
+//
+// Transport errors are scrubbed of the credentials, including the
+// credential-bearing proxy URL that proxy authentication failures can echo,
+// so they are safe to log.
+func NewProxyClient(proxyURL string, username, password *SensitiveString) (*http.Client, error) {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.User != nil {
+		return nil, errors.New("sensitivestring: proxy URL must not contain credentials")
+	}
+	u.User = url.UserPassword(username.Value(), password.Value())
+
+	registry := NewRegistry()
+	registry.Register(username, password, NewLabeled("proxy-userinfo", u.User.String()))
+
+	transport := cloneDefaultTransport()
+	transport.Proxy = http.ProxyURL(u)
+	return &http.Client{Transport: &scrubbingTransport{base: transport, registry: registry}}, nil
+}
+
+// cloneDefaultTransport returns a copy of http.DefaultTransport, or a new
+// transport if a program has replaced it with another RoundTripper.
+func cloneDefaultTransport() *http.Transport {
+	if t, ok := http.DefaultTransport.(*http.Transport); ok {
+		return t.Clone()
+	}
+	return &http.Transport{}
+}
+
+// scrubbingTransport scrubs registered secrets from the errors returned by
+// its base RoundTripper.
+type scrubbingTransport struct {
+	base     http.RoundTripper
+	registry *Registry
+}
+
+func (t *scrubbingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, t.registry.ScrubError(err)
+	}
+	return resp, nil
+}
+
+// CloseIdleConnections forwards to the base RoundTripper, so that
+// http.Client.CloseIdleConnections keeps working.
+func (t *scrubbingTransport) CloseIdleConnections() {
+	if c, ok := t.base.(interface{ CloseIdleConnections() }); ok {
+		c.CloseIdleConnections()
+	}
+}
@@ -0,0 +1,40 @@
+//go:build go1.27 && goexperiment.jsonv2
+
+package sensitivestring
+
+import (
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+)
+
+// MarshalJSONTo implements json.MarshalerTo from encoding/json/v2,
+// producing the same output as MarshalJSON without an intermediate buffer.
+// Uses a value receiver so it is callable on both value and pointer types.
+func (s SensitiveString) MarshalJSONTo(enc *jsontext.Encoder) error {
+	recordRedaction(FormatJSON, s.label, 1)
+	return json.MarshalEncode(enc, s.marshalValue())
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom from encoding/json/v2.
+// It accepts the same input as UnmarshalJSON, subject to the same
+// DecodeMode and compatibility settings.
+func (s *SensitiveString) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	value, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+	return s.UnmarshalJSON(value)
+}
+
+// JSONPlaintext returns an encoding/json/v2 option that marshals
+// SensitiveStrings as their plaintext, for the rare call site that must
+// persist secrets, e.g. writing a credentials file:
+//
+//	json.Marshal(creds, sensitivestring.JSONPlaintext())
+//
+// Without it, encoding/json/v2 emits the redacted form like encoding/json.
+func JSONPlaintext() json.Options {
+	return json.WithMarshalers(json.MarshalToFunc(func(enc *jsontext.Encoder, s SensitiveString) error {
+		return enc.WriteToken(jsontext.String(s.plaintext()))
+	}))
+}
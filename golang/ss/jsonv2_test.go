@@ -0,0 +1,64 @@
+//go:build go1.27 && goexperiment.jsonv2
+
+package sensitivestring
+
+import (
+	"encoding/json/v2"
+	"strings"
+	"testing"
+)
+
+type jsonV2Creds struct {
+	User     string           `json:"user"`
+	Password *SensitiveString `json:"password"`
+	Token    SensitiveString  `json:"token"`
+}
+
+// TestJSONv2_Redacted verifies encoding/json/v2 emits the redacted form
+func TestJSONv2_Redacted(t *testing.T) {
+	data, err := json.Marshal(jsonV2Creds{User: "alice", Password: New("hunter2"), Token: *New("tok")})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	want := `{"user":"alice","password":"` + New("hunter2").String() + `","token":"` + New("tok").String() + `"}`
+	if string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+
+	var got jsonV2Creds
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if got.Password.Value() != New("hunter2").String() {
+		t.Errorf("Password = %v, want the hash form", got.Password.Value())
+	}
+}
+
+// TestJSONv2_Structured verifies the MarshalStructured mode applies
+func TestJSONv2_Structured(t *testing.T) {
+	withMarshalMode(t, MarshalStructured)
+	data, err := json.Marshal(NewLabeled("db", "hunter2"))
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"label":"db"`) || strings.Contains(string(data), "hunter2") {
+		t.Errorf("json.Marshal() = %s, want the structured form", data)
+	}
+}
+
+// TestJSONPlaintext verifies the option selects plaintext output
+func TestJSONPlaintext(t *testing.T) {
+	data, err := json.Marshal(jsonV2Creds{User: "alice", Password: New("hunter2"), Token: *New("tok")}, JSONPlaintext())
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if want := `{"user":"alice","password":"hunter2","token":"tok"}`; string(data) != want {
+		t.Errorf("json.Marshal(JSONPlaintext) = %s, want %s", data, want)
+	}
+
+	withDecodeMode(t, DecodePlaintext)
+	var got jsonV2Creds
+	if err := json.Unmarshal(data, &got); err != nil || got.Password.Value() != "hunter2" {
+		t.Errorf("json.Unmarshal() = %v, %v, want hunter2", got.Password.Value(), err)
+	}
+}
@@ -0,0 +1,17 @@
+package sensitivestring
+
+import "errors"
+
+// Keyring identifies a Linux kernel keyring by its special keyring ID.
+type Keyring int32
+
+const (
+	ThreadKeyring  Keyring = -1
+	ProcessKeyring Keyring = -2
+	SessionKeyring Keyring = -3
+	UserKeyring    Keyring = -4
+)
+
+// ErrKeyringUnsupported is returned by the keyring functions on platforms
+// other than Linux.
+var ErrKeyringUnsupported = errors.New("sensitivestring: kernel keyrings are not supported on this platform")
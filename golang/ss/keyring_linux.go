@@ -0,0 +1,122 @@
+//go:build linux
+
+package sensitivestring
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"golang.org/x/sys/unix"
+)
+
+// keyringStorage is a storage backend that keeps the plaintext in kernel
+// memory as a "user" key, reading it back on demand. Only the key serial
+// number lives in the Go heap.
+type keyringStorage struct {
+	id      int
+	n       int
+	revoked atomic.Bool
+}
+
+// NewInKeyring stores value in keyring as a "user" key with the given
+// description and returns a SensitiveString holding only its handle. The
+// description becomes the SensitiveString's label. An existing key with the
+// same description in that keyring is updated.
+func NewInKeyring(keyring Keyring, description string, value string) (*SensitiveString, error) {
+	id, err := unix.AddKey("user", description, []byte(value), int(keyring))
+	if err != nil {
+		return nil, err
+	}
+	return &SensitiveString{label: description, store: &keyringStorage{id: id, n: len(value)}}, nil
+}
+
+// FromKeyring looks up an existing "user" key by description in keyring
+// (and the keyrings linked from it) and returns a SensitiveString holding
+// its handle.
+func FromKeyring(keyring Keyring, description string) (*SensitiveString, error) {
+	id, err := unix.KeyctlSearch(int(keyring), "user", description, 0)
+	if err != nil {
+		return nil, err
+	}
+	// Without a buffer, KEYCTL_READ reports the payload size only.
+	size, err := unix.KeyctlBuffer(unix.KEYCTL_READ, id, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &SensitiveString{label: description, store: &keyringStorage{id: id, n: size}}, nil
+}
+
+// RemoveFromKeyring revokes the kernel key backing ss, if it has one.
+// Afterwards Value() returns an empty string and Reveal returns
+// ErrDestroyed. Other SensitiveStrings found with FromKeyring for the same
+// key can no longer read it.
+func RemoveFromKeyring(ss *SensitiveString) error {
+	if ss == nil {
+		return nil
+	}
+	k, ok := ss.store.(*keyringStorage)
+	if !ok || k.revoked.Swap(true) {
+		return nil
+	}
+	_, err := unix.KeyctlInt(unix.KEYCTL_REVOKE, k.id, 0, 0, 0)
+	return err
+}
+
+// reveal returns "" once the key was revoked through this SensitiveString,
+// and panics if the key cannot be read otherwise, e.g. because it was
+// revoked elsewhere or has expired; returning "" would silently hand out an
+// empty secret. Reveal reports the error instead.
+func (k *keyringStorage) reveal() string {
+	if k.revoked.Load() {
+		return ""
+	}
+	value, err := k.tryReveal()
+	if err != nil {
+		panic(err.Error())
+	}
+	return value
+}
+
+func (k *keyringStorage) tryReveal() (string, error) {
+	if k.revoked.Load() {
+		return "", ErrDestroyed
+	}
+	buf, err := readKey(k.id)
+	if err != nil {
+		return "", fmt.Errorf("sensitivestring: reading kernel key %d: %w", k.id, err)
+	}
+	defer clear(buf)
+	return string(buf), nil
+}
+
+func (k *keyringStorage) size() int {
+	return k.n
+}
+
+func (k *keyringStorage) wipe() {
+	if !k.revoked.Swap(true) {
+		unix.KeyctlInt(unix.KEYCTL_REVOKE, k.id, 0, 0, 0)
+	}
+}
+
+// readKey reads the payload of the key with the given serial number. The
+// caller must clear the returned buffer.
+func readKey(id int) ([]byte, error) {
+	for {
+		size, err := unix.KeyctlBuffer(unix.KEYCTL_READ, id, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size)
+		n, err := unix.KeyctlBuffer(unix.KEYCTL_READ, id, buf, 0)
+		if err != nil {
+			clear(buf)
+			return nil, err
+		}
+		if n <= size {
+			return buf[:n], nil
+		}
+		// The key was updated with a longer payload between the calls.
+		clear(buf)
+	}
+}
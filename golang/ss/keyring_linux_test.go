@@ -0,0 +1,98 @@
+//go:build linux
+
+package sensitivestring
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+// newTestKey stores value in the process keyring, skipping the test when
+// the sandbox does not allow keyctl.
+func newTestKey(t *testing.T, value string) (*SensitiveString, string) {
+	t.Helper()
+	description := fmt.Sprintf("sensitivestring-test-%d-%s", os.Getpid(), t.Name())
+	ss, err := NewInKeyring(ProcessKeyring, description, value)
+	if errors.Is(err, unix.ENOSYS) || errors.Is(err, unix.EPERM) || errors.Is(err, unix.EACCES) {
+		t.Skipf("kernel keyrings unavailable: %v", err)
+	}
+	if err != nil {
+		t.Fatalf("NewInKeyring() error = %v", err)
+	}
+	t.Cleanup(func() { RemoveFromKeyring(ss) })
+	return ss, description
+}
+
+// TestNewInKeyring verifies values round-trip through the kernel keyring
+func TestNewInKeyring(t *testing.T) {
+	ss, description := newTestKey(t, "foo")
+
+	if ss.value != "" {
+		t.Errorf("keyring-backed secret holds plaintext in the Go struct")
+	}
+	if got := ss.Value(); got != "foo" {
+		t.Errorf("Value() = %v, want foo", got)
+	}
+	if got := ss.Len(); got != 3 {
+		t.Errorf("Len() = %v, want 3", got)
+	}
+	if got := ss.Label(); got != description {
+		t.Errorf("Label() = %v, want %v", got, description)
+	}
+	if got := ss.String(); got != "sha256:"+fooHashHex {
+		t.Errorf("String() = %v, want %v", got, "sha256:"+fooHashHex)
+	}
+
+	found, err := FromKeyring(ProcessKeyring, description)
+	if err != nil {
+		t.Fatalf("FromKeyring() error = %v", err)
+	}
+	if got := found.Value(); got != "foo" {
+		t.Errorf("FromKeyring().Value() = %v, want foo", got)
+	}
+
+	if err := RemoveFromKeyring(ss); err != nil {
+		t.Fatalf("RemoveFromKeyring() error = %v", err)
+	}
+	if got := ss.Value(); got != "" {
+		t.Errorf("Value() after RemoveFromKeyring = %v, want empty", got)
+	}
+}
+
+// TestFromKeyring_Missing verifies lookups of unknown keys fail
+func TestFromKeyring_Missing(t *testing.T) {
+	newTestKey(t, "unused")
+	if _, err := FromKeyring(ProcessKeyring, "sensitivestring-test-missing"); err == nil {
+		t.Errorf("FromKeyring(missing) error = nil, want error")
+	}
+}
+
+// TestKeyring_RevokedElsewhere verifies a key that can no longer be read is reported, not revealed as empty
+func TestKeyring_RevokedElsewhere(t *testing.T) {
+	ss, description := newTestKey(t, "foo")
+	found, err := FromKeyring(ProcessKeyring, description)
+	if err != nil {
+		t.Fatalf("FromKeyring() error = %v", err)
+	}
+	if got := found.Len(); got != 3 {
+		t.Errorf("FromKeyring().Len() = %v, want 3", got)
+	}
+
+	RemoveFromKeyring(ss)
+	if _, err := ss.Reveal(); !errors.Is(err, ErrDestroyed) {
+		t.Errorf("Reveal() after RemoveFromKeyring error = %v, want ErrDestroyed", err)
+	}
+	if _, err := found.Reveal(); !errors.Is(err, unix.EKEYREVOKED) {
+		t.Errorf("Reveal() of a key revoked elsewhere error = %v, want EKEYREVOKED", err)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Value() of a key revoked elsewhere did not panic")
+		}
+	}()
+	found.Value()
+}
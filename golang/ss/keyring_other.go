@@ -0,0 +1,21 @@
+//go:build !linux
+
+package sensitivestring
+
+// NewInKeyring is only supported on Linux; elsewhere it returns
+// ErrKeyringUnsupported.
+func NewInKeyring(keyring Keyring, description string, value string) (*SensitiveString, error) {
+	return nil, ErrKeyringUnsupported
+}
+
+// FromKeyring is only supported on Linux; elsewhere it returns
+// ErrKeyringUnsupported.
+func FromKeyring(keyring Keyring, description string) (*SensitiveString, error) {
+	return nil, ErrKeyringUnsupported
+}
+
+// RemoveFromKeyring is only supported on Linux; elsewhere it returns
+// ErrKeyringUnsupported.
+func RemoveFromKeyring(ss *SensitiveString) error {
+	return ErrKeyringUnsupported
+}
@@ -0,0 +1,59 @@
+// Package logrusx provides a logrus hook that keeps secrets out of log
+// entries, including plaintext that application code extracted with
+// Value() and then logged as an ordinary string.
+package logrusx
+
+import (
+	"github.com/sirupsen/logrus"
+
+	ss "github.com/earlye/sensitive-strings/golang/ss"
+)
+
+// Hook is a logrus.Hook that replaces, in every entry's message and data,
+// SensitiveStrings with their hash form and the plaintext of registered
+// secrets with the hash of that secret.
+type Hook struct {
+	registry *ss.Registry
+}
+
+// NewHook returns a Hook scrubbing the secrets in registry. A nil registry
+// means the ss.DefaultRegistry at the time each entry is written.
+func NewHook(registry *ss.Registry) *Hook {
+	return &Hook{registry: registry}
+}
+
+// Levels implements logrus.Hook; the hook applies to every level.
+func (h *Hook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements logrus.Hook.
+func (h *Hook) Fire(entry *logrus.Entry) error {
+	registry := h.registry
+	if registry == nil {
+		registry = ss.DefaultRegistry()
+	}
+	entry.Message = registry.Scrub(entry.Message)
+	data := make(logrus.Fields, len(entry.Data))
+	for key, value := range entry.Data {
+		data[key] = scrubValue(registry, value)
+	}
+	entry.Data = data
+	return nil
+}
+
+// scrubValue returns value with secrets removed. SensitiveStrings become
+// their hash form; everything else, including values nested in maps and
+// slices, is scrubbed by registry.ScrubValue.
+func scrubValue(registry *ss.Registry, value interface{}) interface{} {
+	switch v := value.(type) {
+	case *ss.SensitiveString:
+		if v == nil {
+			return v
+		}
+		return v.String()
+	case ss.SensitiveString:
+		return v.String()
+	}
+	return registry.ScrubValue(value)
+}
@@ -0,0 +1,68 @@
+package logrusx
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	ss "github.com/earlye/sensitive-strings/golang/ss"
+)
+
+const plaintext = "logrus-secret-plaintext"
+
+// newLogger returns a JSON logger writing to buf with a Hook for registry.
+func newLogger(buf *bytes.Buffer, registry *ss.Registry) *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(buf)
+	logger.SetFormatter(&logrus.JSONFormatter{})
+	logger.AddHook(NewHook(registry))
+	return logger
+}
+
+// TestHook verifies message and data are scrubbed
+func TestHook(t *testing.T) {
+	registry := ss.NewRegistry()
+	secret := ss.New(plaintext)
+	registry.Register(secret)
+
+	var buf bytes.Buffer
+	newLogger(&buf, registry).WithFields(logrus.Fields{
+		"raw":     plaintext,
+		"bytes":   []byte(plaintext),
+		"err":     errors.New("auth failed for " + plaintext),
+		"wrapped": ss.New("other-secret"),
+		"req":     map[string]interface{}{"pw": plaintext},
+		"args":    []interface{}{"user", []string{plaintext}},
+		"port":    5432,
+	}).Info("token " + plaintext)
+
+	out := buf.String()
+	for _, leaked := range []string{plaintext, "other-secret"} {
+		if strings.Contains(out, leaked) {
+			t.Errorf("Hook leaked %q: %s", leaked, out)
+		}
+	}
+	if !strings.Contains(out, `"msg":"token `+secret.String()+`"`) {
+		t.Errorf("Hook message = %s, want scrubbed message", out)
+	}
+	if !strings.Contains(out, `"port":5432`) {
+		t.Errorf("Hook altered unrelated field: %s", out)
+	}
+}
+
+// TestHook_DefaultRegistry verifies a nil registry uses the default registry
+func TestHook_DefaultRegistry(t *testing.T) {
+	previous := ss.DefaultRegistry()
+	ss.SetDefaultRegistry(ss.NewRegistry())
+	t.Cleanup(func() { ss.SetDefaultRegistry(previous) })
+	ss.Register(ss.New(plaintext))
+
+	var buf bytes.Buffer
+	newLogger(&buf, nil).WithField("raw", plaintext).Warn("hello")
+	if strings.Contains(buf.String(), plaintext) {
+		t.Errorf("Hook leaked plaintext: %s", buf.String())
+	}
+}
@@ -0,0 +1,20 @@
+module github.com/earlye/sensitive-strings/golang/ss/logrx
+
+go 1.25.3
+
+require (
+	github.com/earlye/sensitive-strings/golang/ss v0.0.1
+	github.com/go-logr/logr v1.4.4
+)
+
+require (
+	github.com/awnumar/memcall v0.4.0 // indirect
+	github.com/awnumar/memguard v0.23.0 // indirect
+	github.com/fsnotify/fsnotify v1.10.1 // indirect
+	github.com/rivo/uniseg v0.4.7 // indirect
+	golang.org/x/crypto v0.55.0 // indirect
+	golang.org/x/sync v0.22.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+	golang.org/x/text v0.41.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
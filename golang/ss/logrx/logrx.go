@@ -0,0 +1,96 @@
+// Package logrx provides a logr.LogSink wrapper that keeps secrets out of
+// logr, controller-runtime and klog output. SensitiveString itself
+// implements logr.Marshaler; the wrapper additionally catches plaintext
+// that application code extracted with Value() and logged as an ordinary
+// string.
+package logrx
+
+import (
+	"github.com/go-logr/logr"
+
+	ss "github.com/earlye/sensitive-strings/golang/ss"
+)
+
+// scrubbingSink scrubs registered secrets from messages, errors and
+// key/value pairs before passing them on.
+type scrubbingSink struct {
+	sink     logr.LogSink
+	registry *ss.Registry
+}
+
+// NewScrubbingSink wraps sink so that the plaintext of each secret in
+// registry is replaced by its hash form in messages, errors and values,
+// including values added with WithValues. A nil registry means the
+// ss.DefaultRegistry at the time each entry is logged.
+func NewScrubbingSink(sink logr.LogSink, registry *ss.Registry) logr.LogSink {
+	return &scrubbingSink{sink: sink, registry: registry}
+}
+
+// Wrap returns logger with its sink wrapped by NewScrubbingSink.
+func Wrap(logger logr.Logger, registry *ss.Registry) logr.Logger {
+	return logger.WithSink(NewScrubbingSink(logger.GetSink(), registry))
+}
+
+// Init implements logr.LogSink, accounting for the wrapper's stack frame.
+func (s *scrubbingSink) Init(info logr.RuntimeInfo) {
+	info.CallDepth++
+	s.sink.Init(info)
+}
+
+// Enabled implements logr.LogSink.
+func (s *scrubbingSink) Enabled(level int) bool {
+	return s.sink.Enabled(level)
+}
+
+// Info implements logr.LogSink.
+func (s *scrubbingSink) Info(level int, msg string, keysAndValues ...interface{}) {
+	registry := s.getRegistry()
+	s.sink.Info(level, registry.Scrub(msg), scrubValues(registry, keysAndValues)...)
+}
+
+// Error implements logr.LogSink.
+func (s *scrubbingSink) Error(err error, msg string, keysAndValues ...interface{}) {
+	registry := s.getRegistry()
+	s.sink.Error(registry.ScrubError(err), registry.Scrub(msg), scrubValues(registry, keysAndValues)...)
+}
+
+// WithValues implements logr.LogSink.
+func (s *scrubbingSink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	return &scrubbingSink{sink: s.sink.WithValues(scrubValues(s.getRegistry(), keysAndValues)...), registry: s.registry}
+}
+
+// WithName implements logr.LogSink.
+func (s *scrubbingSink) WithName(name string) logr.LogSink {
+	return &scrubbingSink{sink: s.sink.WithName(name), registry: s.registry}
+}
+
+// WithCallDepth implements logr.CallDepthLogSink when the wrapped sink
+// does.
+func (s *scrubbingSink) WithCallDepth(depth int) logr.LogSink {
+	if sink, ok := s.sink.(logr.CallDepthLogSink); ok {
+		return &scrubbingSink{sink: sink.WithCallDepth(depth), registry: s.registry}
+	}
+	return s
+}
+
+// getRegistry returns the registry to scrub with.
+func (s *scrubbingSink) getRegistry() *ss.Registry {
+	if s.registry == nil {
+		return ss.DefaultRegistry()
+	}
+	return s.registry
+}
+
+// scrubValues returns a copy of keysAndValues with secrets removed from
+// the values by registry.ScrubValue, including values nested in maps and
+// slices.
+func scrubValues(registry *ss.Registry, keysAndValues []interface{}) []interface{} {
+	result := make([]interface{}, len(keysAndValues))
+	for i, value := range keysAndValues {
+		if i%2 == 1 {
+			value = registry.ScrubValue(value)
+		}
+		result[i] = value
+	}
+	return result
+}
@@ -0,0 +1,88 @@
+package logrx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+
+	ss "github.com/earlye/sensitive-strings/golang/ss"
+)
+
+const plaintext = "logr-secret-plaintext"
+
+// TestMarshaler verifies funcr renders SensitiveStrings through logr.Marshaler
+func TestMarshaler(t *testing.T) {
+	var out []string
+	logger := funcr.NewJSON(func(obj string) { out = append(out, obj) }, funcr.Options{})
+	secret := ss.New(plaintext)
+	logger.Info("login", "password", secret, "value", *secret)
+
+	if len(out) != 1 || strings.Contains(out[0], plaintext) {
+		t.Fatalf("logger output = %v, want one line without plaintext", out)
+	}
+	if got := strings.Count(out[0], `"`+secret.String()+`"`); got != 2 {
+		t.Errorf("logger output = %s, want 2 hash forms", out[0])
+	}
+}
+
+// TestNewScrubbingSink verifies plaintext is scrubbed from every part of an entry
+func TestNewScrubbingSink(t *testing.T) {
+	registry := ss.NewRegistry()
+	secret := ss.New(plaintext)
+	registry.Register(secret)
+
+	var out []string
+	logger := Wrap(funcr.NewJSON(func(obj string) { out = append(out, obj) }, funcr.Options{}), registry)
+	logger = logger.WithName("ctrl").WithValues("ctx", plaintext)
+	logger.Info("token "+plaintext, "raw", plaintext, "bytes", []byte(plaintext), "port", 5432,
+		"req", map[string]interface{}{"pw": plaintext}, "args", []interface{}{"user", plaintext})
+	logger.Error(errors.New("auth failed for "+plaintext), "failed", "raw", plaintext)
+
+	if len(out) != 2 {
+		t.Fatalf("logger output = %v, want 2 lines", out)
+	}
+	for _, line := range out {
+		if strings.Contains(line, plaintext) {
+			t.Errorf("scrubbing sink leaked plaintext: %s", line)
+		}
+	}
+	if !strings.Contains(out[0], `"port":5432`) || !strings.Contains(out[0], `"logger":"ctrl"`) {
+		t.Errorf("scrubbing sink altered unrelated fields: %s", out[0])
+	}
+	if got := strings.Count(out[1], secret.String()); got != 3 {
+		t.Errorf("error line = %s, want 3 hash forms", out[1])
+	}
+}
+
+// TestNewScrubbingSink_ErrorChain verifies scrubbed errors still match errors.Is
+func TestNewScrubbingSink_ErrorChain(t *testing.T) {
+	registry := ss.NewRegistry()
+	registry.Register(ss.New(plaintext))
+
+	var got error
+	sink := funcr.New(func(prefix, args string) {}, funcr.Options{}).GetSink()
+	logger := logr.New(NewScrubbingSink(recordingSink{LogSink: sink, err: &got}, registry))
+	logger.Error(fmt.Errorf("auth failed for %s: %w", plaintext, context.DeadlineExceeded), "failed")
+
+	if got == nil || strings.Contains(got.Error(), plaintext) {
+		t.Fatalf("Error() received %v, want a scrubbed error", got)
+	}
+	if !errors.Is(got, context.DeadlineExceeded) {
+		t.Errorf("errors.Is(scrubbed, DeadlineExceeded) = false, want true")
+	}
+}
+
+// recordingSink records the error passed to Error.
+type recordingSink struct {
+	logr.LogSink
+	err *error
+}
+
+func (s recordingSink) Error(err error, msg string, keysAndValues ...interface{}) {
+	*s.err = err
+}
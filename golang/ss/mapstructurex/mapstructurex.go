@@ -0,0 +1,55 @@
+// Package mapstructurex integrates SensitiveString with
+// github.com/go-viper/mapstructure/v2, and so with viper, so configuration
+// structs can declare secret fields natively:
+//
+//	type Config struct {
+//		DatabaseURL string              `mapstructure:"database_url"`
+//		Password    *ss.SensitiveString `mapstructure:"password"`
+//	}
+//
+// viper.Unmarshal and viper.UnmarshalKey take the hook through
+// viper.DecodeHook. That option replaces viper's default hooks, so compose
+// them back in if the struct relies on them:
+//
+//	err := v.Unmarshal(&cfg, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+//		mapstructurex.DecodeHook(),
+//		mapstructure.StringToTimeDurationHookFunc(),
+//		mapstructure.StringToSliceHookFunc(","),
+//	)))
+//
+// Ref fields, which name a secret rather than hold it, decode with
+// mapstructure.TextUnmarshallerHookFunc.
+package mapstructurex
+
+import (
+	"reflect"
+
+	"github.com/go-viper/mapstructure/v2"
+
+	ss "github.com/earlye/sensitive-strings/golang/ss"
+)
+
+var (
+	secretType    = reflect.TypeFor[ss.SensitiveString]()
+	secretPtrType = reflect.TypeFor[*ss.SensitiveString]()
+)
+
+// DecodeHook returns a hook that converts strings to SensitiveString and
+// *SensitiveString fields, subject to the package-wide DecodeMode (see
+// SensitiveString.Decode). Other values pass through untouched, so numbers
+// meant as secrets must be quoted in YAML and TOML sources.
+func DecodeHook() mapstructure.DecodeHookFuncType {
+	return func(from, to reflect.Type, data interface{}) (interface{}, error) {
+		if from.Kind() != reflect.String || (to != secretType && to != secretPtrType) {
+			return data, nil
+		}
+		s := new(ss.SensitiveString)
+		if err := s.Decode(reflect.ValueOf(data).String()); err != nil {
+			return nil, err
+		}
+		if to == secretType {
+			return *s, nil
+		}
+		return s, nil
+	}
+}
@@ -0,0 +1,67 @@
+package mapstructurex
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-viper/mapstructure/v2"
+
+	ss "github.com/earlye/sensitive-strings/golang/ss"
+)
+
+type config struct {
+	Host     string              `mapstructure:"host"`
+	Password *ss.SensitiveString `mapstructure:"password"`
+	Token    ss.SensitiveString  `mapstructure:"token"`
+	Secret   ss.Ref              `mapstructure:"secret"`
+}
+
+// decode decodes input into a config with DecodeHook and the text hook.
+func decode(input map[string]interface{}) (config, error) {
+	var cfg config
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook: mapstructure.ComposeDecodeHookFunc(DecodeHook(), mapstructure.TextUnmarshallerHookFunc()),
+		Result:     &cfg,
+	})
+	if err != nil {
+		return cfg, err
+	}
+	return cfg, dec.Decode(input)
+}
+
+// TestDecodeHook verifies strings decode into secret fields and other fields are untouched
+func TestDecodeHook(t *testing.T) {
+	cfg, err := decode(map[string]interface{}{
+		"host":     "db.internal",
+		"password": "hunter2",
+		"token":    "s3cret",
+		"secret":   "env://API_KEY",
+	})
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if cfg.Host != "db.internal" {
+		t.Errorf("Host = %q, want db.internal", cfg.Host)
+	}
+	if cfg.Password.Value() != "hunter2" {
+		t.Errorf("Password = %v, want hunter2", cfg.Password)
+	}
+	if cfg.Token.Value() != "s3cret" {
+		t.Errorf("Token = %v, want s3cret", cfg.Token)
+	}
+	if cfg.Secret != (ss.Ref{Provider: "env", Name: "API_KEY"}) {
+		t.Errorf("Secret = %+v, want env://API_KEY", cfg.Secret)
+	}
+}
+
+// TestDecodeHook_DecodeMode verifies the package-wide DecodeMode is honored
+func TestDecodeHook_DecodeMode(t *testing.T) {
+	previous := ss.GetDecodeMode()
+	ss.SetDecodeMode(ss.DecodePlaintext)
+	t.Cleanup(func() { ss.SetDecodeMode(previous) })
+
+	_, err := decode(map[string]interface{}{"password": ss.New("hunter2").String()})
+	if !errors.Is(err, ss.ErrRedactedValue) {
+		t.Errorf("Decode(redacted) error = %v, want ErrRedactedValue", err)
+	}
+}
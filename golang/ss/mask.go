@@ -0,0 +1,130 @@
+package sensitivestring
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+// DefaultMaskRune is the rune Mask uses when MaskOptions.MaskRune is zero.
+const DefaultMaskRune = '•'
+
+// MaskOptions configures Mask. Lengths are counted in runes, or in
+// grapheme clusters (user-perceived characters) when ByGrapheme is set, so
+// a masked preview never splits a multibyte character.
+type MaskOptions struct {
+	// ShowFirst and ShowLast are the number of leading and trailing
+	// characters left visible. If together they would reveal the whole
+	// value, nothing is revealed.
+	ShowFirst int
+	ShowLast  int
+
+	// MaskRune replaces each hidden character. Zero means DefaultMaskRune.
+	MaskRune rune
+
+	// MinMasked is the minimum number of mask runes emitted, so that short
+	// values do not reveal their length.
+	MinMasked int
+
+	// ByGrapheme counts grapheme clusters instead of runes, so that e.g.
+	// an emoji with a skin-tone modifier or a letter with a combining
+	// accent is shown or hidden as a whole.
+	ByGrapheme bool
+}
+
+// Mask returns a preview of the value with all but the characters selected
+// by opts replaced by the mask rune, e.g. "••••••3456" for
+// MaskOptions{ShowLast: 4}. Unlike String, the result reveals part of the
+// plaintext; use it only where that is acceptable.
+func (s *SensitiveString) Mask(opts MaskOptions) string {
+	if s == nil {
+		return opts.mask(nil)
+	}
+	return opts.mask(splitCharacters(s.plaintext(), opts.ByGrapheme))
+}
+
+// SetMaskOptions sets the package-wide options used by MaskedString and
+// Masked. The default shows the last 4 characters.
+func SetMaskOptions(opts MaskOptions) {
+	updateConfig(func(c *config) { c.maskOptions = opts })
+}
+
+// GetMaskOptions returns the package-wide mask options.
+func GetMaskOptions() MaskOptions {
+	return loadConfig().maskOptions
+}
+
+// MaskedString returns the value masked with the package-wide mask
+// options, e.g. "••••••3456", for operational logs that need to correlate
+// API keys or card-like identifiers.
+func (s *SensitiveString) MaskedString() string {
+	return s.Mask(loadConfig().maskOptions)
+}
+
+// maskedRevealDivisor limits Masked to revealing at most one character in
+// this many.
+const maskedRevealDivisor = 3
+
+// Masked returns a Redactor that shows the last n characters and masks the
+// rest, using the package-wide options otherwise. Install it with
+// SetRedactor(Masked(4)) to make masking the default rendering, or with
+// WithRedactor for one value. Since it applies to every secret, however
+// short, it never reveals more than a third of the characters, and values
+// shorter than three characters are masked entirely. It still reveals part
+// of every secret; prefer the hash form unless correlation by eye is
+// needed.
+func Masked(n int) Redactor {
+	return RedactorFunc(func(value string) string {
+		opts := loadConfig().maskOptions
+		chars := splitCharacters(value, opts.ByGrapheme)
+		budget := len(chars) / maskedRevealDivisor
+		opts.ShowLast = min(max(n, 0), budget)
+		opts.ShowFirst = min(max(opts.ShowFirst, 0), budget-opts.ShowLast)
+		return opts.mask(chars)
+	})
+}
+
+// mask renders chars according to opts.
+func (opts MaskOptions) mask(chars []string) string {
+	maskRune := opts.MaskRune
+	if maskRune == 0 || !utf8.ValidRune(maskRune) {
+		maskRune = DefaultMaskRune
+	}
+	first, last := max(opts.ShowFirst, 0), max(opts.ShowLast, 0)
+	if first+last >= len(chars) {
+		first, last = 0, 0
+	}
+	hidden := max(len(chars)-first-last, opts.MinMasked)
+
+	var b strings.Builder
+	for _, c := range chars[:first] {
+		b.WriteString(c)
+	}
+	for range hidden {
+		b.WriteRune(maskRune)
+	}
+	for _, c := range chars[len(chars)-last:] {
+		b.WriteString(c)
+	}
+	return b.String()
+}
+
+// splitCharacters splits value into runes, or grapheme clusters if
+// byGrapheme is set. Invalid UTF-8 bytes become one character each.
+func splitCharacters(value string, byGrapheme bool) []string {
+	var chars []string
+	if byGrapheme {
+		graphemes := uniseg.NewGraphemes(value)
+		for graphemes.Next() {
+			chars = append(chars, graphemes.Str())
+		}
+		return chars
+	}
+	for len(value) > 0 {
+		_, size := utf8.DecodeRuneInString(value)
+		chars = append(chars, value[:size])
+		value = value[size:]
+	}
+	return chars
+}
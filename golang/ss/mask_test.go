@@ -0,0 +1,107 @@
+package sensitivestring
+
+import (
+	"encoding/json"
+	"testing"
+	"unicode/utf8"
+)
+
+// TestMask verifies masking options
+func TestMask(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		opts  MaskOptions
+		want  string
+	}{
+		{"all hidden", "secret", MaskOptions{}, "••••••"},
+		{"show last", "sk_live_3456", MaskOptions{ShowLast: 4}, "••••••••3456"},
+		{"show first and last", "sk_live_3456", MaskOptions{ShowFirst: 3, ShowLast: 2}, "sk_•••••••56"},
+		{"reveal everything refused", "1234", MaskOptions{ShowLast: 4}, "••••"},
+		{"custom rune", "secret", MaskOptions{MaskRune: '*', ShowLast: 1}, "*****t"},
+		{"min masked", "ab12", MaskOptions{ShowLast: 2, MinMasked: 8}, "••••••••12"},
+		{"multibyte runes", "p\u00e4ssw\u00f6rd\u00e9", MaskOptions{ShowLast: 3}, "••••••rd\u00e9"},
+		{"empty", "", MaskOptions{MinMasked: 3}, "•••"},
+	}
+	for _, tt := range tests {
+		if got := New(tt.value).Mask(tt.opts); got != tt.want {
+			t.Errorf("Mask(%s) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestMask_ByGrapheme verifies grapheme clusters are never split
+func TestMask_ByGrapheme(t *testing.T) {
+	// "e" + combining acute, then a thumbs-up with a skin-tone modifier.
+	value := "abce\u0301\U0001F44D\U0001F3FD"
+
+	if got, want := New(value).Mask(MaskOptions{ShowLast: 2, ByGrapheme: true}), "•••e\u0301\U0001F44D\U0001F3FD"; got != want {
+		t.Errorf("Mask(ByGrapheme) = %q, want %q", got, want)
+	}
+	if got, want := New(value).Mask(MaskOptions{ShowLast: 2}), "•••••\U0001F44D\U0001F3FD"; got != want {
+		t.Errorf("Mask(runes) = %q, want %q", got, want)
+	}
+}
+
+// TestMask_InvalidUTF8 verifies invalid input still yields valid UTF-8 output
+func TestMask_InvalidUTF8(t *testing.T) {
+	got := New("ab\xffcd").Mask(MaskOptions{ShowFirst: 1})
+	if !utf8.ValidString(got) || got != "a••••" {
+		t.Errorf("Mask() = %q, want a••••", got)
+	}
+	if got := (*SensitiveString)(nil).Mask(MaskOptions{MinMasked: 2}); got != "••" {
+		t.Errorf("nil Mask() = %q, want ••", got)
+	}
+}
+
+// TestMaskedString verifies the package-wide mask options
+func TestMaskedString(t *testing.T) {
+	secret := New("sk_live_123456")
+	if got := secret.MaskedString(); got != "••••••••••3456" {
+		t.Errorf("MaskedString() = %q, want ••••••••••3456", got)
+	}
+
+	previous := GetMaskOptions()
+	SetMaskOptions(MaskOptions{ShowFirst: 3, MaskRune: '*'})
+	t.Cleanup(func() { SetMaskOptions(previous) })
+	if got := secret.MaskedString(); got != "sk_***********" {
+		t.Errorf("MaskedString() = %q, want sk_***********", got)
+	}
+}
+
+// TestMasked verifies masking as the default rendering
+func TestMasked(t *testing.T) {
+	withRedactor(t, Masked(2))
+	secret := New("card-4242")
+	if got := secret.String(); got != "•••••••42" {
+		t.Errorf("String() = %q, want •••••••42", got)
+	}
+	if got, _ := json.Marshal(secret); string(got) != `"•••••••42"` {
+		t.Errorf("json.Marshal() = %s, want the masked form", got)
+	}
+}
+
+// TestMasked_ShortValues verifies Masked never reveals more than a third of a value
+func TestMasked_ShortValues(t *testing.T) {
+	withRedactor(t, Masked(4))
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"sk_live_3456", "••••••••3456"},
+		{"1234x", "••••x"},
+		{"12", "••"},
+	}
+	for _, tt := range tests {
+		if got := New(tt.value).String(); got != tt.want {
+			t.Errorf("String(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+
+	previous := GetMaskOptions()
+	SetMaskOptions(MaskOptions{ShowFirst: 3})
+	t.Cleanup(func() { SetMaskOptions(previous) })
+	if got := New("abcdefghi").String(); got != "••••••ghi" {
+		t.Errorf("String() with ShowFirst = %q, want ••••••ghi", got)
+	}
+}
@@ -0,0 +1,64 @@
+package sensitivestring
+
+import "strconv"
+
+// MergePrecedence selects which source wins when several sources supply
+// the same key.
+type MergePrecedence int
+
+const (
+	// LastWins gives later sources precedence, so sources are listed from
+	// lowest to highest priority (e.g. defaults, env, vault). This is the
+	// default.
+	LastWins MergePrecedence = iota
+
+	// FirstWins gives earlier sources precedence.
+	FirstWins
+)
+
+// Source is a named layer of secrets for MergeSources.
+type Source struct {
+	Name    string
+	Secrets map[string]*SensitiveString
+}
+
+// MergeResult is the outcome of MergeSources. Sources maps each key to the
+// name of the source that supplied its final value, so layered configuration
+// is auditable without exposing any values.
+type MergeResult struct {
+	Secrets map[string]*SensitiveString
+	Sources map[string]string
+}
+
+// Merge combines maps of secrets, with later maps taking precedence. nil
+// values are treated as absent.
+func Merge(maps ...map[string]*SensitiveString) map[string]*SensitiveString {
+	sources := make([]Source, len(maps))
+	for i, m := range maps {
+		sources[i] = Source{Name: strconv.Itoa(i), Secrets: m}
+	}
+	return MergeSources(LastWins, sources...).Secrets
+}
+
+// MergeSources combines named sources of secrets using the given precedence
+// and records which source supplied each final value. nil values are treated
+// as absent.
+func MergeSources(precedence MergePrecedence, sources ...Source) MergeResult {
+	result := MergeResult{
+		Secrets: make(map[string]*SensitiveString),
+		Sources: make(map[string]string),
+	}
+	for _, source := range sources {
+		for key, ss := range source.Secrets {
+			if ss == nil {
+				continue
+			}
+			if _, exists := result.Secrets[key]; exists && precedence == FirstWins {
+				continue
+			}
+			result.Secrets[key] = ss
+			result.Sources[key] = source.Name
+		}
+	}
+	return result
+}
@@ -0,0 +1,41 @@
+package sensitivestring
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestMerge_LastWins verifies later maps take precedence and nil values are skipped
+func TestMerge_LastWins(t *testing.T) {
+	merged := Merge(
+		map[string]*SensitiveString{"db": New("default-db"), "api": New("default-api")},
+		map[string]*SensitiveString{"db": New("env-db"), "api": nil},
+	)
+
+	if got := UnwrapMapValues(merged); !reflect.DeepEqual(got, map[string]string{"db": "env-db", "api": "default-api"}) {
+		t.Errorf("Merge() = %v", got)
+	}
+}
+
+// TestMergeSources_Provenance verifies provenance for both precedences
+func TestMergeSources_Provenance(t *testing.T) {
+	defaults := Source{Name: "defaults", Secrets: map[string]*SensitiveString{"db": New("a"), "api": New("b")}}
+	env := Source{Name: "env", Secrets: map[string]*SensitiveString{"db": New("c")}}
+	vault := Source{Name: "vault", Secrets: map[string]*SensitiveString{"db": New("d"), "token": New("e")}}
+
+	result := MergeSources(LastWins, defaults, env, vault)
+	if expected := map[string]string{"db": "vault", "api": "defaults", "token": "vault"}; !reflect.DeepEqual(result.Sources, expected) {
+		t.Errorf("LastWins Sources = %v, want %v", result.Sources, expected)
+	}
+	if got := result.Secrets["db"].Value(); got != "d" {
+		t.Errorf("LastWins db = %v, want d", got)
+	}
+
+	result = MergeSources(FirstWins, defaults, env, vault)
+	if expected := map[string]string{"db": "defaults", "api": "defaults", "token": "vault"}; !reflect.DeepEqual(result.Sources, expected) {
+		t.Errorf("FirstWins Sources = %v, want %v", result.Sources, expected)
+	}
+	if got := result.Secrets["db"].Value(); got != "a" {
+		t.Errorf("FirstWins db = %v, want a", got)
+	}
+}
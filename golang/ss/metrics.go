@@ -0,0 +1,136 @@
+package sensitivestring
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// CacheStatus reports how a CachedProvider served a resolution.
+type CacheStatus int32
+
+const (
+	// CacheNone means no CachedProvider was involved.
+	CacheNone CacheStatus = iota
+	// CacheHit means the secret was served from the cache.
+	CacheHit
+	// CacheMiss means the secret was fetched from the underlying provider.
+	CacheMiss
+	// CacheStale means an expired secret was served while it is refreshed
+	// or the underlying provider fails.
+	CacheStale
+)
+
+// String returns "none", "hit", "miss" or "stale".
+func (c CacheStatus) String() string {
+	switch c {
+	case CacheHit:
+		return "hit"
+	case CacheMiss:
+		return "miss"
+	case CacheStale:
+		return "stale"
+	}
+	return "none"
+}
+
+// Error classes reported in ResolveResults, suitable as metric labels.
+const (
+	ErrorClassNone            = ""
+	ErrorClassNotFound        = "not_found"
+	ErrorClassTimeout         = "timeout"
+	ErrorClassCanceled        = "canceled"
+	ErrorClassUnknownProvider = "unknown_provider"
+	ErrorClassOther           = "error"
+)
+
+// ErrorClass returns the class of a resolution error, or ErrorClassNone
+// for nil.
+func ErrorClass(err error) string {
+	switch {
+	case err == nil:
+		return ErrorClassNone
+	case errors.Is(err, ErrSecretNotFound):
+		return ErrorClassNotFound
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorClassTimeout
+	case errors.Is(err, context.Canceled):
+		return ErrorClassCanceled
+	case errors.Is(err, ErrUnknownProvider):
+		return ErrorClassUnknownProvider
+	}
+	return ErrorClassOther
+}
+
+// ResolveEvent identifies a resolution through a Resolver: the provider
+// name and the secret name within it. Name is empty for a batch fetched
+// with ResolveBatch. Events never carry secret values.
+type ResolveEvent struct {
+	Provider string
+	Name     string
+}
+
+// ResolveResult describes a finished resolution. Duration is measured
+// with the package Clock.
+type ResolveResult struct {
+	Duration   time.Duration
+	Cache      CacheStatus
+	ErrorClass string
+	Err        error
+}
+
+// ResolveObserver instruments secret resolution, e.g. with Prometheus
+// histograms or OpenTelemetry spans. OnResolveStart is called before each
+// resolution through a Resolver and returns the context to resolve with,
+// so a span can be attached to it; OnResolveDone is called with that
+// context once the resolution has finished. Both are called synchronously
+// and must be safe for concurrent use.
+type ResolveObserver interface {
+	OnResolveStart(ctx context.Context, e ResolveEvent) context.Context
+	OnResolveDone(ctx context.Context, e ResolveEvent, r ResolveResult)
+}
+
+// SetResolveObserver installs o to instrument every resolution through a
+// Resolver. A nil o turns instrumentation off again.
+func SetResolveObserver(o ResolveObserver) {
+	updateConfig(func(c *config) { c.resolveObserver = o })
+}
+
+// resolveStateKey is the context key for the resolveState of an observed
+// resolution.
+type resolveStateKey struct{}
+
+// resolveState collects what providers report about an observed
+// resolution.
+type resolveState struct {
+	cache atomic.Int32
+}
+
+// reportCacheStatus records how a CachedProvider served the resolution
+// ctx belongs to, if it is being observed.
+func reportCacheStatus(ctx context.Context, status CacheStatus) {
+	if state, ok := ctx.Value(resolveStateKey{}).(*resolveState); ok {
+		state.cache.Store(int32(status))
+	}
+}
+
+// observeResolve runs fn under the installed ResolveObserver, if any.
+func observeResolve[T any](ctx context.Context, e ResolveEvent, fn func(context.Context) (T, error)) (T, error) {
+	observer := loadConfig().resolveObserver
+	if observer == nil {
+		return fn(ctx)
+	}
+	state := new(resolveState)
+	ctx = context.WithValue(observer.OnResolveStart(ctx, e), resolveStateKey{}, state)
+	clock := GetClock()
+	start := clock.Now()
+	value, err := fn(ctx)
+	observer.OnResolveDone(ctx, e, ResolveResult{
+		Duration:   clock.Now().Sub(start),
+		Cache:      CacheStatus(state.cache.Load()),
+		ErrorClass: ErrorClass(err),
+		Err:        err,
+	})
+	return value, err
+}
@@ -0,0 +1,113 @@
+package sensitivestring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+type ctxMarker struct{}
+
+// recordingObserver records finished resolutions and marks the context it returns.
+type recordingObserver struct {
+	mu      sync.Mutex
+	started []ResolveEvent
+	done    []ResolveResult
+	marked  bool
+}
+
+func (o *recordingObserver) OnResolveStart(ctx context.Context, e ResolveEvent) context.Context {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.started = append(o.started, e)
+	return context.WithValue(ctx, ctxMarker{}, true)
+}
+
+func (o *recordingObserver) OnResolveDone(ctx context.Context, e ResolveEvent, r ResolveResult) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.marked = ctx.Value(ctxMarker{}) == true
+	o.done = append(o.done, r)
+}
+
+// withResolveObserver installs o for the duration of a test.
+func withResolveObserver(t *testing.T, o ResolveObserver) {
+	t.Helper()
+	SetResolveObserver(o)
+	t.Cleanup(func() { SetResolveObserver(nil) })
+}
+
+// TestResolveObserver verifies start and done hooks carry provider, cache status and error class
+func TestResolveObserver(t *testing.T) {
+	o := &recordingObserver{}
+	withResolveObserver(t, o)
+	clock := withManualClock(t)
+
+	backend := &flakyProvider{}
+	r := NewResolver()
+	r.Register("cached", Cache(backend, time.Minute, WithRefreshAhead(0)))
+	r.Register("static", MapProvider{})
+
+	r.Resolve(context.Background(), "cached:db")
+	r.Resolve(context.Background(), "cached:db")
+	clock.Advance(2 * time.Minute)
+	backend.failures, backend.calls, backend.err = 1, 0, errors.New("503")
+	r.Resolve(context.Background(), "cached:db")
+	r.Resolve(context.Background(), "static:missing")
+
+	if len(o.started) != 4 || o.started[0] != (ResolveEvent{Provider: "cached", Name: "db"}) {
+		t.Fatalf("started = %v", o.started)
+	}
+	want := []struct {
+		cache CacheStatus
+		class string
+	}{{CacheMiss, ErrorClassNone}, {CacheHit, ErrorClassNone}, {CacheStale, ErrorClassNone}, {CacheNone, ErrorClassNotFound}}
+	for i, w := range want {
+		if got := o.done[i]; got.Cache != w.cache || got.ErrorClass != w.class {
+			t.Errorf("done[%d] = %v/%q, want %v/%q", i, got.Cache, got.ErrorClass, w.cache, w.class)
+		}
+	}
+	if !o.marked {
+		t.Errorf("OnResolveDone did not receive the context returned by OnResolveStart")
+	}
+}
+
+// TestResolveObserver_Duration verifies durations are measured with the package clock
+func TestResolveObserver_Duration(t *testing.T) {
+	o := &recordingObserver{}
+	withResolveObserver(t, o)
+	clock := withManualClock(t)
+
+	r := NewResolver()
+	r.Register("slow", ProviderFunc(func(ctx context.Context, name string) (*SensitiveString, error) {
+		clock.Advance(3 * time.Second)
+		return New("foo"), nil
+	}))
+	if _, err := r.Resolve(context.Background(), "slow:db"); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if len(o.done) != 1 || o.done[0].Duration != 3*time.Second {
+		t.Errorf("done = %v, want one resolution taking 3s", o.done)
+	}
+}
+
+// TestErrorClass verifies errors map to metric-friendly classes
+func TestErrorClass(t *testing.T) {
+	for err, want := range map[error]string{
+		fmt.Errorf("x: %w", ErrSecretNotFound):  ErrorClassNotFound,
+		context.DeadlineExceeded:                ErrorClassTimeout,
+		context.Canceled:                        ErrorClassCanceled,
+		fmt.Errorf("x: %w", ErrUnknownProvider): ErrorClassUnknownProvider,
+		errors.New("boom"):                      ErrorClassOther,
+	} {
+		if got := ErrorClass(err); got != want {
+			t.Errorf("ErrorClass(%v) = %q, want %q", err, got, want)
+		}
+	}
+	if ErrorClass(nil) != ErrorClassNone || CacheStale.String() != "stale" {
+		t.Errorf("ErrorClass(nil) or CacheStatus.String() mismatch")
+	}
+}
@@ -0,0 +1,112 @@
+package sensitivestring
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// UnmarshalCompat selects which serialized forms of other secret-wrapper
+// libraries UnmarshalJSON accepts, so data written before a codebase
+// adopted this package can still be read. Modes can be combined.
+type UnmarshalCompat int
+
+const (
+	// CompatPlaceholders decodes the fixed placeholders other wrappers
+	// write in place of a secret ("<secret>", "[REDACTED]", "REDACTED",
+	// "***", "********", "xxxxx") as an empty value rather than
+	// adopting the placeholder text as the secret. The original value is
+	// not recoverable; validate with NonEmpty or ValidateRequired to catch
+	// fields that were only ever written redacted.
+	CompatPlaceholders UnmarshalCompat = 1 << iota
+
+	// CompatValueObjects decodes wrappers serialized as an object holding
+	// the plaintext, such as {"value":"…"}, {"Value":"…"} or
+	// {"secret":"…"}.
+	CompatValueObjects
+)
+
+// compatPlaceholders lists the placeholders recognized by
+// CompatPlaceholders.
+var compatPlaceholders = map[string]bool{
+	"<secret>":   true,
+	"[REDACTED]": true,
+	"REDACTED":   true,
+	"***":        true,
+	"********":   true,
+	"xxxxx":      true,
+}
+
+// compatValueKeys lists the object keys recognized by CompatValueObjects.
+var compatValueKeys = []string{"value", "Value", "secret", "Secret"}
+
+// SetUnmarshalCompat sets the package-wide compatibility modes used by
+// UnmarshalJSON. The default is 0, which accepts only this package's own
+// forms.
+func SetUnmarshalCompat(mode UnmarshalCompat) {
+	updateConfig(func(c *config) { c.unmarshalCompat = mode })
+}
+
+// GetUnmarshalCompat returns the package-wide compatibility modes.
+func GetUnmarshalCompat() UnmarshalCompat {
+	return loadConfig().unmarshalCompat
+}
+
+// FromString converts a value of another library's string-based secret
+// type (any type whose underlying type is string) into a SensitiveString.
+func FromString[T ~string](value T) *SensitiveString {
+	return New(string(value))
+}
+
+// FromStrings converts a map of string-based secret values into
+// SensitiveStrings labeled with their keys, like WrapMapValues.
+func FromStrings[T ~string](values map[string]T) map[string]*SensitiveString {
+	result := make(map[string]*SensitiveString, len(values))
+	for key, value := range values {
+		result[key] = NewLabeled(key, string(value))
+	}
+	return result
+}
+
+// ToString converts a SensitiveString back into another library's
+// string-based secret type, for passing to code that has not migrated yet.
+// A nil SensitiveString converts to the zero value.
+func ToString[T ~string](s *SensitiveString) T {
+	if s == nil {
+		return ""
+	}
+	return T(s.plaintext())
+}
+
+// unmarshalCompatObject decodes a {"value":"…"}-style object when
+// CompatValueObjects is enabled. It reports whether data was such an
+// object.
+func unmarshalCompatObject(data []byte) (string, bool, error) {
+	if loadConfig().unmarshalCompat&CompatValueObjects == 0 {
+		return "", false, nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return "", false, err
+	}
+	for _, key := range compatValueKeys {
+		raw, ok := fields[key]
+		if !ok {
+			continue
+		}
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return "", false, fmt.Errorf("sensitivestring: %q field: %w", key, err)
+		}
+		return value, true, nil
+	}
+	return "", false, nil
+}
+
+// compatString maps placeholders to the empty string when
+// CompatPlaceholders is enabled.
+func compatString(value string) string {
+	if loadConfig().unmarshalCompat&CompatPlaceholders != 0 && compatPlaceholders[value] {
+		return ""
+	}
+	return value
+}
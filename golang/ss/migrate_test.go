@@ -0,0 +1,79 @@
+package sensitivestring
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// withUnmarshalCompat sets the compatibility modes for the duration of a test
+func withUnmarshalCompat(t *testing.T, mode UnmarshalCompat) {
+	t.Helper()
+	previous := GetUnmarshalCompat()
+	SetUnmarshalCompat(mode)
+	t.Cleanup(func() { SetUnmarshalCompat(previous) })
+}
+
+type legacySecret string
+
+// TestFromString verifies conversion from and back to string-based secret types
+func TestFromString(t *testing.T) {
+	s := FromString(legacySecret("hunter2"))
+	if s.Value() != "hunter2" {
+		t.Errorf("FromString().Value() = %v, want hunter2", s.Value())
+	}
+	if got := ToString[legacySecret](s); got != "hunter2" {
+		t.Errorf("ToString() = %v, want hunter2", got)
+	}
+	if got := ToString[legacySecret](nil); got != "" {
+		t.Errorf("ToString(nil) = %v, want empty", got)
+	}
+
+	m := FromStrings(map[string]legacySecret{"db": "hunter2"})
+	if m["db"].Value() != "hunter2" || m["db"].Label() != "db" {
+		t.Errorf("FromStrings()[db] = %v/%v, want hunter2/db", m["db"].Value(), m["db"].Label())
+	}
+}
+
+// TestUnmarshalJSON_CompatPlaceholders verifies placeholders are not adopted as values
+func TestUnmarshalJSON_CompatPlaceholders(t *testing.T) {
+	var s SensitiveString
+	if err := json.Unmarshal([]byte(`"<secret>"`), &s); err != nil || s.Value() != "<secret>" {
+		t.Errorf("without compat: Value() = %q, %v, want <secret>", s.Value(), err)
+	}
+
+	withUnmarshalCompat(t, CompatPlaceholders)
+	for _, placeholder := range []string{`"<secret>"`, `"[REDACTED]"`, `"********"`} {
+		if err := json.Unmarshal([]byte(placeholder), &s); err != nil || s.Value() != "" {
+			t.Errorf("Unmarshal(%s) Value() = %q, %v, want empty", placeholder, s.Value(), err)
+		}
+	}
+	if err := json.Unmarshal([]byte(`"hunter2"`), &s); err != nil || s.Value() != "hunter2" {
+		t.Errorf("Unmarshal(hunter2) Value() = %q, %v, want hunter2", s.Value(), err)
+	}
+}
+
+// TestUnmarshalJSON_CompatValueObjects verifies value objects decode their plaintext
+func TestUnmarshalJSON_CompatValueObjects(t *testing.T) {
+	withUnmarshalCompat(t, CompatValueObjects)
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{`{"value":"hunter2"}`, "hunter2"},
+		{`{"Value":"hunter2"}`, "hunter2"},
+		{`{"secret":"hunter2"}`, "hunter2"},
+		{`{"alg":"sha256","hash":"abc","len":3}`, "sha256:abc"},
+	}
+	for _, tt := range tests {
+		var s SensitiveString
+		if err := json.Unmarshal([]byte(tt.input), &s); err != nil || s.Value() != tt.want {
+			t.Errorf("Unmarshal(%s) Value() = %q, %v, want %q", tt.input, s.Value(), err, tt.want)
+		}
+	}
+
+	var s SensitiveString
+	if err := json.Unmarshal([]byte(`{"value":42}`), &s); err == nil {
+		t.Errorf("Unmarshal(non-string value) error = nil, want error")
+	}
+}
@@ -0,0 +1,58 @@
+package sensitivestring
+
+import (
+	"errors"
+	"runtime"
+)
+
+// ErrMemoryLockUnsupported is reported by platforms without a facility to
+// lock memory into RAM.
+var ErrMemoryLockUnsupported = errors.New("sensitivestring: memory locking is not supported on this platform")
+
+// WithMemoryLock holds the plaintext in memory allocated outside the Go
+// heap and locked into RAM (mlock on Unix, VirtualLock on Windows), so the
+// secret is never written to swap. The memory is wiped and released once
+// the secret and all its copies are garbage collected. Locking degrades
+// gracefully: if it is unsupported or fails, e.g. because RLIMIT_MEMLOCK is
+// exhausted, the secret is kept in ordinary memory; use Locked to find out
+// which happened.
+func WithMemoryLock() Option {
+	return func(s *SensitiveString) {
+		previous, _ := s.store.(*byteStorage)
+		var buf []byte
+		var release func()
+		var err error
+		s.usePlaintext(func(value []byte) {
+			if buf, release, err = lockedAlloc(len(value)); err == nil {
+				copy(buf, value)
+			}
+		})
+		if err != nil {
+			return
+		}
+		if previous != nil {
+			previous.wipe()
+		}
+		s.value = ""
+		store := &byteStorage{buf: buf, locked: true}
+		s.store = store
+		runtime.AddCleanup(store, func(release func()) { release() }, release)
+	}
+}
+
+// locker is implemented by storage backends that may keep the plaintext in
+// locked memory.
+type locker interface {
+	isLocked() bool
+}
+
+// Locked reports whether the plaintext of s is held in memory locked into
+// RAM, e.g. because it was created with WithMemoryLock and locking
+// succeeded.
+func (s *SensitiveString) Locked() bool {
+	if s == nil {
+		return false
+	}
+	l, ok := s.store.(locker)
+	return ok && l.isLocked()
+}
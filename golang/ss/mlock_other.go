@@ -0,0 +1,8 @@
+//go:build !unix && !windows
+
+package sensitivestring
+
+// lockedAlloc always fails: this platform cannot lock memory.
+func lockedAlloc(n int) ([]byte, func(), error) {
+	return nil, nil, ErrMemoryLockUnsupported
+}
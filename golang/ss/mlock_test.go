@@ -0,0 +1,65 @@
+package sensitivestring
+
+import (
+	"runtime"
+	"testing"
+)
+
+// TestWithMemoryLock verifies locked secrets behave normally and report their state
+func TestWithMemoryLock(t *testing.T) {
+	ss := New("foo", WithMemoryLock(), WithZeroOnFinalize())
+	if !ss.Locked() {
+		t.Skip("memory locking unavailable in this environment")
+	}
+	if got := ss.Value(); got != "foo" {
+		t.Errorf("Value() = %q, want foo", got)
+	}
+	if got := ss.String(); got != "sha256:"+fooHashHex {
+		t.Errorf("String() = %v, want %v", got, "sha256:"+fooHashHex)
+	}
+
+	ss.Zero()
+	if ss.Locked() || ss.Value() != "" {
+		t.Errorf("after Zero Locked() = %v, Value() = %q, want false and empty", ss.Locked(), ss.Value())
+	}
+}
+
+// TestLocked_Default verifies secrets are not locked unless requested
+func TestLocked_Default(t *testing.T) {
+	if New("foo").Locked() || (*SensitiveString)(nil).Locked() {
+		t.Errorf("Locked() = true without WithMemoryLock")
+	}
+	empty := New("", WithMemoryLock())
+	if got := empty.Value(); got != "" {
+		t.Errorf("empty locked Value() = %q, want empty", got)
+	}
+}
+
+// TestWithMemoryLock_Unreachable verifies locked memory outlives calls on a secret that becomes unreachable
+func TestWithMemoryLock_Unreachable(t *testing.T) {
+	if !New("foo", WithMemoryLock()).Locked() {
+		t.Skip("memory locking unavailable in this environment")
+	}
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				runtime.GC()
+			}
+		}
+	}()
+	for i := 0; i < 200; i++ {
+		// Each secret is last used by the call, so its storage may become
+		// unreachable while the call still reads it.
+		if !New("foo", WithMemoryLock()).EqualString("foo") {
+			t.Fatalf("EqualString() = false on iteration %d", i)
+		}
+		if got := New("foo", WithMemoryLock()).ValueBytes(); string(got) != "foo" {
+			t.Fatalf("ValueBytes() = %q on iteration %d", got, i)
+		}
+	}
+}
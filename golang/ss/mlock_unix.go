@@ -0,0 +1,24 @@
+//go:build unix
+
+package sensitivestring
+
+import "golang.org/x/sys/unix"
+
+// lockedAlloc maps n bytes of anonymous memory and locks them into RAM.
+// release wipes, unlocks and unmaps the memory.
+func lockedAlloc(n int) ([]byte, func(), error) {
+	mem, err := unix.Mmap(-1, 0, max(n, 1), unix.PROT_READ|unix.PROT_WRITE, unix.MAP_ANON|unix.MAP_PRIVATE)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := unix.Mlock(mem); err != nil {
+		unix.Munmap(mem)
+		return nil, nil, err
+	}
+	release := func() {
+		clear(mem)
+		unix.Munlock(mem)
+		unix.Munmap(mem)
+	}
+	return mem[:n], release, nil
+}
@@ -0,0 +1,32 @@
+//go:build windows
+
+package sensitivestring
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// lockedAlloc allocates n bytes with VirtualAlloc and locks them into RAM
+// with VirtualLock. release wipes, unlocks and frees the memory.
+func lockedAlloc(n int) ([]byte, func(), error) {
+	size := uintptr(max(n, 1))
+	addr, err := windows.VirtualAlloc(0, size, windows.MEM_COMMIT|windows.MEM_RESERVE, windows.PAGE_READWRITE)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := windows.VirtualLock(addr, size); err != nil {
+		windows.VirtualFree(addr, 0, windows.MEM_RELEASE)
+		return nil, nil, err
+	}
+	// addr is owned by the OS, not the Go heap; unsafe.Add avoids the
+	// uintptr conversion vet flags for heap pointers.
+	mem := unsafe.Slice((*byte)(unsafe.Add(unsafe.Pointer(nil), addr)), size)
+	release := func() {
+		clear(mem)
+		windows.VirtualUnlock(addr, size)
+		windows.VirtualFree(addr, 0, windows.MEM_RELEASE)
+	}
+	return mem[:n], release, nil
+}
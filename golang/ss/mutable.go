@@ -0,0 +1,93 @@
+package sensitivestring
+
+import (
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+)
+
+// MutableSensitiveString holds a secret that can be replaced in place, for
+// long-lived credential holders that rotate while other goroutines keep
+// reading. Every read sees either the old or the new secret in full, never
+// a mix. It must not be copied after first use.
+type MutableSensitiveString struct {
+	current atomic.Pointer[SensitiveString]
+	opts    []Option
+}
+
+// NewMutable creates a new MutableSensitiveString holding value. opts are
+// applied to the initial value and to every value passed to Set.
+func NewMutable(value string, opts ...Option) *MutableSensitiveString {
+	m := &MutableSensitiveString{opts: opts}
+	m.current.Store(New(value, opts...))
+	return m
+}
+
+// Set atomically replaces the secret with value. The previous secret is not
+// wiped, since concurrent readers may still be using a snapshot of it.
+func (m *MutableSensitiveString) Set(value string) {
+	m.current.Store(New(value, m.opts...))
+}
+
+// Swap atomically replaces the secret with s and returns the previous one,
+// e.g. so that the caller can Zero it once no reader needs it any more. A
+// nil s is stored as an empty secret.
+func (m *MutableSensitiveString) Swap(s *SensitiveString) *SensitiveString {
+	if s == nil {
+		s = New("")
+	}
+	return m.current.Swap(s)
+}
+
+// Load returns a snapshot of the current secret, unaffected by later calls
+// to Set. Use it when several reads must see the same secret.
+func (m *MutableSensitiveString) Load() *SensitiveString {
+	return m.current.Load()
+}
+
+// Value returns the raw plaintext value of the current secret. Use this
+// only when you explicitly need access to the secret value.
+func (m *MutableSensitiveString) Value() string {
+	return m.Load().Value()
+}
+
+// Len returns the length of the current secret without exposing it.
+func (m *MutableSensitiveString) Len() int {
+	return m.Load().Len()
+}
+
+// Label returns the label attached to the current secret, if any.
+func (m *MutableSensitiveString) Label() string {
+	return m.Load().Label()
+}
+
+// String returns the redacted form of the current secret, implementing
+// fmt.Stringer.
+func (m *MutableSensitiveString) String() string {
+	return m.Load().String()
+}
+
+// GoString returns the redacted form for %#v formatting.
+func (m *MutableSensitiveString) GoString() string {
+	return m.Load().GoString()
+}
+
+// Format implements fmt.Formatter like SensitiveString.Format.
+func (m *MutableSensitiveString) Format(f fmt.State, verb rune) {
+	m.Load().Format(f, verb)
+}
+
+// MarshalJSON implements json.Marshaler like SensitiveString.MarshalJSON.
+func (m *MutableSensitiveString) MarshalJSON() ([]byte, error) {
+	return m.Load().MarshalJSON()
+}
+
+// MarshalYAML implements yaml.Marshaler like SensitiveString.MarshalYAML.
+func (m *MutableSensitiveString) MarshalYAML() (interface{}, error) {
+	return m.Load().MarshalYAML()
+}
+
+// LogValue implements slog.LogValuer like SensitiveString.LogValue.
+func (m *MutableSensitiveString) LogValue() slog.Value {
+	return m.Load().LogValue()
+}
@@ -0,0 +1,60 @@
+package sensitivestring
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+)
+
+// TestMutableSensitiveString verifies Set, Swap and snapshots
+func TestMutableSensitiveString(t *testing.T) {
+	m := NewMutable("foo", WithLabel("db"))
+	snapshot := m.Load()
+	if got := m.String(); got != "sha256:"+fooHashHex {
+		t.Errorf("String() = %v, want %v", got, "sha256:"+fooHashHex)
+	}
+
+	m.Set("bar")
+	if m.Value() != "bar" || m.Len() != 3 || m.Label() != "db" {
+		t.Errorf("after Set Value() = %q, Len() = %d, Label() = %q", m.Value(), m.Len(), m.Label())
+	}
+	if got := snapshot.Value(); got != "foo" {
+		t.Errorf("snapshot Value() = %q, want foo", got)
+	}
+
+	previous := m.Swap(New("baz"))
+	if previous.Value() != "bar" || m.Value() != "baz" {
+		t.Errorf("Swap() = %q, current %q, want bar, baz", previous.Value(), m.Value())
+	}
+	data, err := json.Marshal(m)
+	if err != nil || string(data) != `"`+New("baz").String()+`"` {
+		t.Errorf("json.Marshal() = %s, %v", data, err)
+	}
+}
+
+// TestMutableSensitiveString_Concurrent verifies readers never see a torn value while rotating
+func TestMutableSensitiveString_Concurrent(t *testing.T) {
+	m := NewMutable("aaaa")
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			if i%2 == 0 {
+				m.Set("bbbb")
+			} else {
+				m.Set("aaaa")
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			if v := m.Value(); v != "aaaa" && v != "bbbb" {
+				t.Errorf("Value() = %q, want a complete value", v)
+				return
+			}
+		}
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,55 @@
+package sensitivestring
+
+import "runtime"
+
+// Option tunes a single SensitiveString created by New, overriding the
+// package-wide behavior for that secret only.
+type Option func(*SensitiveString)
+
+// WithLabel attaches a label, as NewLabeled does.
+func WithLabel(label string) Option {
+	return func(s *SensitiveString) { s.label = label }
+}
+
+// WithRedactor renders the secret with r instead of the package-wide
+// Redactor. See (*SensitiveString).WithRedactor.
+func WithRedactor(r Redactor) Option {
+	return func(s *SensitiveString) { s.redactor = r }
+}
+
+// WithHashAlgorithm fingerprints the secret with alg instead of the
+// package-wide algorithm. It panics on an unsupported algorithm.
+func WithHashAlgorithm(alg HashAlgorithm) Option {
+	if hashConstructors[alg] == nil {
+		panic("WithHashAlgorithm: unsupported algorithm " + string(alg))
+	}
+	return func(s *SensitiveString) { s.hashAlg = alg }
+}
+
+// WithMask renders the secret masked with opts, e.g. "••••••3456", instead
+// of the hash form. Like Masked, it reveals part of the plaintext.
+func WithMask(opts MaskOptions) Option {
+	return WithRedactor(RedactorFunc(func(value string) string {
+		return opts.mask(splitCharacters(value, opts.ByGrapheme))
+	}))
+}
+
+// WithZeroOnFinalize overwrites the package-owned buffer holding the
+// plaintext with zeros once the secret and every copy of it are garbage
+// collected, so it does not linger in freed memory. The string passed to
+// New is not (and cannot be) zeroed, and values returned by Value() are
+// copies.
+func WithZeroOnFinalize() Option {
+	return func(s *SensitiveString) {
+		store, ok := s.store.(*byteStorage)
+		if !ok {
+			s.setPlaintext(s.plaintext())
+			store = s.store.(*byteStorage)
+		}
+		if store.locked {
+			// Locked memory is already wiped when it is released.
+			return
+		}
+		runtime.AddCleanup(store, func(buf []byte) { clear(buf) }, store.buf)
+	}
+}
@@ -0,0 +1,55 @@
+package sensitivestring
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestNew_Options verifies per-secret options override the package defaults
+func TestNew_Options(t *testing.T) {
+	if got := New("foo").String(); got != "sha256:"+fooHashHex {
+		t.Errorf("New without options String() = %v, want %v", got, "sha256:"+fooHashHex)
+	}
+
+	labeled := New("foo", WithLabel("api-key"), WithHashAlgorithm(SHA512))
+	if got := labeled.Label(); got != "api-key" {
+		t.Errorf("Label() = %v, want api-key", got)
+	}
+	if got := labeled.String(); !strings.HasPrefix(got, "sha512:") {
+		t.Errorf("String() = %v, want sha512 prefix", got)
+	}
+
+	if got := New("foo", WithRedactor(Placeholder(""))).String(); got != DefaultPlaceholder {
+		t.Errorf("WithRedactor String() = %v, want %v", got, DefaultPlaceholder)
+	}
+	if got := New("1234567890", WithMask(MaskOptions{ShowFirst: 2, MaskRune: '*'})).String(); got != "12********" {
+		t.Errorf("WithMask String() = %v, want 12********", got)
+	}
+}
+
+// TestWithZeroOnFinalize verifies the value is readable and its buffer zeroed after collection
+func TestWithZeroOnFinalize(t *testing.T) {
+	ss := New("hunter2", WithZeroOnFinalize())
+	if got := ss.Value(); got != "hunter2" {
+		t.Errorf("Value() = %v, want hunter2", got)
+	}
+	if got := ss.Len(); got != 7 {
+		t.Errorf("Len() = %d, want 7", got)
+	}
+	if got := ss.String(); got != New("hunter2").String() {
+		t.Errorf("String() = %v, want the plain hash form", got)
+	}
+
+	buf := ss.store.(*byteStorage).buf
+	ss = nil
+	deadline := time.Now().Add(5 * time.Second)
+	for string(buf) != "\x00\x00\x00\x00\x00\x00\x00" {
+		if time.Now().After(deadline) {
+			t.Fatalf("buffer = %q after collection, want zeros", buf)
+		}
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+}
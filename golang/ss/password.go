@@ -0,0 +1,153 @@
+package sensitivestring
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	// ErrUnsupportedPasswordHash is returned by Password.Verify for a hash
+	// it cannot parse.
+	ErrUnsupportedPasswordHash = errors.New("sensitivestring: unsupported password hash")
+	// ErrInvalidArgon2Params is returned for Argon2 parameters that
+	// golang.org/x/crypto/argon2 would panic on or silently adjust.
+	ErrInvalidArgon2Params = errors.New("sensitivestring: invalid argon2 parameters")
+)
+
+// Argon2Params configures Password.HashArgon2id.
+type Argon2Params struct {
+	Time    uint32 // number of passes
+	Memory  uint32 // in KiB
+	Threads uint8
+	SaltLen uint32
+	KeyLen  uint32
+}
+
+// DefaultArgon2Params are the parameters recommended by RFC 9106 for
+// memory-constrained environments.
+var DefaultArgon2Params = Argon2Params{Time: 3, Memory: 64 * 1024, Threads: 4, SaltLen: 16, KeyLen: 32}
+
+// validate checks the cost parameters: at least one pass and one thread,
+// and the 8 KiB of memory per thread that RFC 9106 requires.
+func (params Argon2Params) validate() error {
+	switch {
+	case params.Time < 1:
+		return fmt.Errorf("%w: time must be at least 1", ErrInvalidArgon2Params)
+	case params.Threads < 1:
+		return fmt.Errorf("%w: threads must be at least 1", ErrInvalidArgon2Params)
+	case params.Memory < 8*uint32(params.Threads):
+		return fmt.Errorf("%w: memory must be at least 8 KiB per thread", ErrInvalidArgon2Params)
+	}
+	return nil
+}
+
+// Password is a SensitiveString holding a user's password, with helpers to
+// produce storable hashes and to check a login attempt against one, so
+// applications never pull the plaintext out just to feed
+// golang.org/x/crypto.
+type Password struct {
+	*SensitiveString
+}
+
+// NewPassword creates a new Password from the given value.
+func NewPassword(value string, opts ...Option) Password {
+	return Password{New(value, opts...)}
+}
+
+// HashBcrypt returns a bcrypt hash of the password at bcrypt.DefaultCost.
+// bcrypt rejects passwords longer than 72 bytes.
+func (p Password) HashBcrypt() (string, error) {
+	return p.HashBcryptCost(bcrypt.DefaultCost)
+}
+
+// HashBcryptCost returns a bcrypt hash of the password at the given cost.
+func (p Password) HashBcryptCost(cost int) (string, error) {
+	var hash []byte
+	var err error
+	p.Use(func(plaintext []byte) { hash, err = bcrypt.GenerateFromPassword(plaintext, cost) })
+	return string(hash), err
+}
+
+// HashArgon2id returns an Argon2id hash of the password with a random salt
+// and DefaultArgon2Params, encoded in the usual PHC string format
+// "$argon2id$v=19$m=…,t=…,p=…$<salt>$<key>".
+func (p Password) HashArgon2id() (string, error) {
+	return p.HashArgon2idParams(DefaultArgon2Params)
+}
+
+// HashArgon2idParams is HashArgon2id with explicit parameters. Invalid
+// parameters are reported with ErrInvalidArgon2Params.
+func (p Password) HashArgon2idParams(params Argon2Params) (string, error) {
+	if err := params.validate(); err != nil {
+		return "", err
+	}
+	if params.KeyLen < 1 {
+		return "", fmt.Errorf("%w: key length must be at least 1", ErrInvalidArgon2Params)
+	}
+	salt := make([]byte, params.SaltLen)
+	rand.Read(salt)
+	var key []byte
+	p.Use(func(plaintext []byte) {
+		key = argon2.IDKey(plaintext, salt, params.Time, params.Memory, params.Threads, params.KeyLen)
+	})
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, params.Memory, params.Time, params.Threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Verify reports whether the password matches hash, a bcrypt or Argon2id
+// hash produced by HashBcrypt, HashArgon2id or a compatible library. A
+// mismatch is reported as false with a nil error; an error means hash could
+// not be parsed.
+func (p Password) Verify(hash string) (bool, error) {
+	if strings.HasPrefix(hash, "$argon2id$") {
+		return p.verifyArgon2id(hash)
+	}
+	if strings.HasPrefix(hash, "$2") {
+		var err error
+		p.Use(func(plaintext []byte) { err = bcrypt.CompareHashAndPassword([]byte(hash), plaintext) })
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+	return false, ErrUnsupportedPasswordHash
+}
+
+// verifyArgon2id implements Verify for PHC-encoded Argon2id hashes.
+func (p Password) verifyArgon2id(hash string) (bool, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return false, fmt.Errorf("%w: malformed argon2id hash", ErrUnsupportedPasswordHash)
+	}
+	var version int
+	var params Argon2Params
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, fmt.Errorf("%w: argon2id version %q", ErrUnsupportedPasswordHash, parts[2])
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Time, &params.Threads); err != nil {
+		return false, fmt.Errorf("%w: argon2id parameters %q", ErrUnsupportedPasswordHash, parts[3])
+	}
+	if err := params.validate(); err != nil {
+		return false, fmt.Errorf("%w: %w", ErrUnsupportedPasswordHash, err)
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, fmt.Errorf("%w: argon2id salt: %v", ErrUnsupportedPasswordHash, err)
+	}
+	want, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(want) == 0 {
+		return false, fmt.Errorf("%w: argon2id key", ErrUnsupportedPasswordHash)
+	}
+	var got []byte
+	p.Use(func(plaintext []byte) {
+		got = argon2.IDKey(plaintext, salt, params.Time, params.Memory, params.Threads, uint32(len(want)))
+	})
+	return subtle.ConstantTimeCompare(got, want) == 1, nil
+}
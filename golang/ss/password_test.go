@@ -0,0 +1,87 @@
+package sensitivestring
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// fastArgon2 keeps the Argon2id tests quick.
+var fastArgon2 = Argon2Params{Time: 1, Memory: 64, Threads: 1, SaltLen: 16, KeyLen: 32}
+
+// TestPassword_Bcrypt verifies bcrypt hashes verify only the right password
+func TestPassword_Bcrypt(t *testing.T) {
+	hash, err := NewPassword("hunter2").HashBcryptCost(bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("HashBcryptCost() error = %v", err)
+	}
+	if strings.Contains(hash, "hunter2") {
+		t.Errorf("hash contains the plaintext: %v", hash)
+	}
+	if ok, err := NewPassword("hunter2").Verify(hash); !ok || err != nil {
+		t.Errorf("Verify(right password) = %v, %v, want true, nil", ok, err)
+	}
+	if ok, err := NewPassword("hunter3").Verify(hash); ok || err != nil {
+		t.Errorf("Verify(wrong password) = %v, %v, want false, nil", ok, err)
+	}
+	if _, err := NewPassword(strings.Repeat("x", 73)).HashBcrypt(); err == nil {
+		t.Errorf("HashBcrypt() of a 73 byte password error = nil")
+	}
+}
+
+// TestPassword_Argon2id verifies Argon2id hashes and their PHC encoding
+func TestPassword_Argon2id(t *testing.T) {
+	hash, err := NewPassword("hunter2").HashArgon2idParams(fastArgon2)
+	if err != nil {
+		t.Fatalf("HashArgon2idParams() error = %v", err)
+	}
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$") {
+		t.Errorf("HashArgon2idParams() = %v, want PHC format", hash)
+	}
+	other, _ := NewPassword("hunter2").HashArgon2idParams(fastArgon2)
+	if other == hash {
+		t.Errorf("two hashes of the same password are equal; salt is not random")
+	}
+	if ok, err := NewPassword("hunter2").Verify(hash); !ok || err != nil {
+		t.Errorf("Verify(right password) = %v, %v, want true, nil", ok, err)
+	}
+	if ok, err := NewPassword("hunter3").Verify(hash); ok || err != nil {
+		t.Errorf("Verify(wrong password) = %v, %v, want false, nil", ok, err)
+	}
+}
+
+// TestPassword_VerifyMalformed verifies unparseable hashes are errors
+func TestPassword_VerifyMalformed(t *testing.T) {
+	for _, hash := range []string{"", "plaintext", "$argon2id$v=19$m=64", "$argon2id$v=18$m=64,t=1,p=1$AAAA$AAAA", "$argon2id$v=19$m=64,t=1,p=1$!!$AAAA",
+		"$argon2id$v=19$m=65536,t=0,p=4$AAAA$AAAA", "$argon2id$v=19$m=65536,t=3,p=0$AAAA$AAAA", "$argon2id$v=19$m=8,t=3,p=4$AAAA$AAAA"} {
+		if ok, err := NewPassword("x").Verify(hash); ok || !errors.Is(err, ErrUnsupportedPasswordHash) {
+			t.Errorf("Verify(%q) = %v, %v, want ErrUnsupportedPasswordHash", hash, ok, err)
+		}
+	}
+	if ok, err := NewPassword("x").Verify("$2a$broken"); ok || err == nil {
+		t.Errorf("Verify(malformed bcrypt) = %v, %v, want an error", ok, err)
+	}
+}
+
+// TestPassword_Argon2idInvalidParams verifies parameters argon2 would panic on are rejected
+func TestPassword_Argon2idInvalidParams(t *testing.T) {
+	for _, params := range []Argon2Params{
+		{Time: 0, Memory: 64, Threads: 1, SaltLen: 16, KeyLen: 32},
+		{Time: 1, Memory: 64, Threads: 0, SaltLen: 16, KeyLen: 32},
+		{Time: 1, Memory: 8, Threads: 4, SaltLen: 16, KeyLen: 32},
+		{Time: 1, Memory: 64, Threads: 1, SaltLen: 16, KeyLen: 0},
+	} {
+		if _, err := NewPassword("x").HashArgon2idParams(params); !errors.Is(err, ErrInvalidArgon2Params) {
+			t.Errorf("HashArgon2idParams(%+v) error = %v, want ErrInvalidArgon2Params", params, err)
+		}
+	}
+}
+
+// TestPassword_Redacts verifies Password renders like a SensitiveString
+func TestPassword_Redacts(t *testing.T) {
+	if got := NewPassword("foo").String(); got != "sha256:"+fooHashHex {
+		t.Errorf("String() = %v, want %v", got, "sha256:"+fooHashHex)
+	}
+}
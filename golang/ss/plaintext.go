@@ -0,0 +1,202 @@
+package sensitivestring
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// plaintextContextKey marks a context.Context as allowing plaintext
+// serialization.
+type plaintextContextKey struct{}
+
+// WithPlaintext returns a copy of ctx that allows MarshalJSONContext and
+// MarshalYAMLContext to serialize SensitiveStrings as their plaintext, for
+// the one code path that must send secrets somewhere (e.g. building the
+// body of an auth request). Nothing else changes: SensitiveStrings
+// marshaled without the context, or on other paths, stay redacted.
+func WithPlaintext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, plaintextContextKey{}, true)
+}
+
+// PlaintextAllowed reports whether ctx was derived from WithPlaintext.
+func PlaintextAllowed(ctx context.Context) bool {
+	allowed, _ := ctx.Value(plaintextContextKey{}).(bool)
+	return allowed
+}
+
+// MarshalJSONContext marshals v with encoding/json. If ctx allows plaintext
+// (see WithPlaintext), every SensitiveString reachable from v through
+// exported fields, maps, slices, arrays, pointers and interfaces is
+// serialized as its plaintext; otherwise the result is that of
+// json.Marshal(v). v itself is never modified.
+func MarshalJSONContext(ctx context.Context, v interface{}) ([]byte, error) {
+	return MarshalJSON(v, treatmentFor(ctx))
+}
+
+// MarshalYAMLContext is the gopkg.in/yaml.v3 equivalent of
+// MarshalJSONContext.
+func MarshalYAMLContext(ctx context.Context, v interface{}) ([]byte, error) {
+	return MarshalYAML(v, treatmentFor(ctx))
+}
+
+// Treatment selects how MarshalJSON and MarshalYAML serialize the
+// SensitiveStrings embedded in a value.
+type Treatment int
+
+const (
+	// Redact serializes SensitiveStrings in their usual redacted form.
+	Redact Treatment = iota
+
+	// Reveal serializes SensitiveStrings as their plaintext.
+	Reveal
+)
+
+// MarshalJSON marshals v with encoding/json, serializing every embedded
+// SensitiveString according to treatment. It replaces the two-step
+// PlaintextReplacer plus json.Marshal, and unlike PlaintextReplacer it
+// also reaches SensitiveStrings inside structs:
+//
+//	body, err := sensitivestring.MarshalJSON(creds, sensitivestring.Reveal)
+func MarshalJSON(v interface{}, treatment Treatment) ([]byte, error) {
+	if treatment == Reveal {
+		v = revealAll(v)
+	}
+	return json.Marshal(v)
+}
+
+// MarshalYAML is the gopkg.in/yaml.v3 equivalent of MarshalJSON.
+func MarshalYAML(v interface{}, treatment Treatment) ([]byte, error) {
+	if treatment == Reveal {
+		v = revealAll(v)
+	}
+	return yaml.Marshal(v)
+}
+
+// treatmentFor returns the Treatment allowed by ctx.
+func treatmentFor(ctx context.Context) Treatment {
+	if PlaintextAllowed(ctx) {
+		return Reveal
+	}
+	return Redact
+}
+
+// revealRedactor renders the plaintext. It is only ever installed on the
+// copies made by revealAll.
+type revealRedactor struct{}
+
+func (revealRedactor) Redact(value string) string {
+	return value
+}
+
+// sensitiveStringType is the reflect.Type of SensitiveString.
+var sensitiveStringType = reflect.TypeFor[SensitiveString]()
+
+// revealAll returns a copy of v in which every reachable SensitiveString
+// renders its plaintext. Like PlaintextReplacer, subtrees without a
+// SensitiveString are shared with v rather than copied.
+func revealAll(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return v
+	}
+	out, changed := revealValue(rv, make(map[uintptr]bool))
+	if !changed {
+		return v
+	}
+	return out.Interface()
+}
+
+// revealValue implements revealAll for a single value, reporting whether a
+// copy was made. visiting holds the pointers being walked, so that cycles
+// end at the original pointer instead of recursing forever.
+func revealValue(v reflect.Value, visiting map[uintptr]bool) (reflect.Value, bool) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() || visiting[v.Pointer()] {
+			return v, false
+		}
+		if v.Type().Elem() == sensitiveStringType {
+			return reflect.ValueOf(v.Interface().(*SensitiveString).WithRedactor(revealRedactor{})), true
+		}
+		visiting[v.Pointer()] = true
+		defer delete(visiting, v.Pointer())
+		elem, changed := revealValue(v.Elem(), visiting)
+		if !changed {
+			return v, false
+		}
+		out := reflect.New(v.Type().Elem())
+		out.Elem().Set(elem)
+		return out, true
+	case reflect.Interface:
+		if v.IsNil() {
+			return v, false
+		}
+		elem, changed := revealValue(v.Elem(), visiting)
+		if !changed {
+			return v, false
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(elem)
+		return out, true
+	case reflect.Struct:
+		if v.Type() == sensitiveStringType {
+			s := v.Interface().(SensitiveString)
+			s.redactor = revealRedactor{}
+			return reflect.ValueOf(s), true
+		}
+		var out reflect.Value
+		for i := 0; i < v.NumField(); i++ {
+			if !v.Type().Field(i).IsExported() {
+				continue
+			}
+			field, changed := revealValue(v.Field(i), visiting)
+			if !changed {
+				continue
+			}
+			if !out.IsValid() {
+				out = reflect.New(v.Type()).Elem()
+				out.Set(v)
+			}
+			out.Field(i).Set(field)
+		}
+		return out, out.IsValid()
+	case reflect.Slice, reflect.Array:
+		var out reflect.Value
+		for i := 0; i < v.Len(); i++ {
+			elem, changed := revealValue(v.Index(i), visiting)
+			if !changed {
+				continue
+			}
+			if !out.IsValid() {
+				if v.Kind() == reflect.Slice {
+					out = reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+				} else {
+					out = reflect.New(v.Type()).Elem()
+				}
+				reflect.Copy(out, v)
+			}
+			out.Index(i).Set(elem)
+		}
+		return out, out.IsValid()
+	case reflect.Map:
+		var out reflect.Value
+		for iter := v.MapRange(); iter.Next(); {
+			elem, changed := revealValue(iter.Value(), visiting)
+			if !changed {
+				continue
+			}
+			if !out.IsValid() {
+				out = reflect.MakeMapWithSize(v.Type(), v.Len())
+				for copyIter := v.MapRange(); copyIter.Next(); {
+					out.SetMapIndex(copyIter.Key(), copyIter.Value())
+				}
+			}
+			out.SetMapIndex(iter.Key(), elem)
+		}
+		return out, out.IsValid()
+	}
+	return v, false
+}
@@ -0,0 +1,101 @@
+package sensitivestring
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+type authRequest struct {
+	User     string
+	Password *SensitiveString `json:"password" yaml:"password"`
+	Token    SensitiveString  `json:"token" yaml:"token"`
+	Extra    map[string]interface{}
+	Keys     []*SensitiveString
+	hidden   *SensitiveString
+}
+
+// TestMarshalJSONContext verifies plaintext is only emitted under WithPlaintext
+func TestMarshalJSONContext(t *testing.T) {
+	req := &authRequest{
+		User:     "alice",
+		Password: New("hunter2"),
+		Token:    *New("tok"),
+		Extra:    map[string]interface{}{"nested": []interface{}{New("deep")}, "plain": 1},
+		Keys:     []*SensitiveString{New("k1"), nil},
+		hidden:   New("unexported"),
+	}
+
+	redacted, err := MarshalJSONContext(context.Background(), req)
+	if err != nil {
+		t.Fatalf("MarshalJSONContext() error = %v", err)
+	}
+	for _, secret := range []string{"hunter2", "tok\"", "deep", "k1"} {
+		if strings.Contains(string(redacted), secret) {
+			t.Errorf("MarshalJSONContext() without plaintext = %s, contains %q", redacted, secret)
+		}
+	}
+
+	ctx := WithPlaintext(context.Background())
+	if !PlaintextAllowed(ctx) || PlaintextAllowed(context.Background()) {
+		t.Errorf("PlaintextAllowed() does not follow WithPlaintext")
+	}
+	plain, err := MarshalJSONContext(ctx, req)
+	if err != nil {
+		t.Fatalf("MarshalJSONContext() error = %v", err)
+	}
+	want := `{"User":"alice","password":"hunter2","token":"tok","Extra":{"nested":["deep"],"plain":1},"Keys":["k1",null]}`
+	if string(plain) != want {
+		t.Errorf("MarshalJSONContext() = %s, want %s", plain, want)
+	}
+	if got := req.Password.String(); got != New("hunter2").String() {
+		t.Errorf("original Password.String() = %v, want it unchanged", got)
+	}
+}
+
+// TestMarshalYAMLContext verifies the YAML helper and that cycles terminate
+func TestMarshalYAMLContext(t *testing.T) {
+	type node struct {
+		Secret *SensitiveString
+		Next   *node `yaml:"-"`
+	}
+	n := &node{Secret: New("hunter2")}
+	n.Next = n
+
+	out, err := MarshalYAMLContext(WithPlaintext(context.Background()), n)
+	if err != nil {
+		t.Fatalf("MarshalYAMLContext() error = %v", err)
+	}
+	if got := string(out); got != "secret: hunter2\n" {
+		t.Errorf("MarshalYAMLContext() = %q, want %q", got, "secret: hunter2\n")
+	}
+}
+
+// TestMarshalJSON_Treatment verifies the top-level helpers for both treatments
+func TestMarshalJSON_Treatment(t *testing.T) {
+	data := map[string]interface{}{"password": New("secret123")}
+
+	redacted, err := MarshalJSON(data, Redact)
+	if err != nil {
+		t.Fatalf("MarshalJSON(Redact) error = %v", err)
+	}
+	if want := `{"password":"` + New("secret123").String() + `"}`; string(redacted) != want {
+		t.Errorf("MarshalJSON(Redact) = %s, want %s", redacted, want)
+	}
+
+	plain, err := MarshalJSON(data, Reveal)
+	if err != nil {
+		t.Fatalf("MarshalJSON(Reveal) error = %v", err)
+	}
+	if want := `{"password":"secret123"}`; string(plain) != want {
+		t.Errorf("MarshalJSON(Reveal) = %s, want %s", plain, want)
+	}
+
+	out, err := MarshalYAML(data, Reveal)
+	if err != nil {
+		t.Fatalf("MarshalYAML(Reveal) error = %v", err)
+	}
+	if want := "password: secret123\n"; string(out) != want {
+		t.Errorf("MarshalYAML(Reveal) = %q, want %q", out, want)
+	}
+}
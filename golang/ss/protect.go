@@ -0,0 +1,7 @@
+package sensitivestring
+
+import "errors"
+
+// ErrProtectedMemoryUnsupported is returned by NewProtected on platforms
+// without an in-memory encryption facility.
+var ErrProtectedMemoryUnsupported = errors.New("sensitivestring: protected memory is not supported on this platform")
@@ -0,0 +1,9 @@
+//go:build !windows
+
+package sensitivestring
+
+// NewProtected is only supported on Windows, where it uses
+// CryptProtectMemory; elsewhere it returns ErrProtectedMemoryUnsupported.
+func NewProtected(value string) (*SensitiveString, error) {
+	return nil, ErrProtectedMemoryUnsupported
+}
@@ -0,0 +1,12 @@
+//go:build !windows
+
+package sensitivestring
+
+import "testing"
+
+// TestNewProtected_Unsupported verifies NewProtected reports lack of support
+func TestNewProtected_Unsupported(t *testing.T) {
+	if ss, err := NewProtected("foo"); ss != nil || err != ErrProtectedMemoryUnsupported {
+		t.Errorf("NewProtected() = (%v, %v), want (nil, ErrProtectedMemoryUnsupported)", ss, err)
+	}
+}
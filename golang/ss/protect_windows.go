@@ -0,0 +1,78 @@
+//go:build windows
+
+package sensitivestring
+
+import (
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	cryptProtectMemoryBlockSize   = 16
+	cryptProtectMemorySameProcess = 0
+)
+
+var (
+	crypt32                  = syscall.NewLazyDLL("crypt32.dll")
+	procCryptProtectMemory   = crypt32.NewProc("CryptProtectMemory")
+	procCryptUnprotectMemory = crypt32.NewProc("CryptUnprotectMemory")
+)
+
+// protectedMemory is a storage backend that keeps the plaintext encrypted
+// with CryptProtectMemory while resident, decrypting it only for the
+// duration of a reveal.
+type protectedMemory struct {
+	mu  sync.Mutex
+	buf []byte
+	n   int
+}
+
+// NewProtected creates a new SensitiveString whose plaintext is encrypted in
+// memory with CryptProtectMemory and only briefly decrypted inside Value()
+// and the other methods that need it.
+func NewProtected(value string) (*SensitiveString, error) {
+	size := (len(value) + cryptProtectMemoryBlockSize) / cryptProtectMemoryBlockSize * cryptProtectMemoryBlockSize
+	p := &protectedMemory{buf: make([]byte, size), n: len(value)}
+	copy(p.buf, value)
+	if err := cryptMemory(procCryptProtectMemory, p.buf); err != nil {
+		clear(p.buf)
+		return nil, err
+	}
+	return &SensitiveString{store: p}, nil
+}
+
+func (p *protectedMemory) reveal() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := cryptMemory(procCryptUnprotectMemory, p.buf); err != nil {
+		panic("sensitivestring: CryptUnprotectMemory failed: " + err.Error())
+	}
+	value := string(p.buf[:p.n])
+	if err := cryptMemory(procCryptProtectMemory, p.buf); err != nil {
+		clear(p.buf)
+		panic("sensitivestring: CryptProtectMemory failed: " + err.Error())
+	}
+	return value
+}
+
+func (p *protectedMemory) size() int {
+	return p.n
+}
+
+func (p *protectedMemory) wipe() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	clear(p.buf)
+	p.n = 0
+}
+
+// cryptMemory calls CryptProtectMemory or CryptUnprotectMemory on buf in
+// place.
+func cryptMemory(proc *syscall.LazyProc, buf []byte) error {
+	ok, _, err := proc.Call(uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)), cryptProtectMemorySameProcess)
+	if ok == 0 {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+//go:build windows
+
+package sensitivestring
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+// TestNewProtected verifies the protected backend behaves like a plain secret
+func TestNewProtected(t *testing.T) {
+	ss, err := NewProtected("foo")
+	if err != nil {
+		t.Fatalf("NewProtected() error = %v", err)
+	}
+
+	if got := ss.Value(); got != "foo" {
+		t.Errorf("Value() = %v, want foo", got)
+	}
+	if got := ss.Len(); got != 3 {
+		t.Errorf("Len() = %v, want 3", got)
+	}
+	if got := ss.String(); got != "sha256:"+fooHashHex {
+		t.Errorf("String() = %v, want %v", got, "sha256:"+fooHashHex)
+	}
+	if jsonBytes, _ := json.Marshal(ss); !bytes.Contains(jsonBytes, []byte(fooHashHex)) {
+		t.Errorf("json.Marshal() = %s, want hash", jsonBytes)
+	}
+	if bytes.Contains(ss.store.(*protectedMemory).buf, []byte("foo")) {
+		t.Errorf("protected buffer holds the plaintext")
+	}
+}
@@ -0,0 +1,316 @@
+package sensitivestring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrSecretNotFound is wrapped by the errors providers return for a
+	// name they do not hold.
+	ErrSecretNotFound = errors.New("sensitivestring: secret not found")
+	// ErrUnknownProvider is returned when a reference names a provider that
+	// is not registered.
+	ErrUnknownProvider = errors.New("sensitivestring: unknown secret provider")
+)
+
+// Provider resolves secrets by name from a backing store such as Vault, a
+// cloud secret manager, files or the environment. Resolve must honor ctx
+// and should return an error wrapping ErrSecretNotFound when the store has
+// no secret called name. Implementations must be safe for concurrent use,
+// and their errors must never contain secret material.
+type Provider interface {
+	Resolve(ctx context.Context, name string) (*SensitiveString, error)
+}
+
+// ProviderFunc adapts a function to the Provider interface.
+type ProviderFunc func(ctx context.Context, name string) (*SensitiveString, error)
+
+// Resolve calls f(ctx, name).
+func (f ProviderFunc) Resolve(ctx context.Context, name string) (*SensitiveString, error) {
+	return f(ctx, name)
+}
+
+// MapProvider is a Provider serving a fixed set of secrets, e.g. for tests
+// or for values already loaded by other means.
+type MapProvider map[string]*SensitiveString
+
+// Resolve returns the secret called name.
+func (m MapProvider) Resolve(ctx context.Context, name string) (*SensitiveString, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s, ok := m[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+	}
+	return s, nil
+}
+
+// Ref is a reference to a secret: the name of the Provider that holds it,
+// as registered with a Resolver, and its name within that provider. Refs
+// let configuration declare which secret to use without containing it;
+// the secret is only fetched when Resolve is called.
+//
+// A Ref is written either as "provider:name", e.g. "vault:db/password", or
+// as a URI whose scheme names the provider and whose remainder is the name:
+//
+//	env://DB_PASSWORD                   environment variable (EnvProvider)
+//	file:///run/secrets/db_password     file contents (FileProvider)
+//	vault://secret/data/db#password     key of a Vault secret
+//	aws-sm://arn:aws:secretsmanager:... AWS Secrets Manager ARN
+//
+// The env and file schemes are registered with the DefaultResolver from the
+// start; others resolve once a provider is registered under their scheme.
+// The name is passed to the provider verbatim, so "#key" fragments and
+// similar conventions are up to each provider. Ref implements
+// encoding.TextMarshaler and encoding.TextUnmarshaler so it can be a field
+// in JSON, YAML or TOML configuration.
+type Ref struct {
+	Provider string
+	Name     string
+}
+
+// ParseRef parses a Ref from its "provider:name" or URI form.
+func ParseRef(ref string) (Ref, error) {
+	provider, name, ok := strings.Cut(ref, "://")
+	if !ok || !isURIScheme(provider) {
+		provider, name, ok = strings.Cut(ref, ":")
+	}
+	if !ok || provider == "" || name == "" {
+		return Ref{}, fmt.Errorf("sensitivestring: invalid secret reference %q, want provider:name or scheme://name", ref)
+	}
+	return Ref{Provider: provider, Name: name}, nil
+}
+
+// isURIScheme reports whether s is a valid URI scheme (RFC 3986): a letter
+// followed by letters, digits, "+", "-" or ".".
+func isURIScheme(s string) bool {
+	for i, c := range s {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+		case i > 0 && ('0' <= c && c <= '9' || c == '+' || c == '-' || c == '.'):
+		default:
+			return false
+		}
+	}
+	return s != ""
+}
+
+// String returns the "provider:name" form of r.
+func (r Ref) String() string {
+	return r.Provider + ":" + r.Name
+}
+
+// URI returns the "provider://name" form of r.
+func (r Ref) URI() string {
+	return r.Provider + "://" + r.Name
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r Ref) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (r *Ref) UnmarshalText(text []byte) error {
+	parsed, err := ParseRef(string(text))
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// Resolve fetches the referenced secret through the DefaultResolver.
+func (r Ref) Resolve(ctx context.Context) (*SensitiveString, error) {
+	return DefaultResolver().ResolveRef(ctx, r)
+}
+
+// DefaultResolveTimeout bounds each resolution through a Resolver unless
+// the provider was registered with WithResolveTimeout, so a slow secret
+// backend cannot hang application startup indefinitely.
+const DefaultResolveTimeout = 30 * time.Second
+
+// ProviderOption configures a provider registered with a Resolver.
+type ProviderOption func(*registeredProvider)
+
+// WithResolveTimeout bounds each resolution through the provider by d
+// instead of DefaultResolveTimeout. A d of zero or less disables the
+// timeout; the caller's context still applies.
+func WithResolveTimeout(d time.Duration) ProviderOption {
+	return func(r *registeredProvider) { r.timeout = d }
+}
+
+// registeredProvider is a provider together with its registration options.
+type registeredProvider struct {
+	provider Provider
+	timeout  time.Duration
+}
+
+// Resolver maps provider names to Providers and resolves Refs through
+// them. The zero value is not usable; create resolvers with NewResolver.
+type Resolver struct {
+	mu        sync.RWMutex
+	providers map[string]registeredProvider
+	flights   flightGroup
+}
+
+// NewResolver creates a Resolver with no providers.
+func NewResolver() *Resolver {
+	return &Resolver{providers: make(map[string]registeredProvider)}
+}
+
+// Register makes provider available under name, replacing any provider
+// previously registered under it.
+func (r *Resolver) Register(name string, provider Provider, opts ...ProviderOption) {
+	registered := registeredProvider{provider: provider, timeout: DefaultResolveTimeout}
+	for _, opt := range opts {
+		opt(&registered)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.providers[name] = registered
+}
+
+// Unregister removes the provider registered under name.
+func (r *Resolver) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.providers, name)
+}
+
+// Provider returns the provider registered under name.
+func (r *Resolver) Provider(name string) (Provider, bool) {
+	registered, ok := r.registered(name)
+	return registered.provider, ok
+}
+
+func (r *Resolver) registered(name string) (registeredProvider, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	registered, ok := r.providers[name]
+	return registered, ok
+}
+
+// Resolve parses ref, in "provider:name" or URI form, and fetches the
+// secret it names.
+func (r *Resolver) Resolve(ctx context.Context, ref string) (*SensitiveString, error) {
+	parsed, err := ParseRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	return r.ResolveRef(ctx, parsed)
+}
+
+// ResolveRef fetches the secret ref names from its provider, within the
+// provider's resolve timeout. Concurrent calls for the same ref share one
+// resolution. Errors identify the reference, never the secret.
+func (r *Resolver) ResolveRef(ctx context.Context, ref Ref) (*SensitiveString, error) {
+	registered, ok := r.registered(ref.Provider)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProvider, ref.Provider)
+	}
+	s, err := observeResolve(ctx, ResolveEvent(ref), func(ctx context.Context) (*SensitiveString, error) {
+		return r.flights.resolve(ctx, ref.String(), func(ctx context.Context) (*SensitiveString, error) {
+			return resolveWithin(ctx, registered.provider, ref.Name, registered.timeout)
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sensitivestring: resolving %s: %w", ref, err)
+	}
+	return s, nil
+}
+
+// Timeout returns a Provider that bounds each resolution through provider
+// by d, e.g. for one slow member of a ChainProvider.
+func Timeout(provider Provider, d time.Duration) Provider {
+	return timeoutProvider{provider: provider, timeout: d}
+}
+
+// timeoutProvider is the Provider returned by Timeout.
+type timeoutProvider struct {
+	provider Provider
+	timeout  time.Duration
+}
+
+// Resolve implements Provider.
+func (t timeoutProvider) Resolve(ctx context.Context, name string) (*SensitiveString, error) {
+	return resolveWithin(ctx, t.provider, name, t.timeout)
+}
+
+// resolveWithin resolves name from p, giving up once timeout (if
+// positive) has passed or ctx is done. p can read timeout from its context
+// with resolveTimeout, to bound work it does outside the call.
+func resolveWithin(ctx context.Context, p Provider, name string, timeout time.Duration) (*SensitiveString, error) {
+	ctx = context.WithValue(ctx, resolveTimeoutKey{}, timeout)
+	return within(ctx, timeout, func(ctx context.Context) (*SensitiveString, error) {
+		return p.Resolve(ctx, name)
+	})
+}
+
+// resolveTimeoutKey is the context key under which resolveWithin records
+// its timeout.
+type resolveTimeoutKey struct{}
+
+// resolveTimeout returns the timeout of the innermost resolveWithin that
+// ctx was passed through, or DefaultResolveTimeout outside of one.
+func resolveTimeout(ctx context.Context) time.Duration {
+	if timeout, ok := ctx.Value(resolveTimeoutKey{}).(time.Duration); ok {
+		return timeout
+	}
+	return DefaultResolveTimeout
+}
+
+// within calls fn, returning at the latest once timeout (if positive) has
+// passed or ctx is done, even if fn ignores its context; fn then finishes
+// in the background and its result is dropped.
+func within[T any](ctx context.Context, timeout time.Duration, fn func(context.Context) (T, error)) (T, error) {
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	type result struct {
+		value T
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		value, err := fn(ctx)
+		done <- result{value, err}
+	}()
+	select {
+	case r := <-done:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// DefaultResolver returns the process-wide Resolver used by the
+// package-level RegisterProvider and Resolve functions and by Ref.Resolve.
+func DefaultResolver() *Resolver {
+	return loadConfig().resolver
+}
+
+// SetDefaultResolver replaces the process-wide Resolver.
+func SetDefaultResolver(r *Resolver) {
+	updateConfig(func(c *config) { c.resolver = r })
+}
+
+// RegisterProvider registers provider under name in the DefaultResolver.
+func RegisterProvider(name string, provider Provider, opts ...ProviderOption) {
+	DefaultResolver().Register(name, provider, opts...)
+}
+
+// Resolve fetches the secret named by ref, in "provider:name" or URI form
+// (see Ref), through the DefaultResolver.
+func Resolve(ctx context.Context, ref string) (*SensitiveString, error) {
+	return DefaultResolver().Resolve(ctx, ref)
+}
@@ -0,0 +1,119 @@
+package sensitivestring
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// withDefaultResolver replaces the default resolver for the duration of a test.
+func withDefaultResolver(t *testing.T, r *Resolver) {
+	t.Helper()
+	previous := DefaultResolver()
+	SetDefaultResolver(r)
+	t.Cleanup(func() { SetDefaultResolver(previous) })
+}
+
+// TestResolver verifies references resolve through the registered provider
+func TestResolver(t *testing.T) {
+	r := NewResolver()
+	r.Register("static", MapProvider{"db/password": New("hunter2")})
+
+	s, err := r.Resolve(context.Background(), "static:db/password")
+	if err != nil || s.Value() != "hunter2" {
+		t.Fatalf("Resolve() = %v, %v, want hunter2", s, err)
+	}
+
+	_, err = r.Resolve(context.Background(), "static:missing")
+	if !errors.Is(err, ErrSecretNotFound) || !strings.Contains(err.Error(), "static:missing") {
+		t.Errorf("Resolve(missing) error = %v, want ErrSecretNotFound naming the reference", err)
+	}
+	if _, err := r.Resolve(context.Background(), "vault:db"); !errors.Is(err, ErrUnknownProvider) {
+		t.Errorf("Resolve(unregistered) error = %v, want ErrUnknownProvider", err)
+	}
+	if _, err := r.Resolve(context.Background(), "no-provider"); err == nil {
+		t.Errorf("Resolve(malformed) error = nil")
+	}
+
+	r.Unregister("static")
+	if _, ok := r.Provider("static"); ok {
+		t.Errorf("Provider() after Unregister found the provider")
+	}
+}
+
+// TestRef verifies references parse from configuration and resolve lazily
+func TestRef(t *testing.T) {
+	calls := 0
+	r := NewResolver()
+	r.Register("fn", ProviderFunc(func(ctx context.Context, name string) (*SensitiveString, error) {
+		calls++
+		return NewLabeled(name, "value-of-"+name), nil
+	}))
+	withDefaultResolver(t, r)
+
+	var cfg struct {
+		Password Ref `json:"password"`
+	}
+	if err := json.Unmarshal([]byte(`{"password":"fn:db"}`), &cfg); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if cfg.Password != (Ref{Provider: "fn", Name: "db"}) || calls != 0 {
+		t.Fatalf("Unmarshal() = %+v after %d calls, want fn:db and no resolution", cfg.Password, calls)
+	}
+	s, err := cfg.Password.Resolve(context.Background())
+	if err != nil || s.Value() != "value-of-db" {
+		t.Errorf("Ref.Resolve() = %v, %v", s, err)
+	}
+	if out, _ := json.Marshal(cfg); string(out) != `{"password":"fn:db"}` {
+		t.Errorf("Marshal() = %s", out)
+	}
+	if err := json.Unmarshal([]byte(`{"password":":db"}`), &cfg); err == nil {
+		t.Errorf("Unmarshal(empty provider) error = nil")
+	}
+
+	RegisterProvider("static", MapProvider{"api": New("token")})
+	if s, err := Resolve(context.Background(), "static:api"); err != nil || s.Value() != "token" {
+		t.Errorf("package-level Resolve() = %v, %v, want token", s, err)
+	}
+}
+
+// TestMapProvider_Canceled verifies a canceled context is honored
+func TestMapProvider_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := (MapProvider{"a": New("b")}).Resolve(ctx, "a"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Resolve() error = %v, want context.Canceled", err)
+	}
+}
+
+// TestResolver_Timeout verifies a hung provider is abandoned at its timeout
+func TestResolver_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	hung := ProviderFunc(func(ctx context.Context, name string) (*SensitiveString, error) {
+		<-release // ignores ctx
+		return New("late"), nil
+	})
+
+	r := NewResolver()
+	r.Register("slow", hung, WithResolveTimeout(10*time.Millisecond))
+	if _, err := r.Resolve(context.Background(), "slow:db"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Resolve() error = %v, want context.DeadlineExceeded", err)
+	}
+	if _, err := Timeout(hung, 10*time.Millisecond).Resolve(context.Background(), "db"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Timeout().Resolve() error = %v, want context.DeadlineExceeded", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	honoring := ProviderFunc(func(ctx context.Context, name string) (*SensitiveString, error) {
+		return nil, ctx.Err()
+	})
+	r.Register("honoring", honoring, WithResolveTimeout(0))
+	if _, err := r.Resolve(ctx, "honoring:db"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Resolve() with a canceled context error = %v, want context.Canceled", err)
+	}
+}
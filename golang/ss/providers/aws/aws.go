@@ -0,0 +1,187 @@
+// Package aws provides Providers backed by AWS Secrets Manager and AWS
+// Systems Manager Parameter Store, so secret ARNs and SSM parameters can be
+// resolved straight into SensitiveStrings:
+//
+//	cfg, _ := config.LoadDefaultConfig(ctx)
+//	ss.RegisterProvider("aws-sm", aws.NewSecretsManager(secretsmanager.NewFromConfig(cfg)))
+//	ss.RegisterProvider("aws-ssm", aws.NewParameterStore(ssm.NewFromConfig(cfg)))
+//	password, err := ss.Resolve(ctx, "aws-sm:prod/db/password")
+//
+// The clients are accepted as narrow interfaces, which the SDK clients
+// satisfy, so tests can substitute fakes. Error messages name secrets by
+// their ID only.
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	smtypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+
+	ss "github.com/earlye/sensitive-strings/golang/ss"
+)
+
+// Batch sizes accepted by BatchGetSecretValue and GetParameters.
+const (
+	secretsManagerBatchSize = 20
+	parameterStoreBatchSize = 10
+)
+
+// SecretsManagerAPI is the subset of *secretsmanager.Client used by
+// SecretsManager.
+type SecretsManagerAPI interface {
+	GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error)
+	BatchGetSecretValue(ctx context.Context, params *secretsmanager.BatchGetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.BatchGetSecretValueOutput, error)
+}
+
+// SecretsManager resolves secrets from AWS Secrets Manager by name or ARN,
+// using the AWSCURRENT version.
+type SecretsManager struct {
+	client SecretsManagerAPI
+}
+
+// NewSecretsManager returns a SecretsManager provider using client.
+func NewSecretsManager(client SecretsManagerAPI) *SecretsManager {
+	return &SecretsManager{client: client}
+}
+
+// Resolve implements ss.Provider. Binary secrets are returned as their raw
+// bytes; use ResolveBytes to hold them as SensitiveBytes instead.
+func (p *SecretsManager) Resolve(ctx context.Context, name string) (*ss.SensitiveString, error) {
+	out, err := p.getSecretValue(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(out.SecretBinary)
+	if out.SecretString != nil {
+		return ss.New(*out.SecretString, ss.WithLabel(name)), nil
+	}
+	return ss.NewFromBytes(out.SecretBinary), nil
+}
+
+// ResolveBytes returns the binary secret called name, or the bytes of a
+// string secret.
+func (p *SecretsManager) ResolveBytes(ctx context.Context, name string) (*ss.SensitiveBytes, error) {
+	out, err := p.getSecretValue(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(out.SecretBinary)
+	if out.SecretString != nil {
+		return ss.NewBytes([]byte(*out.SecretString)), nil
+	}
+	return ss.NewBytes(out.SecretBinary), nil
+}
+
+func (p *SecretsManager) getSecretValue(ctx context.Context, name string) (*secretsmanager.GetSecretValueOutput, error) {
+	out, err := p.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: awssdk.String(name)})
+	var notFound *smtypes.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		return nil, fmt.Errorf("%w: %s", ss.ErrSecretNotFound, name)
+	}
+	return out, err
+}
+
+// ResolveBatch fetches many secrets with BatchGetSecretValue, 20 IDs and
+// as many pages as needed at a time, keyed by the names or ARNs given. It
+// returns the secrets it fetched together with the errors for the rest,
+// joined.
+func (p *SecretsManager) ResolveBatch(ctx context.Context, names ...string) (map[string]*ss.SensitiveString, error) {
+	result := make(map[string]*ss.SensitiveString, len(names))
+	var errs []error
+	for chunk := range slices.Chunk(names, secretsManagerBatchSize) {
+		paginator := secretsmanager.NewBatchGetSecretValuePaginator(p.client, &secretsmanager.BatchGetSecretValueInput{SecretIdList: chunk})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return result, errors.Join(append(errs, err)...)
+			}
+			for _, entry := range page.SecretValues {
+				id := requestedID(chunk, entry.Name, entry.ARN)
+				if entry.SecretString != nil {
+					result[id] = ss.New(*entry.SecretString, ss.WithLabel(id))
+				} else {
+					result[id] = ss.NewFromBytes(entry.SecretBinary)
+					clear(entry.SecretBinary)
+				}
+			}
+			for _, e := range page.Errors {
+				id := awssdk.ToString(e.SecretId)
+				if awssdk.ToString(e.ErrorCode) == "ResourceNotFoundException" {
+					errs = append(errs, fmt.Errorf("%w: %s", ss.ErrSecretNotFound, id))
+				} else {
+					errs = append(errs, fmt.Errorf("aws: %s: %s", id, awssdk.ToString(e.ErrorCode)))
+				}
+			}
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+// SSMAPI is the subset of *ssm.Client used by ParameterStore.
+type SSMAPI interface {
+	GetParameter(ctx context.Context, params *ssm.GetParameterInput, optFns ...func(*ssm.Options)) (*ssm.GetParameterOutput, error)
+	GetParameters(ctx context.Context, params *ssm.GetParametersInput, optFns ...func(*ssm.Options)) (*ssm.GetParametersOutput, error)
+}
+
+// ParameterStore resolves parameters from SSM Parameter Store by name or
+// ARN, optionally with a ":version" or ":label" selector. SecureString
+// parameters are decrypted.
+type ParameterStore struct {
+	client SSMAPI
+}
+
+// NewParameterStore returns a ParameterStore provider using client.
+func NewParameterStore(client SSMAPI) *ParameterStore {
+	return &ParameterStore{client: client}
+}
+
+// Resolve implements ss.Provider.
+func (p *ParameterStore) Resolve(ctx context.Context, name string) (*ss.SensitiveString, error) {
+	out, err := p.client.GetParameter(ctx, &ssm.GetParameterInput{Name: awssdk.String(name), WithDecryption: awssdk.Bool(true)})
+	var notFound *ssmtypes.ParameterNotFound
+	if errors.As(err, &notFound) {
+		return nil, fmt.Errorf("%w: %s", ss.ErrSecretNotFound, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ss.New(awssdk.ToString(out.Parameter.Value), ss.WithLabel(name)), nil
+}
+
+// ResolveBatch fetches many parameters with GetParameters, 10 at a time,
+// keyed by the names given. It returns the parameters it fetched together
+// with the errors for the rest, joined.
+func (p *ParameterStore) ResolveBatch(ctx context.Context, names ...string) (map[string]*ss.SensitiveString, error) {
+	result := make(map[string]*ss.SensitiveString, len(names))
+	var errs []error
+	for chunk := range slices.Chunk(names, parameterStoreBatchSize) {
+		out, err := p.client.GetParameters(ctx, &ssm.GetParametersInput{Names: chunk, WithDecryption: awssdk.Bool(true)})
+		if err != nil {
+			return result, errors.Join(append(errs, err)...)
+		}
+		for _, param := range out.Parameters {
+			name := awssdk.ToString(param.Name) + awssdk.ToString(param.Selector)
+			id := requestedID(chunk, &name, param.ARN)
+			result[id] = ss.New(awssdk.ToString(param.Value), ss.WithLabel(id))
+		}
+		for _, name := range out.InvalidParameters {
+			errs = append(errs, fmt.Errorf("%w: %s", ss.ErrSecretNotFound, name))
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+// requestedID returns whichever of name and arn the caller asked for.
+func requestedID(requested []string, name, arn *string) string {
+	if arn != nil && slices.Contains(requested, *arn) {
+		return *arn
+	}
+	return awssdk.ToString(name)
+}
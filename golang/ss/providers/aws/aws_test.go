@@ -0,0 +1,176 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	smtypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+
+	ss "github.com/earlye/sensitive-strings/golang/ss"
+)
+
+// fakeSecretsManager serves string secrets named s*, binary secrets named
+// b*, and pages batch results one secret per page.
+type fakeSecretsManager struct {
+	batchCalls int
+}
+
+func (f *fakeSecretsManager) GetSecretValue(ctx context.Context, in *secretsmanager.GetSecretValueInput, _ ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+	id := *in.SecretId
+	switch {
+	case strings.HasPrefix(id, "s"):
+		return &secretsmanager.GetSecretValueOutput{SecretString: awssdk.String("value-of-" + id)}, nil
+	case strings.HasPrefix(id, "b"):
+		return &secretsmanager.GetSecretValueOutput{SecretBinary: []byte{0, 1, 2}}, nil
+	}
+	return nil, &smtypes.ResourceNotFoundException{Message: awssdk.String("not found")}
+}
+
+func (f *fakeSecretsManager) BatchGetSecretValue(ctx context.Context, in *secretsmanager.BatchGetSecretValueInput, _ ...func(*secretsmanager.Options)) (*secretsmanager.BatchGetSecretValueOutput, error) {
+	f.batchCalls++
+	page := 0
+	if in.NextToken != nil {
+		fmt.Sscan(*in.NextToken, &page)
+	}
+	out := &secretsmanager.BatchGetSecretValueOutput{}
+	id := in.SecretIdList[page]
+	name := id[strings.LastIndex(id, ":")+1:]
+	if strings.HasPrefix(name, "s") {
+		out.SecretValues = []smtypes.SecretValueEntry{{
+			Name:         awssdk.String(name),
+			ARN:          awssdk.String("arn:aws:secretsmanager:us-east-1:1:secret:" + name),
+			SecretString: awssdk.String("value-of-" + name),
+		}}
+	} else {
+		out.Errors = []smtypes.APIErrorType{{SecretId: awssdk.String(id), ErrorCode: awssdk.String("ResourceNotFoundException")}}
+	}
+	if page+1 < len(in.SecretIdList) {
+		out.NextToken = awssdk.String(fmt.Sprint(page + 1))
+	}
+	return out, nil
+}
+
+// TestSecretsManager_Resolve verifies string, binary and missing secrets
+func TestSecretsManager_Resolve(t *testing.T) {
+	p := NewSecretsManager(&fakeSecretsManager{})
+	var _ ss.BatchProvider = p
+
+	s, err := p.Resolve(context.Background(), "s1")
+	if err != nil || s.Value() != "value-of-s1" {
+		t.Fatalf("Resolve(s1) = %v, %v", s, err)
+	}
+	if got := s.Redacted().Label; got != "s1" {
+		t.Errorf("Resolve(s1) label = %q, want s1", got)
+	}
+	b, err := p.ResolveBytes(context.Background(), "b1")
+	if err != nil || string(b.Bytes()) != "\x00\x01\x02" {
+		t.Errorf("ResolveBytes(b1) = %x, %v", b.Bytes(), err)
+	}
+	if _, err := p.Resolve(context.Background(), "missing"); !errors.Is(err, ss.ErrSecretNotFound) {
+		t.Errorf("Resolve(missing) error = %v, want ErrSecretNotFound", err)
+	}
+}
+
+// TestSecretsManager_ResolveBatch verifies chunking, pagination, ARN keys and partial results
+func TestSecretsManager_ResolveBatch(t *testing.T) {
+	fake := &fakeSecretsManager{}
+	p := NewSecretsManager(fake)
+	names := []string{"missing", "arn:aws:secretsmanager:us-east-1:1:secret:s0"}
+	for i := 1; i < 25; i++ {
+		names = append(names, fmt.Sprintf("s%d", i))
+	}
+
+	got, err := p.ResolveBatch(context.Background(), names...)
+	if !errors.Is(err, ss.ErrSecretNotFound) || !strings.Contains(err.Error(), "missing") {
+		t.Errorf("ResolveBatch() error = %v, want missing reported", err)
+	}
+	if len(got) != len(names)-1 {
+		t.Fatalf("ResolveBatch() returned %d secrets, want %d", len(got), len(names)-1)
+	}
+	if s := got["arn:aws:secretsmanager:us-east-1:1:secret:s0"]; s.Value() != "value-of-s0" {
+		t.Errorf("ResolveBatch() by ARN = %v", s.Value())
+	}
+	if s := got["s24"]; s.Value() != "value-of-s24" {
+		t.Errorf("ResolveBatch()[s24] = %v", s.Value())
+	}
+	if fake.batchCalls != len(names) {
+		t.Errorf("BatchGetSecretValue called %d times, want one page per secret", fake.batchCalls)
+	}
+}
+
+// fakeSSM serves every parameter except those named missing*, recording
+// whether decryption was requested.
+type fakeSSM struct {
+	batches      int
+	noDecryption bool
+}
+
+func (f *fakeSSM) GetParameter(ctx context.Context, in *ssm.GetParameterInput, _ ...func(*ssm.Options)) (*ssm.GetParameterOutput, error) {
+	f.noDecryption = f.noDecryption || !*in.WithDecryption
+	if strings.HasPrefix(*in.Name, "missing") {
+		return nil, &ssmtypes.ParameterNotFound{}
+	}
+	return &ssm.GetParameterOutput{Parameter: &ssmtypes.Parameter{Name: in.Name, Value: awssdk.String("value-of-" + *in.Name)}}, nil
+}
+
+func (f *fakeSSM) GetParameters(ctx context.Context, in *ssm.GetParametersInput, _ ...func(*ssm.Options)) (*ssm.GetParametersOutput, error) {
+	f.batches++
+	f.noDecryption = f.noDecryption || !*in.WithDecryption
+	if len(in.Names) > parameterStoreBatchSize {
+		return nil, errors.New("too many names")
+	}
+	out := &ssm.GetParametersOutput{}
+	for _, name := range in.Names {
+		if strings.HasPrefix(name, "missing") {
+			out.InvalidParameters = append(out.InvalidParameters, name)
+			continue
+		}
+		base, selector, _ := strings.Cut(name, ":")
+		param := ssmtypes.Parameter{Name: awssdk.String(base), Value: awssdk.String("value-of-" + name)}
+		if selector != "" {
+			param.Selector = awssdk.String(":" + selector)
+		}
+		out.Parameters = append(out.Parameters, param)
+	}
+	return out, nil
+}
+
+// TestParameterStore verifies decryption, selectors, batching and missing parameters
+func TestParameterStore(t *testing.T) {
+	fake := &fakeSSM{}
+	p := NewParameterStore(fake)
+	var _ ss.BatchProvider = p
+
+	s, err := p.Resolve(context.Background(), "/prod/db")
+	if err != nil || s.Value() != "value-of-/prod/db" {
+		t.Errorf("Resolve() = %v, %v", s, err)
+	}
+	if _, err := p.Resolve(context.Background(), "missing"); !errors.Is(err, ss.ErrSecretNotFound) {
+		t.Errorf("Resolve(missing) error = %v, want ErrSecretNotFound", err)
+	}
+
+	names := []string{"/prod/api:3", "missing-one"}
+	for i := 0; i < 12; i++ {
+		names = append(names, fmt.Sprintf("/prod/p%d", i))
+	}
+	got, err := p.ResolveBatch(context.Background(), names...)
+	if !errors.Is(err, ss.ErrSecretNotFound) {
+		t.Errorf("ResolveBatch() error = %v, want ErrSecretNotFound", err)
+	}
+	if len(got) != len(names)-1 || got["/prod/api:3"].Value() != "value-of-/prod/api:3" {
+		t.Errorf("ResolveBatch() = %d secrets, /prod/api:3 = %v", len(got), got["/prod/api:3"])
+	}
+	if fake.batches != 2 {
+		t.Errorf("GetParameters called %d times, want 2", fake.batches)
+	}
+	if fake.noDecryption {
+		t.Errorf("a request did not ask for decryption")
+	}
+}
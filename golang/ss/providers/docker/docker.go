@@ -0,0 +1,110 @@
+// Package docker provides a Provider for Docker swarm and Compose secrets,
+// which are mounted as files named after the secret under /run/secrets:
+//
+//	ss.RegisterProvider("docker", docker.New(docker.Optional("smtp_password")))
+//	password, err := ss.Resolve(ctx, "docker:db_password")
+package docker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+
+	ss "github.com/earlye/sensitive-strings/golang/ss"
+)
+
+// DefaultDir is where Docker mounts secrets inside a container.
+const DefaultDir = "/run/secrets"
+
+// Provider resolves secret names to the contents of the files of the same
+// name in its directory.
+type Provider struct {
+	dir      string
+	keepEOL  bool
+	optional []string
+}
+
+// Option configures a Provider.
+type Option func(*Provider)
+
+// WithDir reads secrets from dir instead of DefaultDir, e.g. for a custom
+// "target" or for local development.
+func WithDir(dir string) Option {
+	return func(p *Provider) { p.dir = dir }
+}
+
+// WithoutTrim keeps the trailing newline that editors and "echo" leave at
+// the end of secret files, which is otherwise removed.
+func WithoutTrim() Option {
+	return func(p *Provider) { p.keepEOL = true }
+}
+
+// Optional marks names as optional: when such a secret is not mounted,
+// Resolve returns an empty SensitiveString instead of an error wrapping
+// ss.ErrSecretNotFound.
+func Optional(names ...string) Option {
+	return func(p *Provider) { p.optional = append(p.optional, names...) }
+}
+
+// New returns a Provider reading secrets from DefaultDir.
+func New(opts ...Option) *Provider {
+	p := &Provider{dir: DefaultDir}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Resolve implements ss.Provider. Names are slash-separated paths within
+// the secrets directory; names that would leave it are rejected.
+func (p *Provider) Resolve(ctx context.Context, name string) (*ss.SensitiveString, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if !fs.ValidPath(name) {
+		return nil, fmt.Errorf("docker: invalid secret name %q", name)
+	}
+	root, err := os.OpenRoot(p.dir)
+	if err == nil {
+		defer root.Close()
+		var data []byte
+		data, err = root.ReadFile(name)
+		defer clear(data)
+		if err == nil {
+			if !p.keepEOL {
+				data = trimEOL(data)
+			}
+			return ss.NewFromBytes(data), nil
+		}
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("docker: reading secret %s: %w", name, err)
+	}
+	if slices.Contains(p.optional, name) {
+		return ss.New(""), nil
+	}
+	return nil, fmt.Errorf("%w: %s", ss.ErrSecretNotFound, name)
+}
+
+// HealthCheck implements ss.HealthChecker: it reports an error unless the
+// secrets directory can be read.
+func (p *Provider) HealthCheck(ctx context.Context) error {
+	root, err := os.OpenRoot(p.dir)
+	if err != nil {
+		return fmt.Errorf("docker: secrets directory: %w", err)
+	}
+	return root.Close()
+}
+
+// trimEOL removes one trailing "\n" or "\r\n" from data.
+func trimEOL(data []byte) []byte {
+	if data, ok := bytes.CutSuffix(data, []byte("\n")); ok {
+		data, _ = bytes.CutSuffix(data, []byte("\r"))
+		return data
+	}
+	return data
+}
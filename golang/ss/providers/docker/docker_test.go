@@ -0,0 +1,58 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ss "github.com/earlye/sensitive-strings/golang/ss"
+)
+
+// TestProvider verifies secret files resolve with their trailing newline trimmed
+func TestProvider(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "db_password"), []byte("hunter2\n"), 0o600)
+	os.WriteFile(filepath.Join(dir, "windows"), []byte("line\r\n"), 0o600)
+	os.WriteFile(filepath.Join(dir, "raw"), []byte("two\n\n"), 0o600)
+
+	p := New(WithDir(dir))
+	var _ ss.Provider = p
+	for name, want := range map[string]string{"db_password": "hunter2", "windows": "line", "raw": "two\n"} {
+		if s, err := p.Resolve(context.Background(), name); err != nil || s.Value() != want {
+			t.Errorf("Resolve(%s) = %q, %v, want %q", name, s.Value(), err, want)
+		}
+	}
+	if s, _ := New(WithDir(dir), WithoutTrim()).Resolve(context.Background(), "db_password"); s.Value() != "hunter2\n" {
+		t.Errorf("Resolve() WithoutTrim = %q, want the newline kept", s.Value())
+	}
+	if _, err := p.Resolve(context.Background(), "../db_password"); err == nil {
+		t.Errorf("Resolve(../db_password) error = nil")
+	}
+}
+
+// TestProvider_Optional verifies optional secrets resolve empty while required ones fail
+func TestProvider_Optional(t *testing.T) {
+	p := New(WithDir(t.TempDir()), Optional("smtp_password"))
+	if s, err := p.Resolve(context.Background(), "smtp_password"); err != nil || s.Len() != 0 {
+		t.Errorf("Resolve(optional) = %v, %v, want empty", s, err)
+	}
+	if _, err := p.Resolve(context.Background(), "db_password"); !errors.Is(err, ss.ErrSecretNotFound) {
+		t.Errorf("Resolve(required) error = %v, want ErrSecretNotFound", err)
+	}
+	if _, err := New(WithDir(filepath.Join(t.TempDir(), "absent"))).Resolve(context.Background(), "x"); !errors.Is(err, ss.ErrSecretNotFound) {
+		t.Errorf("Resolve() without a secrets directory error = %v, want ErrSecretNotFound", err)
+	}
+}
+
+// TestProvider_HealthCheck verifies a missing secrets directory is reported
+func TestProvider_HealthCheck(t *testing.T) {
+	if err := New(WithDir(t.TempDir())).HealthCheck(context.Background()); err != nil {
+		t.Errorf("HealthCheck() error = %v, want nil", err)
+	}
+	var _ ss.HealthChecker = New()
+	if err := New(WithDir(filepath.Join(t.TempDir(), "absent"))).HealthCheck(context.Background()); err == nil {
+		t.Errorf("HealthCheck() without a secrets directory error = nil")
+	}
+}
@@ -0,0 +1,279 @@
+// Package kubernetes provides a Provider for Kubernetes Secrets, read from
+// secret volumes mounted into the pod and, optionally, from the API server
+// when a secret is not mounted.
+//
+// Names have the form "secret/key". Mounted secrets are looked up as
+// <dir>/<secret>/<key>, so with every secret volume mounted under one
+// directory:
+//
+//	volumeMounts:
+//	- name: db
+//	  mountPath: /var/run/secrets/app/db
+//
+//	ss.RegisterProvider("k8s", kubernetes.New("/var/run/secrets/app", kubernetes.WithInCluster()))
+//	password, err := ss.Resolve(ctx, "k8s:db/password")
+//
+// Files are read afresh on every Resolve. Kubelet rotates a mounted secret
+// by writing a new timestamped directory and atomically swapping the
+// "..data" symlink the key files point through, so each read sees either
+// the old or the new value in full, never a mix. Wrap the provider in a
+// cache if it is resolved on hot paths.
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	ss "github.com/earlye/sensitive-strings/golang/ss"
+)
+
+// serviceAccountDir holds the credentials Kubernetes mounts into every pod.
+var serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// APIConfig describes how to reach the Kubernetes API server.
+type APIConfig struct {
+	// Server is the API server URL, e.g. "https://10.0.0.1:443".
+	Server string
+	// Namespace is the namespace secrets are read from.
+	Namespace string
+	// TokenFile holds the bearer token. It is re-read on every request,
+	// since projected service account tokens are rotated.
+	TokenFile string
+	// Client sends the requests; nil means http.DefaultClient.
+	Client *http.Client
+}
+
+// Provider resolves "secret/key" names from mounted secret volumes, falling
+// back to the API server if configured.
+type Provider struct {
+	dir    string
+	api    *APIConfig
+	apiErr error
+}
+
+// Option configures a Provider.
+type Option func(*Provider)
+
+// WithAPI resolves secrets that are not mounted through the API server
+// described by cfg. The service account needs "get" on the secrets.
+func WithAPI(cfg APIConfig) Option {
+	return func(p *Provider) { p.api = &cfg }
+}
+
+// WithInCluster is WithAPI configured from the pod's service account and
+// environment, like client-go's rest.InClusterConfig. Outside a cluster
+// Resolve reports the configuration error for secrets that are not
+// mounted.
+func WithInCluster() Option {
+	return func(p *Provider) {
+		cfg, err := inClusterConfig()
+		p.api, p.apiErr = cfg, err
+	}
+}
+
+// New returns a Provider reading secrets mounted under dir.
+func New(dir string, opts ...Option) *Provider {
+	p := &Provider{dir: dir}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Resolve implements ss.Provider.
+func (p *Provider) Resolve(ctx context.Context, name string) (*ss.SensitiveString, error) {
+	secret, key, ok := strings.Cut(name, "/")
+	if !ok || secret == "" || key == "" || strings.Contains(key, "/") {
+		return nil, fmt.Errorf("kubernetes: invalid secret name %q, want secret/key", name)
+	}
+	s, err := p.readMounted(secret, key)
+	if !errors.Is(err, fs.ErrNotExist) {
+		return s, err
+	}
+	if p.apiErr != nil {
+		return nil, p.apiErr
+	}
+	if p.api == nil {
+		return nil, fmt.Errorf("%w: %s", ss.ErrSecretNotFound, name)
+	}
+	return p.api.get(ctx, secret, key)
+}
+
+// HealthCheck implements ss.HealthChecker. It reports an error if the
+// mount directory cannot be read or, with an API fallback, if the API
+// configuration is broken or the service account token is rejected. The
+// token is checked with a SelfSubjectAccessReview, which every
+// authenticated account may create, so the check needs no access to any
+// secret and works with Roles limited by resourceNames.
+func (p *Provider) HealthCheck(ctx context.Context) error {
+	if p.dir != "" {
+		root, err := os.OpenRoot(p.dir)
+		if err != nil {
+			return fmt.Errorf("kubernetes: mount directory: %w", err)
+		}
+		root.Close()
+	}
+	if p.apiErr != nil {
+		return p.apiErr
+	}
+	if p.api == nil {
+		return nil
+	}
+	return p.api.reviewAccess(ctx)
+}
+
+// readMounted reads <dir>/<secret>/<key>. Reads go through an os.Root, so
+// names cannot escape dir, while kubelet's symlinks within it resolve.
+func (p *Provider) readMounted(secret, key string) (*ss.SensitiveString, error) {
+	if p.dir == "" {
+		return nil, fs.ErrNotExist
+	}
+	root, err := os.OpenRoot(p.dir)
+	if err != nil {
+		return nil, err
+	}
+	defer root.Close()
+	data, err := root.ReadFile(path.Join(secret, key))
+	if err != nil {
+		return nil, err
+	}
+	defer clear(data)
+	return ss.NewFromBytes(data), nil
+}
+
+// do sends an authenticated request with the given JSON body, if any, to
+// apiPath on the API server.
+func (c *APIConfig) do(ctx context.Context, method, apiPath string, body []byte) (*http.Response, error) {
+	token, err := os.ReadFile(c.TokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes: reading service account token: %w", err)
+	}
+	defer clear(token)
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(c.Server, "/")+apiPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do(req)
+}
+
+// reviewAccess creates a SelfSubjectAccessReview for getting secrets in
+// the namespace. Only the token is checked; the review's verdict is not,
+// since access limited to named secrets is reported as denied.
+func (c *APIConfig) reviewAccess(ctx context.Context) error {
+	review, err := json.Marshal(map[string]any{
+		"apiVersion": "authorization.k8s.io/v1",
+		"kind":       "SelfSubjectAccessReview",
+		"spec": map[string]any{
+			"resourceAttributes": map[string]string{"namespace": c.Namespace, "verb": "get", "resource": "secrets"},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(ctx, http.MethodPost, "/apis/authorization.k8s.io/v1/selfsubjectaccessreviews", review)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("kubernetes: reviewing access: %s", resp.Status)
+	}
+	return nil
+}
+
+// get fetches key of secret from the API server and decodes it.
+func (c *APIConfig) get(ctx context.Context, secret, key string) (*ss.SensitiveString, error) {
+	resp, err := c.do(ctx, http.MethodGet, "/api/v1/namespaces/"+url.PathEscape(c.Namespace)+"/secrets/"+url.PathEscape(secret), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s/%s", ss.ErrSecretNotFound, secret, key)
+	default:
+		return nil, fmt.Errorf("kubernetes: getting secret %s: %s", secret, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	defer clear(body)
+	if err != nil {
+		return nil, err
+	}
+	var object struct {
+		Data map[string]string `json:"data"`
+	}
+	if err := json.Unmarshal(body, &object); err != nil {
+		return nil, fmt.Errorf("kubernetes: decoding secret %s: %w", secret, err)
+	}
+	encoded, ok := object.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s/%s", ss.ErrSecretNotFound, secret, key)
+	}
+	value, err := base64.StdEncoding.DecodeString(encoded)
+	defer clear(value)
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes: secret %s key %s is not valid base64", secret, key)
+	}
+	return ss.NewFromBytes(value), nil
+}
+
+// inClusterConfig builds an APIConfig from the service account mounted
+// into the pod and the KUBERNETES_SERVICE_* environment variables.
+func inClusterConfig() (*APIConfig, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, errors.New("kubernetes: not running in a cluster: KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT must be set")
+	}
+	namespace, err := os.ReadFile(path.Join(serviceAccountDir, "namespace"))
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes: reading namespace: %w", err)
+	}
+	ca, err := os.ReadFile(path.Join(serviceAccountDir, "ca.crt"))
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes: reading cluster CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, errors.New("kubernetes: no certificates in cluster CA")
+	}
+	// A program may have replaced http.DefaultTransport with another
+	// RoundTripper; start from a new transport then.
+	transport := &http.Transport{}
+	if t, ok := http.DefaultTransport.(*http.Transport); ok {
+		transport = t.Clone()
+	}
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	return &APIConfig{
+		Server:    "https://" + net.JoinHostPort(host, port),
+		Namespace: strings.TrimSpace(string(namespace)),
+		TokenFile: path.Join(serviceAccountDir, "token"),
+		Client:    &http.Client{Transport: transport},
+	}, nil
+}
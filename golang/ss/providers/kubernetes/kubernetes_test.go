@@ -0,0 +1,138 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ss "github.com/earlye/sensitive-strings/golang/ss"
+)
+
+// mountSecret lays out a secret volume the way kubelet does: key files
+// link through the "..data" symlink to a timestamped directory.
+func mountSecret(t *testing.T, dir, secret, version string, data map[string]string) {
+	t.Helper()
+	volume := filepath.Join(dir, secret)
+	versioned := filepath.Join(volume, version)
+	if err := os.MkdirAll(versioned, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for key, value := range data {
+		if err := os.WriteFile(filepath.Join(versioned, key), []byte(value), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		link := filepath.Join(volume, key)
+		if _, err := os.Lstat(link); err != nil {
+			if err := os.Symlink(filepath.Join("..data", key), link); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	tmp := filepath.Join(volume, "..data_tmp")
+	if err := os.Symlink(version, tmp); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, filepath.Join(volume, "..data")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestProvider_Mounted verifies mounted keys resolve and follow kubelet's symlink swap
+func TestProvider_Mounted(t *testing.T) {
+	dir := t.TempDir()
+	mountSecret(t, dir, "db", "..2026_10_01", map[string]string{"password": "v1"})
+	p := New(dir)
+	var _ ss.Provider = p
+
+	s, err := p.Resolve(context.Background(), "db/password")
+	if err != nil || s.Value() != "v1" {
+		t.Fatalf("Resolve() = %v, %v, want v1", s, err)
+	}
+	mountSecret(t, dir, "db", "..2026_10_02", map[string]string{"password": "v2"})
+	if s, _ := p.Resolve(context.Background(), "db/password"); s.Value() != "v2" {
+		t.Errorf("Resolve() after rotation = %v, want v2", s.Value())
+	}
+
+	if _, err := p.Resolve(context.Background(), "db/missing"); !errors.Is(err, ss.ErrSecretNotFound) {
+		t.Errorf("Resolve(missing) error = %v, want ErrSecretNotFound", err)
+	}
+	for _, name := range []string{"db", "../etc/passwd", "db/a/b"} {
+		if _, err := p.Resolve(context.Background(), name); err == nil || errors.Is(err, ss.ErrSecretNotFound) {
+			t.Errorf("Resolve(%q) error = %v, want an invalid name error", name, err)
+		}
+	}
+}
+
+// TestProvider_APIFallback verifies unmounted secrets are fetched and base64-decoded
+func TestProvider_APIFallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer sa-token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/api/v1/namespaces/prod/secrets/api" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"kind":"Secret","data":{"token":"c2VjcmV0LXRva2Vu","bad":"!!"}}`))
+	}))
+	defer server.Close()
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	os.WriteFile(tokenFile, []byte("sa-token\n"), 0o600)
+
+	p := New(t.TempDir(), WithAPI(APIConfig{Server: server.URL, Namespace: "prod", TokenFile: tokenFile}))
+	s, err := p.Resolve(context.Background(), "api/token")
+	if err != nil || s.Value() != "secret-token" {
+		t.Fatalf("Resolve() = %v, %v, want secret-token", s, err)
+	}
+	for _, name := range []string{"api/missing", "other/token"} {
+		if _, err := p.Resolve(context.Background(), name); !errors.Is(err, ss.ErrSecretNotFound) {
+			t.Errorf("Resolve(%s) error = %v, want ErrSecretNotFound", name, err)
+		}
+	}
+	if _, err := p.Resolve(context.Background(), "api/bad"); err == nil {
+		t.Errorf("Resolve(invalid base64) error = nil")
+	}
+}
+
+// TestWithInCluster_OutsideCluster verifies the configuration error surfaces for unmounted secrets
+func TestWithInCluster_OutsideCluster(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	p := New(t.TempDir(), WithInCluster())
+	if _, err := p.Resolve(context.Background(), "db/password"); err == nil || errors.Is(err, ss.ErrSecretNotFound) {
+		t.Errorf("Resolve() error = %v, want the in-cluster configuration error", err)
+	}
+}
+
+// TestProvider_HealthCheck verifies the mount directory and API credentials are checked
+func TestProvider_HealthCheck(t *testing.T) {
+	status := http.StatusCreated
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/apis/authorization.k8s.io/v1/selfsubjectaccessreviews" {
+			t.Errorf("health check request = %s %s, want a SelfSubjectAccessReview", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(status)
+		// A Role limited by resourceNames denies access to secrets in general.
+		w.Write([]byte(`{"kind":"SelfSubjectAccessReview","status":{"allowed":false}}`))
+	}))
+	defer server.Close()
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	os.WriteFile(tokenFile, []byte("sa-token"), 0o600)
+
+	p := New(t.TempDir(), WithAPI(APIConfig{Server: server.URL, Namespace: "prod", TokenFile: tokenFile}))
+	var _ ss.HealthChecker = p
+	if err := p.HealthCheck(context.Background()); err != nil {
+		t.Errorf("HealthCheck() error = %v, want nil", err)
+	}
+	status = http.StatusUnauthorized
+	if err := p.HealthCheck(context.Background()); err == nil {
+		t.Errorf("HealthCheck() with a rejected token error = nil")
+	}
+	if err := New(filepath.Join(t.TempDir(), "absent")).HealthCheck(context.Background()); err == nil {
+		t.Errorf("HealthCheck() without a mount directory error = nil")
+	}
+}
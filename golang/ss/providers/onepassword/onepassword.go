@@ -0,0 +1,232 @@
+// Package onepassword provides Providers resolving 1Password secret
+// references ("op://vault/item/field" or "op://vault/item/section/field")
+// either through the 1Password CLI, for developer workstations and CI, or
+// through a 1Password Connect server, for production:
+//
+//	ss.RegisterProvider("op", onepassword.NewCLI())
+//	password, err := ss.Resolve(ctx, "op:prod/db/password")
+//
+// Names may be given with or without the "op://" prefix.
+package onepassword
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strings"
+
+	ss "github.com/earlye/sensitive-strings/golang/ss"
+)
+
+// reference is a parsed secret reference.
+type reference struct {
+	vault, item, section, field string
+}
+
+// parseReference parses name, with or without the "op://" prefix.
+func parseReference(name string) (reference, error) {
+	parts := strings.Split(strings.TrimPrefix(name, "op://"), "/")
+	for _, part := range parts {
+		if part == "" {
+			parts = nil
+		}
+	}
+	switch len(parts) {
+	case 3:
+		return reference{vault: parts[0], item: parts[1], field: parts[2]}, nil
+	case 4:
+		return reference{vault: parts[0], item: parts[1], section: parts[2], field: parts[3]}, nil
+	}
+	return reference{}, fmt.Errorf("onepassword: invalid secret reference %q, want op://vault/item/[section/]field", name)
+}
+
+func (r reference) String() string {
+	if r.section != "" {
+		return "op://" + r.vault + "/" + r.item + "/" + r.section + "/" + r.field
+	}
+	return "op://" + r.vault + "/" + r.item + "/" + r.field
+}
+
+// CLI resolves references with "op read". The CLI must be signed in, e.g.
+// through the desktop app integration or OP_SERVICE_ACCOUNT_TOKEN.
+type CLI struct {
+	command string
+}
+
+// CLIOption configures a CLI provider.
+type CLIOption func(*CLI)
+
+// WithCommand runs command instead of the op binary found on PATH.
+func WithCommand(command string) CLIOption {
+	return func(c *CLI) { c.command = command }
+}
+
+// NewCLI returns a CLI provider.
+func NewCLI(opts ...CLIOption) *CLI {
+	c := &CLI{command: "op"}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Resolve implements ss.Provider.
+func (c *CLI) Resolve(ctx context.Context, name string) (*ss.SensitiveString, error) {
+	ref, err := parseReference(name)
+	if err != nil {
+		return nil, err
+	}
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, c.command, "read", "--no-newline", ref.String())
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	defer clear(out)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		msg := strings.TrimSpace(stderr.String())
+		if strings.Contains(msg, "isn't a") || strings.Contains(msg, "could not find") {
+			return nil, fmt.Errorf("%w: %s", ss.ErrSecretNotFound, ref)
+		}
+		return nil, fmt.Errorf("onepassword: op read %s: %w: %s", ref, err, msg)
+	}
+	return ss.NewFromBytes(out), nil
+}
+
+// Connect resolves references through the REST API of a 1Password Connect
+// server. Vaults and items may be given by name or ID; fields by label or
+// ID.
+type Connect struct {
+	host   string
+	token  *ss.SensitiveString
+	client *http.Client
+}
+
+// NewConnect returns a Connect provider for the server at host, e.g.
+// "http://op-connect:8080", authenticating with token. client may be nil
+// for http.DefaultClient.
+func NewConnect(host string, token *ss.SensitiveString, client *http.Client) *Connect {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Connect{host: strings.TrimSuffix(host, "/"), token: token, client: client}
+}
+
+// connectField is a field of a Connect item.
+type connectField struct {
+	ID      string `json:"id"`
+	Label   string `json:"label"`
+	Value   string `json:"value"`
+	Section *struct {
+		ID string `json:"id"`
+	} `json:"section"`
+}
+
+// connectItem is a Connect item with its fields.
+type connectItem struct {
+	Fields   []connectField `json:"fields"`
+	Sections []struct {
+		ID    string `json:"id"`
+		Label string `json:"label"`
+	} `json:"sections"`
+}
+
+// Resolve implements ss.Provider.
+func (c *Connect) Resolve(ctx context.Context, name string) (*ss.SensitiveString, error) {
+	ref, err := parseReference(name)
+	if err != nil {
+		return nil, err
+	}
+	vaultID, err := c.lookupID(ctx, "/v1/vaults", "name", ref.vault)
+	if err != nil {
+		return nil, err
+	}
+	itemPath := "/v1/vaults/" + url.PathEscape(vaultID) + "/items"
+	itemID, err := c.lookupID(ctx, itemPath, "title", ref.item)
+	if err != nil {
+		return nil, err
+	}
+	var item connectItem
+	if err := c.get(ctx, itemPath+"/"+url.PathEscape(itemID), &item); err != nil {
+		return nil, err
+	}
+	sectionID := ref.section
+	for _, s := range item.Sections {
+		if s.Label == ref.section {
+			sectionID = s.ID
+		}
+	}
+	for _, f := range item.Fields {
+		if f.Label != ref.field && f.ID != ref.field {
+			continue
+		}
+		if ref.section != "" && (f.Section == nil || f.Section.ID != sectionID) {
+			continue
+		}
+		return ss.New(f.Value, ss.WithLabel(ref.String())), nil
+	}
+	return nil, fmt.Errorf("%w: %s", ss.ErrSecretNotFound, ref)
+}
+
+// lookupID returns the ID of the object under collection whose attr is
+// nameOrID, or whose ID is.
+func (c *Connect) lookupID(ctx context.Context, collection, attr, nameOrID string) (string, error) {
+	var matches []struct {
+		ID string `json:"id"`
+	}
+	query := url.Values{"filter": {fmt.Sprintf("%s eq %q", attr, nameOrID)}}
+	if err := c.get(ctx, collection+"?"+query.Encode(), &matches); err != nil {
+		return "", err
+	}
+	switch len(matches) {
+	case 0:
+		// Not found by name; try it as an ID.
+		err := c.get(ctx, collection+"/"+url.PathEscape(nameOrID), nil)
+		if err != nil {
+			return "", err
+		}
+		return nameOrID, nil
+	case 1:
+		return matches[0].ID, nil
+	}
+	return "", fmt.Errorf("onepassword: %d objects named %q under %s", len(matches), nameOrID, collection)
+}
+
+// get fetches path and decodes the JSON response into v, if not nil.
+func (c *Connect) get(ctx context.Context, path string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.host+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token.Value())
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	endpoint, _, _ := strings.Cut(path, "?")
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("%w: %s", ss.ErrSecretNotFound, endpoint)
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("onepassword: GET %s: %s", endpoint, resp.Status)
+	case v == nil:
+		return nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	defer clear(body)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return errors.New("onepassword: malformed response from " + endpoint)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package onepassword
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	ss "github.com/earlye/sensitive-strings/golang/ss"
+)
+
+// TestMain lets the test binary stand in for the op CLI: run with
+// FAKE_OP=1 it answers "op read" for op://dev/db/password.
+func TestMain(m *testing.M) {
+	if os.Getenv("FAKE_OP") == "1" {
+		args := os.Args[1:]
+		if len(args) == 3 && args[0] == "read" && args[1] == "--no-newline" && args[2] == "op://dev/db/password" {
+			fmt.Print("hunter2")
+			os.Exit(0)
+		}
+		fmt.Fprintf(os.Stderr, "[ERROR] %q isn't an item in the vault\n", args[len(args)-1])
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
+
+// TestCLI verifies references resolve through op read
+func TestCLI(t *testing.T) {
+	t.Setenv("FAKE_OP", "1")
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewCLI(WithCommand(exe))
+	var _ ss.Provider = p
+
+	for _, name := range []string{"op://dev/db/password", "dev/db/password"} {
+		if s, err := p.Resolve(context.Background(), name); err != nil || s.Value() != "hunter2" {
+			t.Errorf("Resolve(%s) = %v, %v, want hunter2", name, s, err)
+		}
+	}
+	if _, err := p.Resolve(context.Background(), "dev/db/missing"); !errors.Is(err, ss.ErrSecretNotFound) {
+		t.Errorf("Resolve(missing) error = %v, want ErrSecretNotFound", err)
+	}
+	if _, err := p.Resolve(context.Background(), "dev//password"); err == nil {
+		t.Errorf("Resolve(malformed) error = nil")
+	}
+}
+
+// TestConnect verifies vaults and items are looked up by name and fields by label and section
+func TestConnect(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer connect-token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		filter := r.URL.Query().Get("filter")
+		switch {
+		case r.URL.Path == "/v1/vaults" && filter == `name eq "prod"`:
+			fmt.Fprint(w, `[{"id":"v1"}]`)
+		case r.URL.Path == "/v1/vaults":
+			fmt.Fprint(w, `[]`)
+		case r.URL.Path == "/v1/vaults/v1":
+			fmt.Fprint(w, `{"id":"v1"}`)
+		case r.URL.Path == "/v1/vaults/v1/items" && filter == `title eq "db"`:
+			fmt.Fprint(w, `[{"id":"i1"}]`)
+		case r.URL.Path == "/v1/vaults/v1/items":
+			fmt.Fprint(w, `[]`)
+		case r.URL.Path == "/v1/vaults/v1/items/i1":
+			fmt.Fprint(w, `{"sections":[{"id":"s1","label":"replica"}],"fields":[
+				{"id":"password","label":"password","value":"primary-pw"},
+				{"id":"f2","label":"password","value":"replica-pw","section":{"id":"s1"}}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	p := NewConnect(server.URL, ss.New("connect-token"), nil)
+	var _ ss.Provider = p
+	for name, want := range map[string]string{
+		"op://prod/db/password":         "primary-pw",
+		"op://prod/db/replica/password": "replica-pw",
+		"v1/i1/password":                "primary-pw",
+	} {
+		if s, err := p.Resolve(context.Background(), name); err != nil || s.Value() != want {
+			t.Errorf("Resolve(%s) = %v, %v, want %s", name, s, err, want)
+		}
+	}
+	for _, name := range []string{"op://prod/db/username", "op://prod/cache/password", "op://dev/db/password"} {
+		if _, err := p.Resolve(context.Background(), name); !errors.Is(err, ss.ErrSecretNotFound) {
+			t.Errorf("Resolve(%s) error = %v, want ErrSecretNotFound", name, err)
+		}
+	}
+
+	_, err := NewConnect(server.URL, ss.New("wrong"), nil).Resolve(context.Background(), "prod/db/password")
+	if err == nil || strings.Contains(err.Error(), "wrong") {
+		t.Errorf("Resolve() with a bad token error = %v", err)
+	}
+}
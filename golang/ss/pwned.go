@@ -0,0 +1,53 @@
+package sensitivestring
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// pwnedRangeURL is the HaveIBeenPwned Pwned Passwords range API.
+var pwnedRangeURL = "https://api.pwnedpasswords.com/range/"
+
+// CheckPwned reports whether the secret appears in the HaveIBeenPwned
+// Pwned Passwords corpus of breached passwords, e.g. to reject such a
+// password at signup. Only the first 5 hex characters of its SHA-1 digest
+// are sent (the k-anonymity model); the rest of the digest is matched
+// locally against the returned suffixes, in constant time per entry.
+// Responses are padded so their size does not hint at the prefix.
+func (s *SensitiveString) CheckPwned(ctx context.Context) (bool, error) {
+	var sum [sha1.Size]byte
+	s.Use(func(secret []byte) { sum = sha1.Sum(secret) })
+	digest := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := digest[:5], digest[5:]
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pwnedRangeURL+prefix, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Add-Padding", "true")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("sensitivestring: pwned passwords API returned %s", resp.Status)
+	}
+
+	found := false
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		candidate, count, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		// Padding entries have a count of 0.
+		if subtle.ConstantTimeCompare([]byte(candidate), []byte(suffix)) == 1 && count != "0" {
+			found = true
+		}
+	}
+	return found, scanner.Err()
+}
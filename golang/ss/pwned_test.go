@@ -0,0 +1,60 @@
+package sensitivestring
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestCheckPwned verifies only the digest prefix is sent and suffixes are matched locally
+func TestCheckPwned(t *testing.T) {
+	// SHA-1 of "password" is 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8.
+	var requested string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Path
+		if r.Header.Get("Add-Padding") != "true" {
+			t.Errorf("Add-Padding header = %q, want true", r.Header.Get("Add-Padding"))
+		}
+		fmt.Fprint(w, "003D68EB55068C33ACE09247EE4C639306B:3\r\n")
+		fmt.Fprint(w, "1E4C9B93F3F0682250B6CF8331B7EE68FD8:9545824\r\n")
+		fmt.Fprint(w, "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF:0\r\n")
+	}))
+	defer server.Close()
+	previous := pwnedRangeURL
+	pwnedRangeURL = server.URL + "/range/"
+	t.Cleanup(func() { pwnedRangeURL = previous })
+
+	pwned, err := New("password").CheckPwned(context.Background())
+	if err != nil || !pwned {
+		t.Errorf("CheckPwned(password) = %v, %v, want true", pwned, err)
+	}
+	if requested != "/range/5BAA6" {
+		t.Errorf("requested path = %v, want /range/5BAA6", requested)
+	}
+	if pwned, err := New("correct horse battery staple 42").CheckPwned(context.Background()); err != nil || pwned {
+		t.Errorf("CheckPwned(unbreached) = %v, %v, want false", pwned, err)
+	}
+}
+
+// TestCheckPwned_PaddingAndErrors verifies padding entries never match and API errors surface
+func TestCheckPwned_PaddingAndErrors(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		fmt.Fprint(w, "1E4C9B93F3F0682250B6CF8331B7EE68FD8:0\r\n")
+	}))
+	defer server.Close()
+	previous := pwnedRangeURL
+	pwnedRangeURL = server.URL + "/range/"
+	t.Cleanup(func() { pwnedRangeURL = previous })
+
+	if pwned, err := New("password").CheckPwned(context.Background()); err != nil || pwned {
+		t.Errorf("CheckPwned() with a padding entry = %v, %v, want false", pwned, err)
+	}
+	status = http.StatusTooManyRequests
+	if _, err := New("password").CheckPwned(context.Background()); err == nil {
+		t.Errorf("CheckPwned() on 429 error = nil")
+	}
+}
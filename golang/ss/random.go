@@ -0,0 +1,91 @@
+package sensitivestring
+
+import (
+	"crypto/rand"
+	"unicode/utf8"
+)
+
+// Character sets for NewRandom.
+const (
+	CharsetAlphanumeric = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+	CharsetHex          = "0123456789abcdef"
+	CharsetBase64URL    = CharsetAlphanumeric + "-_"
+	CharsetPrintable    = CharsetAlphanumeric + "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+)
+
+// CharsetOption configures NewRandom.
+type CharsetOption func(*randomOptions)
+
+type randomOptions struct {
+	charset string
+	label   string
+}
+
+// WithCharset draws characters from charset, which must be non-empty ASCII.
+// The default is CharsetAlphanumeric.
+func WithCharset(charset string) CharsetOption {
+	return func(o *randomOptions) { o.charset = charset }
+}
+
+// WithRandomLabel labels the generated secret. See NewLabeled.
+func WithRandomLabel(label string) CharsetOption {
+	return func(o *randomOptions) { o.label = label }
+}
+
+// NewRandom generates a secret of length characters drawn uniformly from
+// the character set using crypto/rand, e.g. for API keys, passwords and
+// tokens. The characters are written straight into the secret's storage,
+// so the generated value never exists as a plain string in calling code.
+// It panics if length is negative or the character set is empty or not
+// ASCII.
+func NewRandom(length int, opts ...CharsetOption) *SensitiveString {
+	options := randomOptions{charset: CharsetAlphanumeric}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	charset := options.charset
+	if length < 0 {
+		panic("NewRandom: negative length")
+	}
+	if charset == "" || !isASCII(charset) {
+		panic("NewRandom: charset must be non-empty ASCII")
+	}
+
+	// Reject random bytes at or above the largest multiple of the charset
+	// size, so every character is equally likely.
+	limit := 256 - 256%len(charset)
+	buf := make([]byte, length)
+	random := make([]byte, 64)
+	defer clear(random)
+	for i := 0; i < length; {
+		rand.Read(random)
+		for _, r := range random {
+			if int(r) < limit && i < length {
+				buf[i] = charset[int(r)%len(charset)]
+				i++
+			}
+		}
+	}
+	return &SensitiveString{label: options.label, store: &byteStorage{buf: buf}}
+}
+
+// NewRandomBytes generates n bytes of key material with crypto/rand
+// straight into a SensitiveBytes. It panics if n is negative.
+func NewRandomBytes(n int) *SensitiveBytes {
+	if n < 0 {
+		panic("NewRandomBytes: negative length")
+	}
+	buf := make([]byte, n)
+	rand.Read(buf)
+	return &SensitiveBytes{s: &SensitiveString{store: &byteStorage{buf: buf}}}
+}
+
+// isASCII reports whether s contains only ASCII characters.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
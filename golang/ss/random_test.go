@@ -0,0 +1,59 @@
+package sensitivestring
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestNewRandom verifies length, charset and uniqueness of generated secrets
+func TestNewRandom(t *testing.T) {
+	a, b := NewRandom(32), NewRandom(32)
+	if a.Len() != 32 || a.Equal(b) {
+		t.Errorf("NewRandom(32) = lengths %d/%d, equal %v", a.Len(), b.Len(), a.Equal(b))
+	}
+	for _, c := range a.Value() {
+		if !strings.ContainsRune(CharsetAlphanumeric, c) {
+			t.Errorf("NewRandom() produced %q outside the default charset", c)
+		}
+	}
+
+	hex := NewRandom(1000, WithCharset(CharsetHex), WithRandomLabel("token"))
+	if hex.Label() != "token" || strings.Trim(hex.Value(), CharsetHex) != "" {
+		t.Errorf("NewRandom(WithCharset(hex)) label %q, value outside charset", hex.Label())
+	}
+	for _, c := range CharsetHex {
+		if !strings.ContainsRune(hex.Value(), c) {
+			t.Errorf("1000 hex characters never contained %q", c)
+		}
+	}
+	if got := NewRandom(0).Len(); got != 0 {
+		t.Errorf("NewRandom(0).Len() = %d, want 0", got)
+	}
+}
+
+// TestNewRandom_Invalid verifies bad arguments panic
+func TestNewRandom_Invalid(t *testing.T) {
+	for name, fn := range map[string]func(){
+		"negative length":   func() { NewRandom(-1) },
+		"empty charset":     func() { NewRandom(8, WithCharset("")) },
+		"non-ASCII charset": func() { NewRandom(8, WithCharset("äöü")) },
+		"negative bytes":    func() { NewRandomBytes(-1) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: did not panic", name)
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
+// TestNewRandomBytes verifies random key material is generated
+func TestNewRandomBytes(t *testing.T) {
+	a, b := NewRandomBytes(32), NewRandomBytes(32)
+	if a.Len() != 32 || a.Equal(b) {
+		t.Errorf("NewRandomBytes(32) = lengths %d/%d, equal %v", a.Len(), b.Len(), a.Equal(b))
+	}
+}
@@ -0,0 +1,110 @@
+package sensitivestring
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// ErrAlreadyRead is returned by ReadOnce.Value after the first read.
+var ErrAlreadyRead = errors.New("sensitivestring: read-once secret has already been read")
+
+// ReadOnce is a secret whose plaintext can be read exactly once, for
+// bootstrap tokens and one-time passwords where a second read indicates a
+// bug or misuse. The first Value call returns the plaintext and wipes the
+// secret (see Zero); every later call fails with ErrAlreadyRead. The
+// redacted renderings keep showing the secret's fingerprint. ReadOnce is
+// safe for concurrent use; only one goroutine ever gets the plaintext.
+type ReadOnce struct {
+	mu     sync.Mutex
+	secret *SensitiveString
+
+	// read is set by the first Value call, which also records the
+	// renderings of the secret so that they survive the wipe.
+	read      bool
+	str       string
+	marshaled interface{}
+}
+
+// NewReadOnce creates a new ReadOnce holding value.
+func NewReadOnce(value string, opts ...Option) *ReadOnce {
+	return &ReadOnce{secret: New(value, opts...)}
+}
+
+// Value returns the plaintext the first time it is called and wipes the
+// secret. Every later call returns "" and ErrAlreadyRead.
+func (r *ReadOnce) Value() (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.read {
+		return "", ErrAlreadyRead
+	}
+	value := r.secret.Value()
+	r.read = true
+	r.str = r.secret.String()
+	r.marshaled = r.secret.marshalValue()
+	r.secret.Zero()
+	return value, nil
+}
+
+// Consumed reports whether Value has been called.
+func (r *ReadOnce) Consumed() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.read
+}
+
+// Label returns the label attached to the secret, if any.
+func (r *ReadOnce) Label() string {
+	return r.secret.Label()
+}
+
+// String returns the redacted form, implementing fmt.Stringer.
+func (r *ReadOnce) String() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.read {
+		return r.str
+	}
+	return r.secret.String()
+}
+
+// GoString returns the redacted form for %#v formatting.
+func (r *ReadOnce) GoString() string {
+	return fmt.Sprintf("sensitivestring.ReadOnce{value:%q}", r.String())
+}
+
+// Format implements fmt.Formatter like SensitiveString.Format.
+func (r *ReadOnce) Format(f fmt.State, verb rune) {
+	formatRedacted(f, verb, r.String(), r.GoString)
+}
+
+// MarshalJSON implements json.Marshaler like SensitiveString.MarshalJSON.
+func (r *ReadOnce) MarshalJSON() ([]byte, error) {
+	recordRedaction(FormatJSON, r.secret.label, 1)
+	return json.Marshal(r.marshalValue())
+}
+
+// MarshalYAML implements yaml.Marshaler like SensitiveString.MarshalYAML.
+func (r *ReadOnce) MarshalYAML() (interface{}, error) {
+	recordRedaction(FormatYAML, r.secret.label, 1)
+	return r.marshalValue(), nil
+}
+
+// LogValue implements slog.LogValuer, returning the redacted form.
+func (r *ReadOnce) LogValue() slog.Value {
+	recordRedaction(FormatSlog, r.secret.label, 1)
+	return slog.StringValue(r.String())
+}
+
+// marshalValue returns the value MarshalJSON and MarshalYAML should encode.
+func (r *ReadOnce) marshalValue() interface{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.read {
+		return r.marshaled
+	}
+	return r.secret.marshalValue()
+}
@@ -0,0 +1,61 @@
+package sensitivestring
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// TestReadOnce verifies the plaintext is returned exactly once and renderings stay stable
+func TestReadOnce(t *testing.T) {
+	r := NewReadOnce("foo", WithLabel("bootstrap"))
+	before, _ := json.Marshal(r)
+	if r.Consumed() {
+		t.Errorf("Consumed() = true before reading")
+	}
+
+	value, err := r.Value()
+	if value != "foo" || err != nil {
+		t.Fatalf("first Value() = %q, %v, want foo, nil", value, err)
+	}
+	if value, err := r.Value(); value != "" || !errors.Is(err, ErrAlreadyRead) {
+		t.Errorf("second Value() = %q, %v, want ErrAlreadyRead", value, err)
+	}
+	if !r.Consumed() || r.Label() != "bootstrap" {
+		t.Errorf("Consumed() = %v, Label() = %q", r.Consumed(), r.Label())
+	}
+
+	if got := r.String(); got != "sha256:"+fooHashHex {
+		t.Errorf("String() after read = %v, want the original fingerprint", got)
+	}
+	if got := fmt.Sprintf("%d", r); got != "sha256:"+fooHashHex {
+		t.Errorf("Sprintf(%%d) after read = %v", got)
+	}
+	after, _ := json.Marshal(r)
+	if string(after) != string(before) {
+		t.Errorf("json.Marshal() after read = %s, want %s", after, before)
+	}
+}
+
+// TestReadOnce_Concurrent verifies only one goroutine gets the plaintext
+func TestReadOnce_Concurrent(t *testing.T) {
+	r := NewReadOnce("otp")
+	var successes atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := r.Value(); err == nil {
+				successes.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := successes.Load(); got != 1 {
+		t.Errorf("successful reads = %d, want 1", got)
+	}
+}
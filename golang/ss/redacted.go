@@ -0,0 +1,88 @@
+package sensitivestring
+
+// MarshalMode selects how a SensitiveString is rendered by MarshalJSON and
+// MarshalYAML.
+type MarshalMode int
+
+const (
+	// MarshalHash renders the value as a bare "sha256:<hex>" string. This is
+	// the default.
+	MarshalHash MarshalMode = iota
+
+	// MarshalStructured renders the value as a Redacted object, e.g.
+	// {"alg":"sha256","hash":"…","len":12,"label":"db-password"}, so that
+	// downstream log processors can recognize redacted fields
+	// programmatically.
+	MarshalStructured
+)
+
+// SetMarshalMode sets the package-wide MarshalMode used by MarshalJSON and
+// MarshalYAML. It is safe to call while other goroutines are marshaling.
+func SetMarshalMode(mode MarshalMode) {
+	updateConfig(func(c *config) { c.marshalMode = mode })
+}
+
+// GetMarshalMode returns the package-wide MarshalMode.
+func GetMarshalMode() MarshalMode {
+	return loadConfig().marshalMode
+}
+
+// Redacted is the structured representation of a SensitiveString emitted
+// when the MarshalStructured mode is enabled. Len is zero, and omitted from
+// output, for empty values and when lengths are hidden (see Config.HideLen).
+type Redacted struct {
+	Alg   string `json:"alg" yaml:"alg"`
+	Hash  string `json:"hash" yaml:"hash"`
+	Len   int    `json:"len,omitempty" yaml:"len,omitempty"`
+	Label string `json:"label,omitempty" yaml:"label,omitempty"`
+}
+
+// NewLabeled creates a new SensitiveString carrying a label. The label is
+// not secret; it is included in the structured representation so that
+// redacted fields can be identified without exposing the value.
+func NewLabeled(label string, value string) *SensitiveString {
+	return New(value, WithLabel(label))
+}
+
+// Label returns the label attached to the SensitiveString, if any.
+func (s *SensitiveString) Label() string {
+	if s == nil {
+		return ""
+	}
+	return s.label
+}
+
+// Redacted returns the structured representation of the value.
+// Uses a value receiver so it is callable on both value and pointer types.
+func (s SensitiveString) Redacted() Redacted {
+	alg, hash := s.hashHex()
+	r := Redacted{Alg: alg, Hash: hash, Label: s.label}
+	if !loadConfig().hideLen {
+		r.Len = s.size()
+	}
+	return r
+}
+
+// RedactedForm returns the Redacted form of s for structured output, or
+// false while a Redactor is in effect, since the hash it carries is what
+// the Redactor exists to hide; s must then render as String. MarshalJSON,
+// MarshalYAML and LogValue apply it in MarshalStructured mode, and
+// adapters for other encoders and loggers should do the same.
+// Uses a value receiver so it is callable on both value and pointer types.
+func (s SensitiveString) RedactedForm() (Redacted, bool) {
+	if s.activeRedactor() != nil {
+		return Redacted{}, false
+	}
+	return s.Redacted(), true
+}
+
+// marshalValue returns the value MarshalJSON and MarshalYAML should encode
+// for the current MarshalMode and Redactor.
+func (s SensitiveString) marshalValue() interface{} {
+	if loadConfig().marshalMode == MarshalStructured {
+		if r, ok := s.RedactedForm(); ok {
+			return r
+		}
+	}
+	return s.String()
+}
@@ -0,0 +1,103 @@
+package sensitivestring
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const fooHashHex = "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+
+// withMarshalMode sets the package-wide MarshalMode for the duration of a test.
+func withMarshalMode(t *testing.T, mode MarshalMode) {
+	t.Helper()
+	previous := GetMarshalMode()
+	SetMarshalMode(mode)
+	t.Cleanup(func() { SetMarshalMode(previous) })
+}
+
+// TestRedacted_Fields verifies the structured representation contents
+func TestRedacted_Fields(t *testing.T) {
+	r := NewLabeled("db-password", "foo").Redacted()
+	expected := Redacted{Alg: "sha256", Hash: fooHashHex, Len: 3, Label: "db-password"}
+	if r != expected {
+		t.Errorf("Redacted() = %+v, want %+v", r, expected)
+	}
+}
+
+// TestMarshalStructured_JSON verifies JSON output in MarshalStructured mode
+func TestMarshalStructured_JSON(t *testing.T) {
+	withMarshalMode(t, MarshalStructured)
+
+	jsonBytes, err := json.Marshal(map[string]interface{}{
+		"password": NewLabeled("db-password", "foo"),
+	})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	expected := `{"password":{"alg":"sha256","hash":"` + fooHashHex + `","len":3,"label":"db-password"}}`
+	if got := string(jsonBytes); got != expected {
+		t.Errorf("json.Marshal() = %v, want %v", got, expected)
+	}
+}
+
+// TestMarshalStructured_NoLabel verifies the label is omitted when unset
+func TestMarshalStructured_NoLabel(t *testing.T) {
+	withMarshalMode(t, MarshalStructured)
+
+	jsonBytes, err := json.Marshal(New("foo"))
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if strings.Contains(string(jsonBytes), "label") {
+		t.Errorf("json.Marshal() should omit empty label, got: %s", jsonBytes)
+	}
+}
+
+// TestMarshalStructured_YAML verifies YAML output in MarshalStructured mode
+func TestMarshalStructured_YAML(t *testing.T) {
+	withMarshalMode(t, MarshalStructured)
+
+	type Config struct {
+		Password *SensitiveString `yaml:"password"`
+	}
+
+	yamlBytes, err := yaml.Marshal(Config{Password: NewLabeled("db-password", "foo")})
+	if err != nil {
+		t.Fatalf("yaml.Marshal() error = %v", err)
+	}
+
+	yamlStr := string(yamlBytes)
+	for _, want := range []string{"alg: sha256", "hash: " + fooHashHex, "len: 3", "label: db-password"} {
+		if !strings.Contains(yamlStr, want) {
+			t.Errorf("YAML output should contain %q, got:\n%s", want, yamlStr)
+		}
+	}
+	if strings.Contains(yamlStr, "foo\n") {
+		t.Errorf("YAML output leaked raw value:\n%s", yamlStr)
+	}
+}
+
+// TestMarshalStructured_RoundTrip verifies structured JSON can be unmarshaled
+func TestMarshalStructured_RoundTrip(t *testing.T) {
+	withMarshalMode(t, MarshalStructured)
+
+	jsonBytes, err := json.Marshal(NewLabeled("db-password", "foo"))
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	var ss SensitiveString
+	if err := json.Unmarshal(jsonBytes, &ss); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if got := ss.Value(); got != "sha256:"+fooHashHex {
+		t.Errorf("Value() = %v, want %v", got, "sha256:"+fooHashHex)
+	}
+	if got := ss.Label(); got != "db-password" {
+		t.Errorf("Label() = %v, want db-password", got)
+	}
+}
@@ -0,0 +1,76 @@
+package sensitivestring
+
+// Redactor renders a secret's plaintext in a form that is safe to emit.
+// Implementations must not return the plaintext or anything it can be
+// recovered from.
+type Redactor interface {
+	Redact(value string) string
+}
+
+// RedactorFunc adapts a function to the Redactor interface.
+type RedactorFunc func(value string) string
+
+// Redact calls f(value).
+func (f RedactorFunc) Redact(value string) string {
+	return f(value)
+}
+
+// SetRedactor installs r as the package-wide Redactor used by String(),
+// MarshalJSON, MarshalYAML and LogValue, in place of the sha256 form. A
+// custom Redactor replaces the hash in every MarshalMode. A nil r restores
+// the default. Instances with their own Redactor (see WithRedactor) are
+// unaffected.
+func SetRedactor(r Redactor) {
+	updateConfig(func(c *config) { c.redactor = r })
+}
+
+// GetRedactor returns the package-wide Redactor, or nil for the default.
+func GetRedactor() Redactor {
+	return loadConfig().redactor
+}
+
+// WithRedactor returns a copy of s that is rendered with r instead of the
+// package-wide Redactor. A nil r makes the copy follow the package-wide
+// setting again.
+func (s *SensitiveString) WithRedactor(r Redactor) *SensitiveString {
+	if s == nil {
+		s = New("")
+	}
+	c := *s
+	c.redactor = r
+	return &c
+}
+
+// activeRedactor returns the Redactor for s, or nil for the default hash
+// form.
+func (s SensitiveString) activeRedactor() Redactor {
+	if s.redactor != nil {
+		return s.redactor
+	}
+	return loadConfig().redactor
+}
+
+// DefaultPlaceholder is the text rendered by Placeholder("").
+const DefaultPlaceholder = "[REDACTED]"
+
+// Placeholder returns a Redactor that renders every value as the constant
+// text, or DefaultPlaceholder if text is empty. Unlike the hash form, a
+// placeholder gives nothing away, so weak passwords cannot be recovered by
+// dictionary attacks on logs; in exchange, occurrences of the same secret
+// can no longer be correlated.
+//
+// To apply it globally use SetRedactor(Placeholder("")); for one value use
+// WithRedactor.
+func Placeholder(text string) Redactor {
+	if text == "" {
+		text = DefaultPlaceholder
+	}
+	return placeholder(text)
+}
+
+// placeholder is the Redactor returned by Placeholder.
+type placeholder string
+
+func (p placeholder) Redact(string) string {
+	return string(p)
+}
@@ -0,0 +1,99 @@
+package sensitivestring
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// withRedactor installs a package-wide Redactor for the duration of a test
+func withRedactor(t *testing.T, r Redactor) {
+	t.Helper()
+	previous := GetRedactor()
+	SetRedactor(r)
+	t.Cleanup(func() { SetRedactor(previous) })
+}
+
+var lengthRedactor = RedactorFunc(func(value string) string {
+	return strings.Repeat("x", len(value))
+})
+
+// TestSetRedactor verifies the global Redactor drives every output
+func TestSetRedactor(t *testing.T) {
+	withRedactor(t, lengthRedactor)
+	secret := New("hunter2")
+
+	if got := secret.String(); got != "xxxxxxx" {
+		t.Errorf("String() = %v, want xxxxxxx", got)
+	}
+	if got, _ := json.Marshal(secret); string(got) != `"xxxxxxx"` {
+		t.Errorf("json.Marshal() = %s, want \"xxxxxxx\"", got)
+	}
+	if got, _ := yaml.Marshal(secret); string(got) != "xxxxxxx\n" {
+		t.Errorf("yaml.Marshal() = %q, want xxxxxxx", got)
+	}
+	if got := newScrubber([]*SensitiveString{secret}).scrub("pw=hunter2"); got != "pw=xxxxxxx" {
+		t.Errorf("scrub() = %v, want pw=xxxxxxx", got)
+	}
+
+	withMarshalMode(t, MarshalStructured)
+	if got, _ := json.Marshal(secret); string(got) != `"xxxxxxx"` {
+		t.Errorf("json.Marshal(structured) = %s, want the Redactor output", got)
+	}
+	if got := secret.LogValue().String(); got != "xxxxxxx" {
+		t.Errorf("LogValue() = %v, want the Redactor output", got)
+	}
+
+	SetRedactor(nil)
+	if got := secret.String(); got != "sha256:"+secret.Redacted().Hash {
+		t.Errorf("String() after SetRedactor(nil) = %v, want the hash form", got)
+	}
+}
+
+// TestWithRedactor verifies a per-instance Redactor overrides the global one
+func TestWithRedactor(t *testing.T) {
+	withRedactor(t, lengthRedactor)
+	original := NewLabeled("db", "hunter2")
+	custom := original.WithRedactor(RedactorFunc(func(string) string { return "<db>" }))
+
+	if got := custom.String(); got != "<db>" {
+		t.Errorf("String() = %v, want <db>", got)
+	}
+	if custom.Value() != "hunter2" || custom.Label() != "db" {
+		t.Errorf("WithRedactor() = %v/%v, want hunter2/db", custom.Value(), custom.Label())
+	}
+	if got := original.String(); got != "xxxxxxx" {
+		t.Errorf("original String() = %v, want unchanged xxxxxxx", got)
+	}
+	if got := custom.WithRedactor(nil).String(); got != "xxxxxxx" {
+		t.Errorf("WithRedactor(nil).String() = %v, want the global Redactor", got)
+	}
+}
+
+// TestPlaceholder verifies the constant placeholder replaces the hash everywhere
+func TestPlaceholder(t *testing.T) {
+	secret := NewLabeled("db", "hunter2")
+	placeholderOnly := secret.WithRedactor(Placeholder(""))
+	if got := placeholderOnly.String(); got != "[REDACTED]" {
+		t.Errorf("WithRedactor(Placeholder).String() = %v, want [REDACTED]", got)
+	}
+	if got := secret.String(); got == "[REDACTED]" {
+		t.Errorf("String() of the original = %v, want the hash form", got)
+	}
+
+	withRedactor(t, Placeholder("(hidden)"))
+	type creds struct {
+		Password *SensitiveString `json:"password" yaml:"password"`
+	}
+	if got, _ := json.Marshal(creds{secret}); string(got) != `{"password":"(hidden)"}` {
+		t.Errorf("json.Marshal() = %s, want the placeholder", got)
+	}
+	if got, _ := yaml.Marshal(creds{secret}); string(got) != "password: (hidden)\n" {
+		t.Errorf("yaml.Marshal() = %q, want the placeholder", got)
+	}
+	if strings.Contains(secret.GoString(), "sha256") {
+		t.Errorf("GoString() = %v, want no hash", secret.GoString())
+	}
+}
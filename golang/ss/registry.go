@@ -0,0 +1,213 @@
+package sensitivestring
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"weak"
+)
+
+// Registry tracks secrets whose plaintext must never appear in output, so
+// that scrubbers, log hooks and error formatters can remove them even after
+// the plaintext has been extracted with Value() and passed around as a plain
+// string.
+//
+// Secrets are matched by the value they held when the registry last changed.
+// Secrets added with Register stay until Unregister is called; secrets added
+// with RegisterWeak are also dropped once they are garbage collected. The
+// zero value is not usable; create registries with NewRegistry.
+//
+// A Registry is read on every scrub, so lookups never take a lock: each
+// change builds a new immutable scrubber and publishes it with a single
+// atomic store (copy-on-write). Changes are serialized among themselves but
+// never block readers.
+type Registry struct {
+	mu       sync.Mutex
+	secrets  map[*SensitiveString]struct{}
+	weak     map[weak.Pointer[SensitiveString]]struct{}
+	scrubber atomic.Pointer[scrubber]
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		secrets: make(map[*SensitiveString]struct{}),
+		weak:    make(map[weak.Pointer[SensitiveString]]struct{}),
+	}
+}
+
+// Register adds secrets to the registry. nil secrets are ignored.
+func (r *Registry) Register(secrets ...*SensitiveString) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, ss := range secrets {
+		if ss != nil {
+			r.secrets[ss] = struct{}{}
+		}
+	}
+	r.rebuild()
+}
+
+// RegisterWeak adds secrets to the registry without keeping them alive:
+// each is dropped automatically once it is garbage collected, so
+// short-lived secrets need no explicit Unregister. nil secrets are ignored.
+func (r *Registry) RegisterWeak(secrets ...*SensitiveString) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, ss := range secrets {
+		if ss == nil {
+			continue
+		}
+		wp := weak.Make(ss)
+		if _, exists := r.weak[wp]; exists {
+			continue
+		}
+		r.weak[wp] = struct{}{}
+		runtime.AddCleanup(ss, r.dropWeak, wp)
+	}
+	r.rebuild()
+}
+
+// dropWeak removes a collected weakly registered secret.
+func (r *Registry) dropWeak(wp weak.Pointer[SensitiveString]) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.weak[wp]; exists {
+		delete(r.weak, wp)
+		r.rebuild()
+	}
+}
+
+// Unregister removes secrets from the registry, however they were
+// registered.
+func (r *Registry) Unregister(secrets ...*SensitiveString) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, ss := range secrets {
+		delete(r.secrets, ss)
+		delete(r.weak, weak.Make(ss))
+	}
+	r.rebuild()
+}
+
+// Len returns the number of registered secrets that are still alive.
+func (r *Registry) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.live())
+}
+
+// Secrets returns the registered secrets that are still alive, in no
+// particular order, so that integrations can look up every secret known
+// to the process.
+func (r *Registry) Secrets() []*SensitiveString {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.live()
+}
+
+// live returns the strongly registered secrets and the weakly registered
+// ones not yet collected. Callers must hold r.mu.
+func (r *Registry) live() []*SensitiveString {
+	secrets := make([]*SensitiveString, 0, len(r.secrets)+len(r.weak))
+	for ss := range r.secrets {
+		secrets = append(secrets, ss)
+	}
+	for wp := range r.weak {
+		if ss := wp.Value(); ss != nil {
+			if _, strong := r.secrets[ss]; !strong {
+				secrets = append(secrets, ss)
+			}
+		}
+	}
+	return secrets
+}
+
+// Scrub returns s with the plaintext of every registered secret replaced by
+// its String form under the current configuration.
+func (r *Registry) Scrub(s string) string {
+	return r.snapshot().scrub(s)
+}
+
+// ScrubBytes is the []byte equivalent of Scrub. b is returned unchanged when
+// it cannot contain a registered secret.
+func (r *Registry) ScrubBytes(b []byte) []byte {
+	return r.snapshot().scrubBytes(b)
+}
+
+// ScrubValue returns value with registered secrets removed, for log
+// integrations whose fields hold arbitrary values. Strings, byte slices and
+// Stringers are scrubbed to strings; errors are replaced as by ScrubError;
+// slices, arrays and maps are walked, and become []interface{} or
+// map[string]interface{} only when one of their elements changed. Other
+// values, including SensitiveStrings, are returned as they are.
+func (r *Registry) ScrubValue(value interface{}) interface{} {
+	scrubbed, _ := scrubAny(r.snapshot(), value)
+	return scrubbed
+}
+
+// ScrubError returns err unchanged if its message holds no registered
+// secret. Otherwise it returns an error with the scrubbed message that
+// wraps err, so errors.Is and errors.As still see it.
+func (r *Registry) ScrubError(err error) error {
+	return scrubError(r.snapshot(), err)
+}
+
+// snapshot returns the scrubber for the currently registered secrets,
+// rebuilding it first if the package configuration has changed since.
+func (r *Registry) snapshot() *scrubber {
+	if sc := r.scrubber.Load(); !sc.stale() {
+		return sc
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.scrubber.Load().stale() {
+		r.rebuild()
+	}
+	return r.scrubber.Load()
+}
+
+// rebuild recreates the scrubber from the registered secrets and publishes
+// it. Callers must hold r.mu.
+func (r *Registry) rebuild() {
+	r.scrubber.Store(newScrubber(r.live()))
+}
+
+// DefaultRegistry returns the process-wide Registry used by the
+// package-level Register, Unregister and Scrub functions.
+func DefaultRegistry() *Registry {
+	return loadConfig().registry
+}
+
+// SetDefaultRegistry replaces the process-wide Registry. It is safe to call
+// while other goroutines are scrubbing.
+func SetDefaultRegistry(r *Registry) {
+	updateConfig(func(c *config) { c.registry = r })
+}
+
+// Register adds secrets to the DefaultRegistry.
+func Register(secrets ...*SensitiveString) {
+	DefaultRegistry().Register(secrets...)
+}
+
+// RegisterWeak adds secrets to the DefaultRegistry until they are garbage
+// collected.
+func RegisterWeak(secrets ...*SensitiveString) {
+	DefaultRegistry().RegisterWeak(secrets...)
+}
+
+// RegisteredSecrets returns the live secrets in the DefaultRegistry.
+func RegisteredSecrets() []*SensitiveString {
+	return DefaultRegistry().Secrets()
+}
+
+// Unregister removes secrets from the DefaultRegistry.
+func Unregister(secrets ...*SensitiveString) {
+	DefaultRegistry().Unregister(secrets...)
+}
+
+// Scrub returns s with the plaintext of every secret in the DefaultRegistry
+// replaced by its SHA256 hash form.
+func Scrub(s string) string {
+	return DefaultRegistry().Scrub(s)
+}
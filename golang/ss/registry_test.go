@@ -0,0 +1,224 @@
+package sensitivestring
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestRegistry_RegisterUnregister verifies scrubbing follows registration
+func TestRegistry_RegisterUnregister(t *testing.T) {
+	r := NewRegistry()
+	secret := New("hunter2")
+
+	if got := r.Scrub("pw hunter2"); got != "pw hunter2" {
+		t.Errorf("Scrub() before Register = %v, want unchanged", got)
+	}
+
+	r.Register(secret, nil)
+	if got := r.Len(); got != 1 {
+		t.Errorf("Len() = %v, want 1", got)
+	}
+	if got := r.Scrub("pw hunter2"); got != "pw "+secret.String() {
+		t.Errorf("Scrub() = %v, want %v", got, "pw "+secret.String())
+	}
+	if got := string(r.ScrubBytes([]byte("pw hunter2"))); got != "pw "+secret.String() {
+		t.Errorf("ScrubBytes() = %v, want %v", got, "pw "+secret.String())
+	}
+
+	r.Unregister(secret)
+	if got := r.Scrub("pw hunter2"); got != "pw hunter2" {
+		t.Errorf("Scrub() after Unregister = %v, want unchanged", got)
+	}
+}
+
+// TestRegistry_ConfigChange verifies scrubbing follows configuration changes
+func TestRegistry_ConfigChange(t *testing.T) {
+	r := NewRegistry()
+	r.Register(New("hunter2"))
+	want := "pw " + New("hunter2").String()
+	if got := r.Scrub("pw hunter2"); got != want {
+		t.Fatalf("Scrub() = %v, want the sha256 form", got)
+	}
+
+	withRedactor(t, Placeholder(""))
+	if got := r.Scrub("pw hunter2"); got != "pw [REDACTED]" {
+		t.Errorf("Scrub() after SetRedactor = %v, want the placeholder", got)
+	}
+	SetRedactor(nil)
+	withHashAlgorithm(t, SHA512)
+	if got := r.Scrub("pw hunter2"); !strings.HasPrefix(got, "pw sha512:") {
+		t.Errorf("Scrub() after SetHashAlgorithm = %v, want the sha512 form", got)
+	}
+
+	var buf bytes.Buffer
+	w := ScrubbingWriter(&buf, New("hunter2"))
+	SetHashAlgorithm(SHA256)
+	fmt.Fprint(w, "pw hunter2")
+	w.Close()
+	if got := buf.String(); got != want {
+		t.Errorf("ScrubbingWriter output = %v, want the sha256 form", got)
+	}
+}
+
+// TestRegistry_ScrubValue verifies nested values and errors are scrubbed
+func TestRegistry_ScrubValue(t *testing.T) {
+	r := NewRegistry()
+	secret := New("hunter2")
+	r.Register(secret)
+
+	got := r.ScrubValue(map[string]interface{}{"db": []string{"user", "hunter2"}, "port": 5432})
+	want := map[string]interface{}{"db": []interface{}{"user", secret.String()}, "port": 5432}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("ScrubValue() = %v, want %v", got, want)
+	}
+	clean := []string{"nothing", "here"}
+	if got := r.ScrubValue(clean); &got.([]string)[0] != &clean[0] {
+		t.Errorf("ScrubValue(clean) = %v, want the original slice", got)
+	}
+
+	err := fmt.Errorf("login hunter2: %w", context.Canceled)
+	scrubbed := r.ScrubError(err)
+	if strings.Contains(scrubbed.Error(), "hunter2") || !errors.Is(scrubbed, context.Canceled) {
+		t.Errorf("ScrubError() = %v, want a scrubbed error wrapping context.Canceled", scrubbed)
+	}
+	if value, ok := r.ScrubValue(err).(error); !ok || strings.Contains(value.Error(), "hunter2") {
+		t.Errorf("ScrubValue(error) = %v, want a scrubbed error", value)
+	}
+	if r.ScrubError(context.Canceled) != context.Canceled || r.ScrubError(nil) != nil {
+		t.Errorf("ScrubError() replaced an error without secrets")
+	}
+}
+
+// TestDefaultRegistry verifies the package-level helpers use the default registry
+func TestDefaultRegistry(t *testing.T) {
+	previous := DefaultRegistry()
+	SetDefaultRegistry(NewRegistry())
+	t.Cleanup(func() { SetDefaultRegistry(previous) })
+
+	secret := New("package-level-secret")
+	Register(secret)
+	if got := Scrub("x package-level-secret"); got != "x "+secret.String() {
+		t.Errorf("Scrub() = %v, want %v", got, "x "+secret.String())
+	}
+	Unregister(secret)
+	if got := Scrub("x package-level-secret"); got != "x package-level-secret" {
+		t.Errorf("Scrub() after Unregister = %v, want unchanged", got)
+	}
+}
+
+// TestRegistry_ConcurrentUse verifies concurrent registration and scrubbing
+func TestRegistry_ConcurrentUse(t *testing.T) {
+	r := NewRegistry()
+	stable := New("stable-secret")
+	r.Register(stable)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				ss := New(fmt.Sprintf("transient-%d-%d", i, j))
+				r.Register(ss)
+				r.Unregister(ss)
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				if got := r.Scrub("x stable-secret"); got != "x "+stable.String() {
+					t.Errorf("Scrub() = %v, want %v", got, "x "+stable.String())
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := r.Len(); got != 1 {
+		t.Errorf("Len() = %v, want 1", got)
+	}
+}
+
+// BenchmarkRegistry_ParallelScrub measures scrubbing throughput across
+// goroutines while another goroutine keeps changing the registry.
+func BenchmarkRegistry_ParallelScrub(b *testing.B) {
+	r := NewRegistry()
+	for i := 0; i < 100; i++ {
+		r.Register(New(fmt.Sprintf("Xsecret-value-%d", i)))
+	}
+	line := "level=info msg=request handled status=200 path=/api/v1/items"
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				ss := New("Xrotating-secret")
+				r.Register(ss)
+				r.Unregister(ss)
+			}
+		}
+	}()
+	defer close(done)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			r.Scrub(line)
+		}
+	})
+}
+
+// TestRegistry_Secrets verifies enumeration of registered secrets
+func TestRegistry_Secrets(t *testing.T) {
+	r := NewRegistry()
+	a, b := New("alpha-secret"), New("beta-secret")
+	r.Register(a)
+	r.RegisterWeak(a, b, nil)
+
+	got := r.Secrets()
+	if len(got) != 2 || r.Len() != 2 {
+		t.Fatalf("Secrets() = %d secrets, Len() = %d, want 2", len(got), r.Len())
+	}
+	seen := map[*SensitiveString]bool{got[0]: true, got[1]: true}
+	if !seen[a] || !seen[b] {
+		t.Errorf("Secrets() = %v, want a and b", got)
+	}
+
+	r.Unregister(a, b)
+	if got := r.Secrets(); len(got) != 0 {
+		t.Errorf("Secrets() after Unregister = %v, want none", got)
+	}
+	runtime.KeepAlive(b)
+}
+
+// TestRegistry_RegisterWeak verifies weakly registered secrets are dropped once collected
+func TestRegistry_RegisterWeak(t *testing.T) {
+	r := NewRegistry()
+	func() {
+		r.RegisterWeak(New(fmt.Sprintf("weak-secret-%d", 42)))
+	}()
+	if got := r.Scrub("x weak-secret-42"); got == "x weak-secret-42" {
+		t.Fatalf("Scrub() = %v, want the weak secret scrubbed while alive", got)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for r.Len() != 0 || r.Scrub("x weak-secret-42") != "x weak-secret-42" {
+		if time.Now().After(deadline) {
+			t.Fatalf("weak secret still registered after GC: Len() = %d", r.Len())
+		}
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,43 @@
+package sensitivestring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrMissingSecret is wrapped by the errors ValidateRequired reports for
+// each missing or empty secret.
+var ErrMissingSecret = errors.New("sensitivestring: missing required secret")
+
+// ValidateRequired checks at startup that every named secret is present in
+// secrets and non-empty. It returns nil when all are present; otherwise it
+// returns the errors for all missing secrets joined together, each
+// identifying the secret by name only.
+func ValidateRequired(secrets map[string]*SensitiveString, names ...string) error {
+	var errs []error
+	for _, name := range names {
+		if secrets[name].Len() == 0 {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrMissingSecret, name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateProvider is the Provider counterpart of ValidateRequired: it
+// resolves every named secret from p and reports those that are missing
+// or empty, joined together. Errors other than ErrSecretNotFound, such as
+// an unreachable backend, are reported as they are.
+func ValidateProvider(ctx context.Context, p Provider, names ...string) error {
+	var errs []error
+	for _, name := range names {
+		s, err := p.Resolve(ctx, name)
+		switch {
+		case errors.Is(err, ErrSecretNotFound) || err == nil && s.Len() == 0:
+			errs = append(errs, fmt.Errorf("%w: %s", ErrMissingSecret, name))
+		case err != nil:
+			errs = append(errs, fmt.Errorf("sensitivestring: resolving %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,52 @@
+package sensitivestring
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestValidateRequired verifies missing, nil and empty secrets are all reported
+func TestValidateRequired(t *testing.T) {
+	secrets := map[string]*SensitiveString{
+		"db":    New("hunter2"),
+		"api":   New(""),
+		"cache": nil,
+	}
+
+	if err := ValidateRequired(secrets, "db"); err != nil {
+		t.Errorf("ValidateRequired(db) error = %v, want nil", err)
+	}
+
+	err := ValidateRequired(secrets, "db", "api", "cache", "token")
+	if !errors.Is(err, ErrMissingSecret) {
+		t.Fatalf("ValidateRequired() error = %v, want ErrMissingSecret", err)
+	}
+	for _, name := range []string{"api", "cache", "token"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("ValidateRequired() error should list %s, got: %v", name, err)
+		}
+	}
+	if strings.Contains(err.Error(), "db") || strings.Contains(err.Error(), "hunter2") {
+		t.Errorf("ValidateRequired() error should only list missing secrets, got: %v", err)
+	}
+}
+
+// TestValidateProvider verifies missing secrets and backend failures are both reported
+func TestValidateProvider(t *testing.T) {
+	p := MapProvider{"db": New("hunter2"), "api": New("")}
+	if err := ValidateProvider(context.Background(), p, "db"); err != nil {
+		t.Errorf("ValidateProvider(db) error = %v, want nil", err)
+	}
+	err := ValidateProvider(context.Background(), p, "db", "api", "token")
+	if !errors.Is(err, ErrMissingSecret) || !strings.Contains(err.Error(), "api") || !strings.Contains(err.Error(), "token") {
+		t.Errorf("ValidateProvider() error = %v, want api and token missing", err)
+	}
+
+	down := errors.New("backend down")
+	failing := ProviderFunc(func(context.Context, string) (*SensitiveString, error) { return nil, down })
+	if err := ValidateProvider(context.Background(), failing, "db"); !errors.Is(err, down) || errors.Is(err, ErrMissingSecret) {
+		t.Errorf("ValidateProvider() error = %v, want the backend error", err)
+	}
+}
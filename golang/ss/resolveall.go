@@ -0,0 +1,122 @@
+package sensitivestring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// maxResolveConcurrency bounds the resolutions ResolveAll runs at once per
+// provider without a batch API.
+const maxResolveConcurrency = 8
+
+// BatchProvider is implemented by providers whose backend can fetch many
+// secrets in one round trip, such as AWS BatchGetSecretValue. ResolveBatch
+// returns the secrets it fetched, keyed by the names given, together with
+// the errors for the rest.
+type BatchProvider interface {
+	Provider
+	ResolveBatch(ctx context.Context, names ...string) (map[string]*SensitiveString, error)
+}
+
+// ResolveAll resolves many references at once, e.g. the secrets a service
+// needs at boot. References to a BatchProvider are fetched with
+// ResolveBatch; the rest are resolved concurrently, sharing resolutions
+// already in flight through ResolveRef. The result holds every
+// secret resolved, keyed by reference as given, and the error joins the errors for
+// the others, so callers can proceed with partial results.
+func (r *Resolver) ResolveAll(ctx context.Context, refs ...string) (map[string]*SensitiveString, error) {
+	byProvider := make(map[string][]Ref)
+	keys := make(map[Ref][]string)
+	var errs []error
+	for _, ref := range refs {
+		parsed, err := ParseRef(ref)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if _, seen := keys[parsed]; !seen {
+			byProvider[parsed.Provider] = append(byProvider[parsed.Provider], parsed)
+		}
+		keys[parsed] = append(keys[parsed], ref)
+	}
+
+	var mu sync.Mutex
+	result := make(map[string]*SensitiveString, len(refs))
+	report := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+	}
+	collect := func(ref Ref, s *SensitiveString, err error) {
+		if err != nil {
+			report(fmt.Errorf("sensitivestring: resolving %s: %w", ref, err))
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		for _, key := range keys[ref] {
+			result[key] = s
+		}
+	}
+
+	var wg sync.WaitGroup
+	for name, group := range byProvider {
+		registered, ok := r.registered(name)
+		if !ok {
+			report(fmt.Errorf("%w: %s", ErrUnknownProvider, name))
+			continue
+		}
+		if batch, ok := registered.provider.(BatchProvider); ok {
+			wg.Go(func() { r.resolveBatch(ctx, batch, registered.timeout, group, collect, report) })
+			continue
+		}
+		limit := make(chan struct{}, maxResolveConcurrency)
+		for _, ref := range group {
+			wg.Go(func() {
+				limit <- struct{}{}
+				defer func() { <-limit }()
+				s, err := observeResolve(ctx, ResolveEvent(ref), func(ctx context.Context) (*SensitiveString, error) {
+					return r.flights.resolve(ctx, ref.String(), func(ctx context.Context) (*SensitiveString, error) {
+						return resolveWithin(ctx, registered.provider, ref.Name, registered.timeout)
+					})
+				})
+				collect(ref, s, err)
+			})
+		}
+	}
+	wg.Wait()
+	return result, errors.Join(errs...)
+}
+
+// resolveBatch resolves group with one ResolveBatch call. The batch error,
+// which names the secrets it concerns, is reported once for the group.
+func (r *Resolver) resolveBatch(ctx context.Context, p BatchProvider, timeout time.Duration, group []Ref, collect func(Ref, *SensitiveString, error), report func(error)) {
+	names := make([]string, len(group))
+	for i, ref := range group {
+		names[i] = ref.Name
+	}
+	secrets, err := observeResolve(ctx, ResolveEvent{Provider: group[0].Provider}, func(ctx context.Context) (map[string]*SensitiveString, error) {
+		return within(ctx, timeout, func(ctx context.Context) (map[string]*SensitiveString, error) {
+			return p.ResolveBatch(ctx, names...)
+		})
+	})
+	for _, ref := range group {
+		if s, ok := secrets[ref.Name]; ok {
+			collect(ref, s, nil)
+		} else if err == nil {
+			collect(ref, nil, fmt.Errorf("%w: %s", ErrSecretNotFound, ref.Name))
+		}
+	}
+	if err != nil {
+		report(fmt.Errorf("sensitivestring: resolving from %s: %w", group[0].Provider, err))
+	}
+}
+
+// ResolveAll resolves many references through the DefaultResolver. See
+// Resolver.ResolveAll.
+func ResolveAll(ctx context.Context, refs ...string) (map[string]*SensitiveString, error) {
+	return DefaultResolver().ResolveAll(ctx, refs...)
+}
@@ -0,0 +1,125 @@
+package sensitivestring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// batchMap is a MapProvider with a batch API that records its calls.
+type batchMap struct {
+	MapProvider
+	batches atomic.Int32
+}
+
+func (b *batchMap) ResolveBatch(ctx context.Context, names ...string) (map[string]*SensitiveString, error) {
+	b.batches.Add(1)
+	result := make(map[string]*SensitiveString)
+	var errs []error
+	for _, name := range names {
+		if s, ok := b.MapProvider[name]; ok {
+			result[name] = s
+		} else if name != "silent" {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrSecretNotFound, name))
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+// TestResolveAll verifies batch and fan-out resolution with partial results
+func TestResolveAll(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	slow := ProviderFunc(func(ctx context.Context, name string) (*SensitiveString, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+		}
+		time.Sleep(20 * time.Millisecond)
+		if name == "broken" {
+			return nil, errors.New("backend error")
+		}
+		return New("value-of-" + name), nil
+	})
+	batch := &batchMap{MapProvider: MapProvider{"a": New("A"), "b": New("B")}}
+	r := NewResolver()
+	r.Register("slow", slow)
+	r.Register("batch", batch)
+
+	refs := []string{"batch:a", "batch:b", "batch:missing", "slow:broken", "nope:x", "malformed"}
+	for i := range 20 {
+		refs = append(refs, fmt.Sprintf("slow:s%d", i))
+	}
+	start := time.Now()
+	got, err := r.ResolveAll(context.Background(), refs...)
+	if elapsed := time.Since(start); elapsed > 400*time.Millisecond {
+		t.Errorf("ResolveAll() took %v, want concurrent resolution", elapsed)
+	}
+	if len(got) != 22 || got["batch:a"].Value() != "A" || got["slow:s7"].Value() != "value-of-s7" {
+		t.Errorf("ResolveAll() = %d secrets, want 22", len(got))
+	}
+	if batch.batches.Load() != 1 {
+		t.Errorf("ResolveBatch called %d times, want 1", batch.batches.Load())
+	}
+	if p := peak.Load(); p > maxResolveConcurrency {
+		t.Errorf("peak concurrency = %d, want at most %d", p, maxResolveConcurrency)
+	}
+	for _, want := range []string{"missing", "broken", "nope", "malformed"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("ResolveAll() error does not mention %s: %v", want, err)
+		}
+	}
+	if !errors.Is(err, ErrSecretNotFound) || !errors.Is(err, ErrUnknownProvider) {
+		t.Errorf("ResolveAll() error = %v, want ErrSecretNotFound and ErrUnknownProvider", err)
+	}
+
+	// A secret a batch silently leaves out is reported as not found.
+	_, err = r.ResolveAll(context.Background(), "batch:a", "batch:silent")
+	if !errors.Is(err, ErrSecretNotFound) || !strings.Contains(err.Error(), "batch:silent") {
+		t.Errorf("ResolveAll() error = %v, want batch:silent not found", err)
+	}
+}
+
+// TestResolveAll_Default verifies the package-level function uses the default resolver
+func TestResolveAll_Default(t *testing.T) {
+	r := NewResolver()
+	r.Register("static", MapProvider{"a": New("A")})
+	withDefaultResolver(t, r)
+	got, err := ResolveAll(context.Background(), "static:a")
+	if err != nil || got["static:a"].Value() != "A" {
+		t.Errorf("ResolveAll() = %v, %v", got, err)
+	}
+}
+
+// TestResolveAll_SharesFlights verifies ResolveAll joins a resolution already in flight through ResolveRef
+func TestResolveAll_SharesFlights(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	var calls atomic.Int32
+	r := NewResolver()
+	r.Register("slow", ProviderFunc(func(ctx context.Context, name string) (*SensitiveString, error) {
+		if calls.Add(1) == 1 {
+			close(started)
+		}
+		<-release
+		return New("foo"), nil
+	}))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.Resolve(context.Background(), "slow:db")
+	}()
+	<-started
+	time.AfterFunc(50*time.Millisecond, func() { close(release) })
+	secrets, err := r.ResolveAll(context.Background(), "slow:db")
+	<-done
+	if err != nil || secrets["slow:db"].Value() != "foo" {
+		t.Fatalf("ResolveAll() = %v, %v", secrets, err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("provider called %d times, want 1", got)
+	}
+}
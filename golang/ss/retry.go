@@ -0,0 +1,98 @@
+package sensitivestring
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryOption configures Retry.
+type RetryOption func(*retryOptions)
+
+type retryOptions struct {
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+	retryIf     func(error) bool
+}
+
+// WithMaxAttempts sets how many times a resolution is attempted in total,
+// including the first. The default is 3.
+func WithMaxAttempts(n int) RetryOption {
+	return func(o *retryOptions) { o.maxAttempts = n }
+}
+
+// WithBackoff sets the delay before the first retry, doubled for each
+// further retry up to maxDelay. Each delay is drawn uniformly from zero up
+// to that bound ("full jitter"), so clients recovering from the same outage
+// do not retry in lockstep. The defaults are 100ms and 5s.
+func WithBackoff(baseDelay, maxDelay time.Duration) RetryOption {
+	return func(o *retryOptions) { o.baseDelay, o.maxDelay = baseDelay, maxDelay }
+}
+
+// WithRetryIf sets the function that decides whether an error is worth
+// retrying, replacing IsRetryable.
+func WithRetryIf(fn func(error) bool) RetryOption {
+	return func(o *retryOptions) { o.retryIf = fn }
+}
+
+// IsRetryable is the default retry classification. Errors are assumed
+// transient, such as 5xx responses, throttling and network failures,
+// except ErrSecretNotFound, ErrUnknownProvider, context cancellation and
+// deadlines, and errors with a Temporary method reporting false.
+func IsRetryable(err error) bool {
+	var temporary interface{ Temporary() bool }
+	switch {
+	case errors.Is(err, ErrSecretNotFound), errors.Is(err, ErrUnknownProvider),
+		errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return false
+	case errors.As(err, &temporary):
+		return temporary.Temporary()
+	}
+	return true
+}
+
+// Retry returns a Provider that retries failed resolutions through
+// provider with exponential backoff, so transient failures of a secret
+// backend need not be handled by every caller. Delays are measured with
+// the package Clock and cut short when ctx is done. The last error is
+// returned once the attempts are exhausted or an error is not retryable.
+func Retry(provider Provider, opts ...RetryOption) Provider {
+	options := retryOptions{maxAttempts: 3, baseDelay: 100 * time.Millisecond, maxDelay: 5 * time.Second, retryIf: IsRetryable}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return retryProvider{provider: provider, options: options}
+}
+
+// retryProvider is the Provider returned by Retry.
+type retryProvider struct {
+	provider Provider
+	options  retryOptions
+}
+
+// Resolve implements Provider.
+func (r retryProvider) Resolve(ctx context.Context, name string) (*SensitiveString, error) {
+	delay := r.options.baseDelay
+	for attempt := 1; ; attempt++ {
+		s, err := r.provider.Resolve(ctx, name)
+		if err == nil || attempt >= r.options.maxAttempts || !r.options.retryIf(err) {
+			return s, err
+		}
+		select {
+		case <-GetClock().After(jitter(delay)):
+		case <-ctx.Done():
+			return nil, errors.Join(err, ctx.Err())
+		}
+		delay = min(2*delay, r.options.maxDelay)
+	}
+}
+
+// jitter returns a random duration in [0, d].
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return rand.N(d + 1)
+}
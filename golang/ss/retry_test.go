@@ -0,0 +1,114 @@
+package sensitivestring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// flakyProvider fails with err for its first failures calls.
+type flakyProvider struct {
+	failures int
+	err      error
+	calls    int
+}
+
+func (p *flakyProvider) Resolve(ctx context.Context, name string) (*SensitiveString, error) {
+	p.calls++
+	if p.calls <= p.failures {
+		return nil, p.err
+	}
+	return New("value"), nil
+}
+
+// advanceWhileWaiting fires the clock for every retry delay until done is closed.
+func advanceWhileWaiting(clock *ManualClock, done <-chan struct{}) {
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+		if clock.Waiters() > 0 {
+			clock.Advance(time.Hour)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// TestRetry verifies transient errors are retried up to the attempt limit
+func TestRetry(t *testing.T) {
+	clock := withManualClock(t)
+	done := make(chan struct{})
+	defer close(done)
+	go advanceWhileWaiting(clock, done)
+
+	backend := &flakyProvider{failures: 2, err: errors.New("503 service unavailable")}
+	s, err := Retry(backend).Resolve(context.Background(), "db")
+	if err != nil || s.Value() != "value" || backend.calls != 3 {
+		t.Errorf("Resolve() = %v, %v after %d calls, want success after 3", s, err, backend.calls)
+	}
+
+	backend = &flakyProvider{failures: 5, err: errors.New("throttled")}
+	if _, err := Retry(backend, WithMaxAttempts(4)).Resolve(context.Background(), "db"); err == nil || backend.calls != 4 {
+		t.Errorf("Resolve() = %v after %d calls, want failure after 4", err, backend.calls)
+	}
+
+	backend = &flakyProvider{failures: 5, err: fmt.Errorf("%w: db", ErrSecretNotFound)}
+	if _, err := Retry(backend).Resolve(context.Background(), "db"); !errors.Is(err, ErrSecretNotFound) || backend.calls != 1 {
+		t.Errorf("Resolve() = %v after %d calls, want no retry of ErrSecretNotFound", err, backend.calls)
+	}
+
+	backend = &flakyProvider{failures: 1, err: errors.New("permanent")}
+	retryNothing := WithRetryIf(func(error) bool { return false })
+	if _, err := Retry(backend, retryNothing).Resolve(context.Background(), "db"); err == nil || backend.calls != 1 {
+		t.Errorf("Resolve() with WithRetryIf = %v after %d calls, want 1 call", err, backend.calls)
+	}
+}
+
+// TestRetry_Canceled verifies a backoff delay ends when the context is canceled
+func TestRetry_Canceled(t *testing.T) {
+	withManualClock(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	backend := &flakyProvider{failures: 5, err: errors.New("503")}
+	if _, err := Retry(backend).Resolve(ctx, "db"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Resolve() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+// TestJitter verifies delays stay within the backoff bound
+func TestJitter(t *testing.T) {
+	for range 100 {
+		if d := jitter(time.Second); d < 0 || d > time.Second {
+			t.Fatalf("jitter(1s) = %v", d)
+		}
+	}
+	if d := jitter(0); d != 0 {
+		t.Errorf("jitter(0) = %v, want 0", d)
+	}
+}
+
+// temporaryError reports whether it is temporary.
+type temporaryError bool
+
+func (e temporaryError) Error() string   { return "temporary error" }
+func (e temporaryError) Temporary() bool { return bool(e) }
+
+// TestIsRetryable verifies the default classification
+func TestIsRetryable(t *testing.T) {
+	for err, want := range map[error]bool{
+		errors.New("500 internal server error"): true,
+		temporaryError(true):                    true,
+		temporaryError(false):                   false,
+		context.Canceled:                        false,
+		fmt.Errorf("x: %w", ErrSecretNotFound):  false,
+		fmt.Errorf("x: %w", ErrUnknownProvider): false,
+	} {
+		if got := IsRetryable(err); got != want {
+			t.Errorf("IsRetryable(%v) = %v, want %v", err, got, want)
+		}
+	}
+}
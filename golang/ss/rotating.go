@@ -0,0 +1,160 @@
+package sensitivestring
+
+import (
+	"sync"
+	"time"
+)
+
+// RotatingSecret holds the current value of a secret and, for a grace
+// period after each rotation, the previous one, so that inbound requests
+// authenticated with either are accepted while clients catch up. The
+// previous value is retired automatically once the grace period has passed
+// on the package Clock. It keeps its own copies of the values it is given,
+// which stay the caller's, and wipes a copy with Zero once it is retired,
+// so values returned by Current and Previous must not be kept beyond their
+// use. It is safe for concurrent use.
+type RotatingSecret struct {
+	mu       sync.RWMutex
+	current  *SensitiveString
+	previous *SensitiveString
+	retireAt time.Time
+	grace    time.Duration
+}
+
+// NewRotating creates a RotatingSecret holding current, which keeps the
+// previous value for grace after each Rotate.
+func NewRotating(current *SensitiveString, grace time.Duration) *RotatingSecret {
+	return &RotatingSecret{current: privateCopy(current), grace: grace}
+}
+
+// Rotate makes next the current value and keeps the value it replaces for
+// the grace period. A value still in its grace period from an earlier
+// rotation is retired.
+func (r *RotatingSecret) Rotate(next *SensitiveString) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.retire()
+	r.previous = r.current
+	r.current = privateCopy(next)
+	r.retireAt = GetClock().Now().Add(r.grace)
+}
+
+// OnChange rotates to new. It has the signature of a Watch callback, so a
+// RotatingSecret can follow a provider:
+//
+//	initial, err := sensitivestring.Watch(ctx, ref, rotating.OnChange)
+func (r *RotatingSecret) OnChange(old, new *SensitiveString) {
+	r.Rotate(new)
+}
+
+// Retire drops the previous value before its grace period ends, e.g. once
+// every client is known to have rotated.
+func (r *RotatingSecret) Retire() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.retire()
+}
+
+// retire wipes and drops the previous value. r.mu must be held for
+// writing.
+func (r *RotatingSecret) retire() {
+	r.previous.Zero()
+	r.previous = nil
+}
+
+// privateCopy returns a copy of s for a RotatingSecret to own and wipe,
+// keeping its label, rendering and memory locking.
+func privateCopy(s *SensitiveString) *SensitiveString {
+	if s == nil {
+		return nil
+	}
+	var c *SensitiveString
+	s.usePlaintext(func(value []byte) { c = NewFromBytes(value) })
+	c.label, c.redactor, c.hashAlg = s.label, s.redactor, s.hashAlg
+	if s.Locked() {
+		WithMemoryLock()(c)
+	}
+	return c
+}
+
+// retireExpired retires the previous value once its grace period is over.
+func (r *RotatingSecret) retireExpired() {
+	r.mu.RLock()
+	expired := r.previous != nil && r.activePrevious() == nil
+	r.mu.RUnlock()
+	if !expired {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.previous != nil && r.activePrevious() == nil {
+		r.retire()
+	}
+}
+
+// Current returns the current value.
+func (r *RotatingSecret) Current() *SensitiveString {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.current
+}
+
+// Previous returns the previous value, or nil once it has been retired.
+func (r *RotatingSecret) Previous() *SensitiveString {
+	r.retireExpired()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.activePrevious()
+}
+
+// activePrevious returns the previous value unless its grace period is
+// over. r.mu must be held.
+func (r *RotatingSecret) activePrevious() *SensitiveString {
+	if r.previous == nil || !GetClock().Now().Before(r.retireAt) {
+		return nil
+	}
+	return r.previous
+}
+
+// VerifyAny reports whether candidate equals the current or the previous
+// value, e.g. an API key presented by a client. Both comparisons always
+// run, in constant time, so timing reveals neither which value matched nor
+// whether a rotation is in progress.
+func (r *RotatingSecret) VerifyAny(candidate string) bool {
+	r.retireExpired()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	// Without a previous value, the current one is compared twice so that
+	// the work done is the same.
+	other := r.activePrevious()
+	if other == nil {
+		other = r.current
+	}
+	matchCurrent := r.current.EqualString(candidate)
+	matchOther := other.EqualString(candidate)
+	return matchCurrent || matchOther
+}
+
+// VerifyAnyFunc reports whether verify accepts the current or the previous
+// value, e.g. to check a request signature made with either key:
+//
+//	ok := rotating.VerifyAnyFunc(func(key *SensitiveString) bool {
+//		return hmac.Equal(signature, sign(key, body))
+//	})
+//
+// verify is called twice whatever the outcome, with the current value and
+// then the previous one, or the current one again outside a rotation, so
+// that timing does not reveal whether a rotation is in progress. verify
+// runs with r locked for reading and must not call Rotate or Retire.
+func (r *RotatingSecret) VerifyAnyFunc(verify func(*SensitiveString) bool) bool {
+	r.retireExpired()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	other := r.activePrevious()
+	if other == nil {
+		other = r.current
+	}
+	matchCurrent := verify(r.current)
+	matchOther := verify(other)
+	return matchCurrent || matchOther
+}
@@ -0,0 +1,113 @@
+package sensitivestring
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestRotatingSecret verifies both values are accepted during the grace period only
+func TestRotatingSecret(t *testing.T) {
+	clock := withManualClock(t)
+	r := NewRotating(New("key-1"), time.Hour)
+	if !r.VerifyAny("key-1") || r.VerifyAny("key-2") || r.Previous() != nil {
+		t.Fatalf("before rotation: VerifyAny or Previous mismatch")
+	}
+
+	r.Rotate(New("key-2"))
+	if r.Current().Value() != "key-2" || r.Previous().Value() != "key-1" {
+		t.Errorf("after Rotate: Current = %v, Previous = %v", r.Current().Value(), r.Previous().Value())
+	}
+	for candidate, want := range map[string]bool{"key-1": true, "key-2": true, "key-3": false} {
+		if got := r.VerifyAny(candidate); got != want {
+			t.Errorf("VerifyAny(%s) during grace = %v, want %v", candidate, got, want)
+		}
+	}
+
+	retired := r.Previous()
+	clock.Advance(time.Hour)
+	if r.VerifyAny("key-1") || r.Previous() != nil {
+		t.Errorf("previous value accepted after the grace period")
+	}
+	if !retired.Destroyed() {
+		t.Errorf("previous value not wiped after the grace period")
+	}
+	if !r.VerifyAny("key-2") {
+		t.Errorf("current value rejected after the grace period")
+	}
+
+	r.OnChange(r.Current(), New("key-3"))
+	retired = r.Previous()
+	r.Retire()
+	if r.VerifyAny("key-2") || !r.VerifyAny("key-3") {
+		t.Errorf("Retire() did not drop the previous value")
+	}
+	if !retired.Destroyed() || r.Current().Destroyed() {
+		t.Errorf("Retire() wiped the wrong value")
+	}
+
+	dropped := r.Current()
+	r.Rotate(New("key-4"))
+	r.Rotate(New("key-5"))
+	if !dropped.Destroyed() || r.Previous().Destroyed() {
+		t.Errorf("Rotate() during a grace period did not wipe only the dropped value")
+	}
+}
+
+// TestRotatingSecret_VerifyAnyFunc verifies the callback sees both keys during a rotation
+func TestRotatingSecret_VerifyAnyFunc(t *testing.T) {
+	withManualClock(t)
+	r := NewRotating(New("old"), time.Minute)
+	r.Rotate(New("new"))
+
+	var seen []string
+	signedWithOld := r.VerifyAnyFunc(func(key *SensitiveString) bool {
+		seen = append(seen, key.Value())
+		return key.Value() == "old"
+	})
+	if !signedWithOld || len(seen) != 2 || seen[0] != "new" {
+		t.Errorf("VerifyAnyFunc() = %v, saw %v, want true after trying new then old", signedWithOld, seen)
+	}
+	if r.VerifyAnyFunc(func(key *SensitiveString) bool { return false }) {
+		t.Errorf("VerifyAnyFunc() = true when no key verifies")
+	}
+
+	r.Retire()
+	calls := 0
+	r.VerifyAnyFunc(func(key *SensitiveString) bool {
+		calls++
+		return true
+	})
+	if calls != 2 {
+		t.Errorf("VerifyAnyFunc() without a previous value called verify %d times, want 2", calls)
+	}
+}
+
+// TestRotatingSecret_SharedValues verifies retiring values leaves the caller's copies, such as cached ones, intact
+func TestRotatingSecret_SharedValues(t *testing.T) {
+	clock := withManualClock(t)
+	ctx := context.Background()
+	cache := Cache(MapProvider{"api-key": New("key-1")}, time.Hour)
+	initial, err := cache.Resolve(ctx, "api-key")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+
+	r := NewRotating(initial, time.Minute)
+	next := New("key-2")
+	r.Rotate(next)
+	clock.Advance(time.Minute)
+	if r.Previous() != nil {
+		t.Fatalf("previous value not retired after the grace period")
+	}
+	r.Rotate(New("key-3"))
+	r.Retire()
+
+	cached, err := cache.Resolve(ctx, "api-key")
+	if err != nil || cached.Value() != "key-1" || initial.Value() != "key-1" {
+		t.Errorf("cached value after retirement = %q, %v, want key-1", cached.Value(), err)
+	}
+	if next.Destroyed() || next.Value() != "key-2" {
+		t.Errorf("value passed to Rotate was wiped by retirement")
+	}
+}
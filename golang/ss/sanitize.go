@@ -0,0 +1,106 @@
+package sensitivestring
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultSensitiveKeys are the key patterns used by Sanitize.
+var DefaultSensitiveKeys = []string{
+	"password", "passwd", "pwd", "secret", "token", "api_key", "apikey",
+	"authorization", "credential", "private_key", "session", "cookie",
+}
+
+// Sanitizer wraps the values of map entries whose keys look sensitive, for
+// log fields produced by code that does not use SensitiveString.
+type Sanitizer struct {
+	patterns []string
+}
+
+// NewSanitizer creates a Sanitizer for the given key patterns. A key
+// matches a pattern if, ignoring case and the separators '_', '-', '.' and
+// ' ', the key contains the pattern; so "api_key" matches "apiKey",
+// "X-Api-Key" and "stripe.api_key".
+func NewSanitizer(patterns ...string) *Sanitizer {
+	s := &Sanitizer{}
+	for _, pattern := range patterns {
+		if normalized := normalizeKey(pattern); normalized != "" {
+			s.patterns = append(s.patterns, normalized)
+		}
+	}
+	return s
+}
+
+// Sanitize sanitizes fields with the DefaultSensitiveKeys.
+func Sanitize(fields map[string]interface{}) map[string]interface{} {
+	return NewSanitizer(DefaultSensitiveKeys...).Sanitize(fields)
+}
+
+// Sanitize returns a copy of fields in which the value of every sensitive
+// key is wrapped in a SensitiveString labeled with the key, so it renders
+// as a hash. Values that are already SensitiveStrings are kept. Nested maps
+// and slices are sanitized too; everything under a sensitive key is
+// wrapped. fields itself is not modified.
+func (s *Sanitizer) Sanitize(fields map[string]interface{}) map[string]interface{} {
+	if fields == nil {
+		return nil
+	}
+	return s.sanitizeMap(fields, false)
+}
+
+// Matches reports whether key is sensitive.
+func (s *Sanitizer) Matches(key string) bool {
+	normalized := normalizeKey(key)
+	for _, pattern := range s.patterns {
+		if strings.Contains(normalized, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Sanitizer) sanitizeMap(fields map[string]interface{}, sensitive bool) map[string]interface{} {
+	result := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		result[key] = s.sanitizeValue(key, value, sensitive || s.Matches(key))
+	}
+	return result
+}
+
+// sanitizeValue sanitizes value found under key.
+func (s *Sanitizer) sanitizeValue(key string, value interface{}, sensitive bool) interface{} {
+	switch v := value.(type) {
+	case nil, *SensitiveString, SensitiveString:
+		return value
+	case map[string]interface{}:
+		return s.sanitizeMap(v, sensitive)
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, elem := range v {
+			result[i] = s.sanitizeValue(key, elem, sensitive)
+		}
+		return result
+	}
+	if !sensitive {
+		return value
+	}
+	switch v := value.(type) {
+	case string:
+		return NewLabeled(key, v)
+	case []byte:
+		return NewLabeled(key, string(v))
+	default:
+		return NewLabeled(key, fmt.Sprint(v))
+	}
+}
+
+// normalizeKey lower-cases key and removes separators.
+func normalizeKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '_', '-', '.', ' ':
+			return -1
+		}
+		return r
+	}, strings.ToLower(key))
+}
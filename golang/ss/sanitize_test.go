@@ -0,0 +1,73 @@
+package sensitivestring
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestSanitize verifies sensitive keys are wrapped and others kept
+func TestSanitize(t *testing.T) {
+	existing := New("already-wrapped")
+	fields := map[string]interface{}{
+		"user":          "alice",
+		"password":      "hunter2",
+		"X-Api-Key":     []byte("key-123"),
+		"Authorization": "Bearer abc",
+		"pin_secret":    1234,
+		"token":         existing,
+		"empty":         nil,
+		"nested": map[string]interface{}{
+			"host":       "db",
+			"dbPassword": "nested-pw",
+		},
+		"credentials": map[string]interface{}{"anything": "all-hidden"},
+		"items":       []interface{}{map[string]interface{}{"session_id": "sess-1"}, "plain"},
+	}
+
+	got := Sanitize(fields)
+	out, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	for _, leaked := range []string{"hunter2", "key-123", "Bearer", "1234", "nested-pw", "all-hidden", "sess-1", "already-wrapped"} {
+		if strings.Contains(string(out), leaked) {
+			t.Errorf("Sanitize() leaked %q: %s", leaked, out)
+		}
+	}
+	for _, kept := range []string{`"user":"alice"`, `"host":"db"`, `"plain"`} {
+		if !strings.Contains(string(out), kept) {
+			t.Errorf("Sanitize() output = %s, want %s kept", out, kept)
+		}
+	}
+	if got["token"] != existing {
+		t.Errorf("Sanitize()[token] = %v, want the existing SensitiveString", got["token"])
+	}
+	if pw := got["password"].(*SensitiveString); pw.Label() != "password" || pw.Value() != "hunter2" {
+		t.Errorf("Sanitize()[password] = %v/%v, want labeled hunter2", pw.Label(), pw.Value())
+	}
+	if fields["password"] != "hunter2" {
+		t.Errorf("Sanitize() modified its input")
+	}
+}
+
+// TestSanitizer_Matches verifies key normalization and custom patterns
+func TestSanitizer_Matches(t *testing.T) {
+	s := NewSanitizer("api_key", "ssn")
+	tests := map[string]bool{
+		"apiKey":         true,
+		"X-API-KEY":      true,
+		"stripe.api_key": true,
+		"customer_SSN":   true,
+		"password":       false,
+		"apikeeper":      false,
+	}
+	for key, want := range tests {
+		if got := s.Matches(key); got != want {
+			t.Errorf("Matches(%q) = %v, want %v", key, got, want)
+		}
+	}
+	if got := Sanitize(nil); got != nil {
+		t.Errorf("Sanitize(nil) = %v, want nil", got)
+	}
+}
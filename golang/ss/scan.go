@@ -0,0 +1,118 @@
+package sensitivestring
+
+import (
+	"errors"
+	"io"
+	"os"
+)
+
+// scanChunkSize is the read size used by ScanReader.
+const scanChunkSize = 1 << 20
+
+// Finding describes an occurrence of a registered secret found by a scan.
+// It identifies the secret by its hash form and label, never by its value.
+type Finding struct {
+	Offset   int64
+	Length   int
+	Redacted string
+	Label    string
+}
+
+// errMmapUnsupported is returned by mmapFile on platforms without mmap.
+var errMmapUnsupported = errors.New("sensitivestring: mmap is not supported on this platform")
+
+// ScanBytes returns every non-overlapping occurrence of a registered secret
+// in b.
+func (r *Registry) ScanBytes(b []byte) []Finding {
+	findings, _ := scanWindow(r.snapshot(), b, 0, len(b), nil)
+	return findings
+}
+
+// ScanReader returns every non-overlapping occurrence of a registered secret
+// in the data read from rd. Memory use is bounded by the chunk size plus the
+// length of the longest registered secret, regardless of input size.
+func (r *Registry) ScanReader(rd io.Reader) ([]Finding, error) {
+	sc := r.snapshot()
+	if sc == nil {
+		_, err := io.Copy(io.Discard, rd)
+		return nil, err
+	}
+
+	var findings []Finding
+	var base int64
+	window := make([]byte, 0, scanChunkSize+sc.maxLen)
+	for {
+		n, err := rd.Read(window[len(window):cap(window)])
+		window = window[:len(window)+n]
+		eof := errors.Is(err, io.EOF)
+		if err != nil && !eof {
+			return findings, err
+		}
+
+		// Only matches that start early enough to fit entirely in the
+		// window are reported; the tail is carried into the next read.
+		limit := len(window) - (sc.maxLen - 1)
+		if eof {
+			limit = len(window)
+		}
+		var next int
+		findings, next = scanWindow(sc, window, base, limit, findings)
+		if eof {
+			return findings, nil
+		}
+		base += int64(next)
+		window = window[:copy(window, window[next:])]
+	}
+}
+
+// ScanFile returns every non-overlapping occurrence of a registered secret
+// in the file at path. Regular files are memory-mapped where the platform
+// supports it, so multi-GB artifacts can be scanned without loading them
+// into the heap; other files are streamed through ScanReader.
+func (r *Registry) ScanFile(path string) ([]Finding, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Mode().IsRegular() && info.Size() > 0 {
+		data, unmap, err := mmapFile(f, info.Size())
+		if err == nil {
+			defer unmap()
+			return r.ScanBytes(data), nil
+		}
+		if !errors.Is(err, errMmapUnsupported) {
+			return nil, err
+		}
+	}
+	return r.ScanReader(f)
+}
+
+// scanWindow appends the findings that start in b[:limit], with offsets
+// relative to base, and returns the position at which scanning stopped.
+func scanWindow[T string | []byte](sc *scrubber, b T, base int64, limit int, findings []Finding) ([]Finding, int) {
+	if sc == nil {
+		return findings, max(limit, 0)
+	}
+	i := 0
+	for i < limit {
+		target, ok := matchAt(sc, b, i)
+		if !ok {
+			i++
+			continue
+		}
+		findings = append(findings, Finding{
+			Offset:   base + int64(i),
+			Length:   len(target.value),
+			Redacted: target.redacted,
+			Label:    target.label,
+		})
+		i += len(target.value)
+	}
+	return findings, max(i, 0)
+}
@@ -0,0 +1,11 @@
+//go:build !unix
+
+package sensitivestring
+
+import "os"
+
+// mmapFile is not supported on this platform; ScanFile falls back to
+// streaming the file.
+func mmapFile(f *os.File, size int64) ([]byte, func(), error) {
+	return nil, nil, errMmapUnsupported
+}
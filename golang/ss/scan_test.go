@@ -0,0 +1,85 @@
+package sensitivestring
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/iotest"
+)
+
+// scanTestRegistry returns a registry with two labeled secrets.
+func scanTestRegistry() *Registry {
+	r := NewRegistry()
+	r.Register(NewLabeled("token", "tok-123456"), NewLabeled("password", "hunter2"))
+	return r
+}
+
+var scanTestData = []byte("header tok-123456 middle hunter2 tail hunter2")
+
+var scanTestFindings = []Finding{
+	{Offset: 7, Length: 10, Redacted: New("tok-123456").String(), Label: "token"},
+	{Offset: 25, Length: 7, Redacted: New("hunter2").String(), Label: "password"},
+	{Offset: 38, Length: 7, Redacted: New("hunter2").String(), Label: "password"},
+}
+
+// TestScanBytes verifies findings in an in-memory buffer
+func TestScanBytes(t *testing.T) {
+	got := scanTestRegistry().ScanBytes(scanTestData)
+	if !reflect.DeepEqual(got, scanTestFindings) {
+		t.Errorf("ScanBytes() = %+v, want %+v", got, scanTestFindings)
+	}
+
+	if got := NewRegistry().ScanBytes(scanTestData); got != nil {
+		t.Errorf("ScanBytes() on empty registry = %+v, want nil", got)
+	}
+}
+
+// TestScanReader_ChunkBoundaries verifies secrets split across reads are found
+func TestScanReader_ChunkBoundaries(t *testing.T) {
+	got, err := scanTestRegistry().ScanReader(iotest.OneByteReader(bytes.NewReader(scanTestData)))
+	if err != nil {
+		t.Fatalf("ScanReader() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, scanTestFindings) {
+		t.Errorf("ScanReader() = %+v, want %+v", got, scanTestFindings)
+	}
+}
+
+// TestScanReader_Error verifies read errors are returned
+func TestScanReader_Error(t *testing.T) {
+	_, err := scanTestRegistry().ScanReader(iotest.ErrReader(os.ErrClosed))
+	if err != os.ErrClosed {
+		t.Errorf("ScanReader() error = %v, want %v", err, os.ErrClosed)
+	}
+}
+
+// TestScanFile verifies file scanning, including empty files
+func TestScanFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "artifact.bin")
+	if err := os.WriteFile(path, scanTestData, 0o600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	got, err := scanTestRegistry().ScanFile(path)
+	if err != nil {
+		t.Fatalf("ScanFile() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, scanTestFindings) {
+		t.Errorf("ScanFile() = %+v, want %+v", got, scanTestFindings)
+	}
+
+	empty := filepath.Join(dir, "empty.bin")
+	if err := os.WriteFile(empty, nil, 0o600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+	if got, err := scanTestRegistry().ScanFile(empty); err != nil || got != nil {
+		t.Errorf("ScanFile(empty) = (%+v, %v), want (nil, nil)", got, err)
+	}
+
+	if _, err := scanTestRegistry().ScanFile(filepath.Join(dir, "missing")); !os.IsNotExist(err) {
+		t.Errorf("ScanFile(missing) error = %v, want not-exist", err)
+	}
+}
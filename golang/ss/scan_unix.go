@@ -0,0 +1,20 @@
+//go:build unix
+
+package sensitivestring
+
+import (
+	"os"
+	"syscall"
+)
+
+// mmapFile maps size bytes of f read-only into memory.
+func mmapFile(f *os.File, size int64) ([]byte, func(), error) {
+	if int64(int(size)) != size {
+		return nil, nil, errMmapUnsupported
+	}
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, func() { _ = syscall.Munmap(data) }, nil
+}
@@ -0,0 +1,232 @@
+package sensitivestring
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// scrubTarget is a secret captured by a scrubber.
+type scrubTarget struct {
+	value    string
+	redacted string
+	label    string
+}
+
+// scrubber replaces the plaintext of a fixed set of secrets, and the common
+// encodings of that plaintext (see encodedForms), with their redacted form.
+// A scrubber is immutable once built. The redacted forms depend on the
+// package configuration (Redactor, hash algorithm, salt, fingerprint key),
+// so a scrubber built under an earlier configuration is stale and must be
+// rebuilt before use.
+//
+// Matching is done with a strings.Replacer, which is comparatively
+// expensive, so every input first goes through a cheap candidate filter: an
+// input shorter than the shortest secret, or containing none of the byte
+// pairs that a secret starts with, cannot contain any secret and is returned
+// unchanged without entering the matching phase.
+type scrubber struct {
+	config   *config
+	replacer *strings.Replacer
+	minLen   int
+	maxLen   int
+	byFirst  [256][]scrubTarget
+	pairs    [1 << 16 / 64]uint64
+	singles  [256]bool
+}
+
+// newScrubber builds a scrubber for the given secrets. Empty values are
+// ignored since they would match everywhere.
+func newScrubber(secrets []*SensitiveString) *scrubber {
+	cfg := loadConfig()
+	targets := make(map[string]scrubTarget, len(secrets))
+	for _, ss := range secrets {
+		if value := ss.Value(); value != "" {
+			targets[value] = scrubTarget{value: value, redacted: ss.String(), label: ss.Label()}
+		}
+	}
+	// Encoded forms never take precedence over a plaintext value.
+	for _, ss := range secrets {
+		value := ss.Value()
+		if value == "" {
+			continue
+		}
+		for _, form := range encodedForms(value) {
+			if _, exists := targets[form]; !exists && form != "" {
+				targets[form] = scrubTarget{value: form, redacted: ss.String(), label: ss.Label()}
+			}
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	// strings.Replacer tries patterns in argument order, so put longer
+	// values first to prefer the longest match when secrets overlap.
+	sorted := make([]scrubTarget, 0, len(targets))
+	for _, target := range targets {
+		sorted = append(sorted, target)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if len(sorted[i].value) != len(sorted[j].value) {
+			return len(sorted[i].value) > len(sorted[j].value)
+		}
+		return sorted[i].value < sorted[j].value
+	})
+
+	sc := &scrubber{config: cfg, minLen: len(sorted[len(sorted)-1].value), maxLen: len(sorted[0].value)}
+	oldnew := make([]string, 0, 2*len(sorted))
+	for _, target := range sorted {
+		sc.byFirst[target.value[0]] = append(sc.byFirst[target.value[0]], target)
+		if len(target.value) == 1 {
+			sc.singles[target.value[0]] = true
+		} else {
+			pair := uint16(target.value[0])<<8 | uint16(target.value[1])
+			sc.pairs[pair/64] |= 1 << (pair % 64)
+		}
+		oldnew = append(oldnew, target.value, target.redacted)
+	}
+	sc.replacer = strings.NewReplacer(oldnew...)
+	return sc
+}
+
+// stale reports whether the package configuration has changed since sc was
+// built, so its redacted forms may be out of date.
+func (sc *scrubber) stale() bool {
+	return sc != nil && sc.config != loadConfig()
+}
+
+// mayContain reports whether s could contain one of the scrubber's secrets.
+// A false result is definitive; a true result only means the matching phase
+// has to run.
+func mayContain[T string | []byte](sc *scrubber, s T) bool {
+	if sc == nil || len(s) < sc.minLen {
+		return false
+	}
+	for i := 0; i <= len(s)-sc.minLen; i++ {
+		if sc.singles[s[i]] {
+			return true
+		}
+		if i+1 < len(s) {
+			pair := uint16(s[i])<<8 | uint16(s[i+1])
+			if sc.pairs[pair/64]&(1<<(pair%64)) != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchAt returns the longest secret starting at s[i], if any.
+func matchAt[T string | []byte](sc *scrubber, s T, i int) (scrubTarget, bool) {
+	for _, target := range sc.byFirst[s[i]] {
+		if len(s)-i >= len(target.value) && string(s[i:i+len(target.value)]) == target.value {
+			return target, true
+		}
+	}
+	return scrubTarget{}, false
+}
+
+// scrub returns s with every secret replaced by its redacted form.
+func (sc *scrubber) scrub(s string) string {
+	if !mayContain(sc, s) {
+		return s
+	}
+	recordScrub(sc, s)
+	return sc.replacer.Replace(s)
+}
+
+// scrubBytes is the []byte equivalent of scrub. b is returned unchanged
+// when it cannot contain a secret.
+func (sc *scrubber) scrubBytes(b []byte) []byte {
+	if !mayContain(sc, b) {
+		return b
+	}
+	recordScrub(sc, b)
+	return []byte(sc.replacer.Replace(string(b)))
+}
+
+// scrubAny returns value with secrets removed and whether anything
+// changed. Strings, byte slices, errors and Stringers are scrubbed; slices,
+// arrays and maps are walked, and become []interface{} or
+// map[string]interface{} only when one of their elements changed. Errors
+// stay errors, wrapping the original (see scrubError). SensitiveStrings
+// are left to their own String and LogValue.
+func scrubAny(sc *scrubber, value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case nil, *SensitiveString, SensitiveString, slog.LogValuer:
+		return value, false
+	case string:
+		scrubbed := sc.scrub(v)
+		return scrubbed, scrubbed != v
+	case []byte:
+		if !mayContain(sc, v) {
+			return value, false
+		}
+		return sc.scrub(string(v)), true
+	case error:
+		scrubbed := scrubError(sc, v)
+		return scrubbed, scrubbed != v
+	case fmt.Stringer:
+		text := v.String()
+		if scrubbed := sc.scrub(text); scrubbed != text {
+			return scrubbed, true
+		}
+		return value, false
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		var result []interface{}
+		for i := 0; i < rv.Len(); i++ {
+			elem, changed := scrubAny(sc, rv.Index(i).Interface())
+			if changed && result == nil {
+				result = make([]interface{}, rv.Len())
+				for j := 0; j < i; j++ {
+					result[j] = rv.Index(j).Interface()
+				}
+			}
+			if result != nil {
+				result[i] = elem
+			}
+		}
+		if result == nil {
+			return value, false
+		}
+		return result, true
+	case reflect.Map:
+		var result map[string]interface{}
+		iter := rv.MapRange()
+		for iter.Next() {
+			if _, changed := scrubAny(sc, iter.Value().Interface()); changed {
+				result = make(map[string]interface{}, rv.Len())
+				break
+			}
+		}
+		if result == nil {
+			return value, false
+		}
+		for iter = rv.MapRange(); iter.Next(); {
+			elem, _ := scrubAny(sc, iter.Value().Interface())
+			result[fmt.Sprint(iter.Key().Interface())] = elem
+		}
+		return result, true
+	}
+	return value, false
+}
+
+// scrubError returns err unchanged if its message holds no secret, and
+// otherwise a scrubbedError wrapping it.
+func scrubError(sc *scrubber, err error) error {
+	if err == nil {
+		return nil
+	}
+	msg := err.Error()
+	if scrubbed := sc.scrub(msg); scrubbed != msg {
+		return &scrubbedError{msg: scrubbed, err: err}
+	}
+	return err
+}
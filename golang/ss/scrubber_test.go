@@ -0,0 +1,87 @@
+package sensitivestring
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestScrubber_Replaces verifies every secret occurrence is replaced
+func TestScrubber_Replaces(t *testing.T) {
+	secret := New("hunter2")
+	sc := newScrubber([]*SensitiveString{secret, New("other")})
+
+	got := sc.scrub("password=hunter2 again hunter2 and other")
+	expected := "password=" + secret.String() + " again " + secret.String() + " and " + New("other").String()
+	if got != expected {
+		t.Errorf("scrub() = %v, want %v", got, expected)
+	}
+
+	if got := string(sc.scrubBytes([]byte("x hunter2"))); got != "x "+secret.String() {
+		t.Errorf("scrubBytes() = %v, want %v", got, "x "+secret.String())
+	}
+}
+
+// TestScrubber_PrefersLongest verifies overlapping secrets match the longest value
+func TestScrubber_PrefersLongest(t *testing.T) {
+	short := New("abc")
+	long := New("abcdef")
+	sc := newScrubber([]*SensitiveString{short, long})
+
+	if got := sc.scrub("abcdef"); got != long.String() {
+		t.Errorf("scrub() = %v, want %v", got, long.String())
+	}
+}
+
+// TestScrubber_Empty verifies empty and nil scrubbers pass input through
+func TestScrubber_Empty(t *testing.T) {
+	sc := newScrubber([]*SensitiveString{New("")})
+	if sc != nil {
+		t.Errorf("newScrubber(empty value) = %v, want nil", sc)
+	}
+	if got := sc.scrub("anything"); got != "anything" {
+		t.Errorf("nil scrub() = %v, want anything", got)
+	}
+}
+
+// TestScrubber_FastPath verifies the candidate filter
+func TestScrubber_FastPath(t *testing.T) {
+	sc := newScrubber([]*SensitiveString{New("hunter2"), New("zebra")})
+
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"short", false},
+		{"no candidates at all", false},
+		{"has an h in it", false},
+		{"has a hu in it", true},
+		{"ends with h", false},
+		{"contains hunter2", true},
+	}
+	for _, tt := range tests {
+		if got := mayContain(sc, tt.input); got != tt.want {
+			t.Errorf("mayContain(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+		if got := mayContain(sc, []byte(tt.input)); got != tt.want {
+			t.Errorf("mayContain([]byte(%q)) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func BenchmarkScrubber_NoCandidate(b *testing.B) {
+	sc := newScrubber([]*SensitiveString{New("Xsecret-token-value"), New("Qanother-secret")})
+	line := strings.Repeat("level=info msg=request handled status=200 ", 4)
+	b.ReportAllocs()
+	for b.Loop() {
+		sc.scrub(line)
+	}
+}
+
+func BenchmarkScrubber_Candidate(b *testing.B) {
+	sc := newScrubber([]*SensitiveString{New("secret-token-value"), New("another-secret")})
+	line := strings.Repeat("level=info msg=request handled status=200 ", 4)
+	b.ReportAllocs()
+	for b.Loop() {
+		sc.scrub(line)
+	}
+}
@@ -0,0 +1,133 @@
+package sensitivestring
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidKey is returned by Encrypt and Decrypt for a key that is not
+// 32 bytes long.
+var ErrInvalidKey = errors.New("sensitivestring: encryption key must be 32 bytes")
+
+// Encrypt encrypts plaintext with AES-256-GCM under the 32-byte key held
+// in k and returns a binary Envelope (see ParseEnvelope). The key is only
+// read for the duration of the call.
+func (k *SensitiveBytes) Encrypt(plaintext []byte) ([]byte, error) {
+	if k.Len() != 32 {
+		return nil, ErrInvalidKey
+	}
+	var e *Envelope
+	var err error
+	k.Use(func(key []byte) { e, err = sealEnvelope(key, plaintext) })
+	if err != nil {
+		return nil, err
+	}
+	return e.MarshalBinary()
+}
+
+// Decrypt opens an Envelope produced by Encrypt (or Seal) with the key held
+// in k. The caller should clear the returned plaintext once done with it.
+func (k *SensitiveBytes) Decrypt(ciphertext []byte) ([]byte, error) {
+	if k.Len() != 32 {
+		return nil, ErrInvalidKey
+	}
+	e, err := ParseEnvelope(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	var plaintext []byte
+	k.Use(func(key []byte) { plaintext, err = e.open(key) })
+	return plaintext, err
+}
+
+// SealedString is a secret in encrypted form. Unlike a SensitiveString,
+// whose serialized form is a hash, a SealedString marshals to its
+// ciphertext (a base64-encoded Envelope), so the secret can be persisted
+// and read back with the key. It never holds the plaintext or the key.
+type SealedString struct {
+	envelope []byte
+}
+
+// NewSealedString encrypts s under the key held in key.
+func NewSealedString(s *SensitiveString, key *SensitiveBytes) (*SealedString, error) {
+	var envelope []byte
+	var err error
+	s.Use(func(plaintext []byte) { envelope, err = key.Encrypt(plaintext) })
+	if err != nil {
+		return nil, err
+	}
+	return &SealedString{envelope: envelope}, nil
+}
+
+// Open decrypts the sealed secret with the key held in key.
+func (s *SealedString) Open(key *SensitiveBytes) (*SensitiveString, error) {
+	if s == nil {
+		return nil, fmt.Errorf("%w: empty sealed string", ErrInvalidEnvelope)
+	}
+	plaintext, err := key.Decrypt(s.envelope)
+	if err != nil {
+		return nil, err
+	}
+	return &SensitiveString{store: &byteStorage{buf: plaintext}}, nil
+}
+
+// Bytes returns a copy of the binary Envelope.
+func (s *SealedString) Bytes() []byte {
+	if s == nil {
+		return nil
+	}
+	return append([]byte(nil), s.envelope...)
+}
+
+// String returns the base64-encoded Envelope, implementing fmt.Stringer.
+// The ciphertext is safe to print.
+func (s SealedString) String() string {
+	return base64.StdEncoding.EncodeToString(s.envelope)
+}
+
+// MarshalJSON implements json.Marshaler, encoding the ciphertext.
+func (s SealedString) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting the form produced
+// by MarshalJSON.
+func (s *SealedString) UnmarshalJSON(data []byte) error {
+	var encoded string
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	return s.decode(encoded)
+}
+
+// MarshalYAML implements yaml.Marshaler, encoding the ciphertext.
+func (s SealedString) MarshalYAML() (interface{}, error) {
+	return s.String(), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler, accepting the form produced by
+// MarshalYAML.
+func (s *SealedString) UnmarshalYAML(node *yaml.Node) error {
+	var encoded string
+	if err := node.Decode(&encoded); err != nil {
+		return err
+	}
+	return s.decode(encoded)
+}
+
+// decode sets s from a base64-encoded Envelope, validating its structure.
+func (s *SealedString) decode(encoded string) error {
+	envelope, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEnvelope, err)
+	}
+	if _, err := ParseEnvelope(envelope); err != nil {
+		return err
+	}
+	s.envelope = envelope
+	return nil
+}
@@ -0,0 +1,70 @@
+package sensitivestring
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestSensitiveBytes_EncryptDecrypt verifies round trips and wrong-key failures
+func TestSensitiveBytes_EncryptDecrypt(t *testing.T) {
+	key := NewRandomBytes(32)
+	ciphertext, err := key.Encrypt([]byte("hunter2"))
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if bytes.Contains(ciphertext, []byte("hunter2")) {
+		t.Errorf("ciphertext contains the plaintext")
+	}
+	plaintext, err := key.Decrypt(ciphertext)
+	if err != nil || string(plaintext) != "hunter2" {
+		t.Errorf("Decrypt() = %q, %v, want hunter2", plaintext, err)
+	}
+	if _, err := NewRandomBytes(32).Decrypt(ciphertext); !errors.Is(err, ErrInvalidEnvelope) {
+		t.Errorf("Decrypt() with the wrong key error = %v, want ErrInvalidEnvelope", err)
+	}
+	if _, err := NewRandomBytes(16).Encrypt([]byte("x")); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Encrypt() with a 16-byte key error = %v, want ErrInvalidKey", err)
+	}
+}
+
+// TestSealedString verifies sealed secrets marshal as ciphertext and open with the key
+func TestSealedString(t *testing.T) {
+	key := NewRandomBytes(32)
+	sealed, err := NewSealedString(New("hunter2"), key)
+	if err != nil {
+		t.Fatalf("NewSealedString() error = %v", err)
+	}
+
+	data, err := json.Marshal(map[string]*SealedString{"password": sealed})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if strings.Contains(string(data), "hunter2") || strings.Contains(string(data), "sha256") {
+		t.Errorf("json.Marshal() = %s, want ciphertext", data)
+	}
+	var decoded map[string]*SealedString
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	opened, err := decoded["password"].Open(key)
+	if err != nil || opened.Value() != "hunter2" {
+		t.Errorf("Open() = %q, %v, want hunter2", opened.Value(), err)
+	}
+
+	out, _ := yaml.Marshal(sealed)
+	var fromYAML SealedString
+	if err := yaml.Unmarshal(out, &fromYAML); err != nil || !bytes.Equal(fromYAML.Bytes(), sealed.Bytes()) {
+		t.Errorf("YAML round trip = %v, error %v", fromYAML.String(), err)
+	}
+	if _, err := fromYAML.Open(NewRandomBytes(32)); !errors.Is(err, ErrInvalidEnvelope) {
+		t.Errorf("Open() with the wrong key error = %v, want ErrInvalidEnvelope", err)
+	}
+	if err := json.Unmarshal([]byte(`"bm90IGFuIGVudmVsb3Bl"`), &fromYAML); !errors.Is(err, ErrInvalidEnvelope) {
+		t.Errorf("json.Unmarshal(not an envelope) error = %v, want ErrInvalidEnvelope", err)
+	}
+}
@@ -0,0 +1,87 @@
+// Package securefmt mirrors the printing functions of fmt, but never emits
+// secrets: SensitiveString arguments render as their hash regardless of the
+// formatting verb, and the plaintext of every secret in the default
+// registry is scrubbed from the output, even when it arrives as a plain
+// string. It is intended as a drop-in replacement for fmt at call sites that
+// may handle credentials.
+package securefmt
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	ss "github.com/earlye/sensitive-strings/golang/ss"
+)
+
+// Sprintf is like fmt.Sprintf, with redaction.
+func Sprintf(format string, a ...interface{}) string {
+	return ss.Scrub(fmt.Sprintf(format, redact(a)...))
+}
+
+// Sprint is like fmt.Sprint, with redaction.
+func Sprint(a ...interface{}) string {
+	return ss.Scrub(fmt.Sprint(redact(a)...))
+}
+
+// Sprintln is like fmt.Sprintln, with redaction.
+func Sprintln(a ...interface{}) string {
+	return ss.Scrub(fmt.Sprintln(redact(a)...))
+}
+
+// Fprintf is like fmt.Fprintf, with redaction.
+func Fprintf(w io.Writer, format string, a ...interface{}) (int, error) {
+	return io.WriteString(w, Sprintf(format, a...))
+}
+
+// Fprint is like fmt.Fprint, with redaction.
+func Fprint(w io.Writer, a ...interface{}) (int, error) {
+	return io.WriteString(w, Sprint(a...))
+}
+
+// Fprintln is like fmt.Fprintln, with redaction.
+func Fprintln(w io.Writer, a ...interface{}) (int, error) {
+	return io.WriteString(w, Sprintln(a...))
+}
+
+// Printf is like fmt.Printf, with redaction.
+func Printf(format string, a ...interface{}) (int, error) {
+	return Fprintf(os.Stdout, format, a...)
+}
+
+// Print is like fmt.Print, with redaction.
+func Print(a ...interface{}) (int, error) {
+	return Fprint(os.Stdout, a...)
+}
+
+// Println is like fmt.Println, with redaction.
+func Println(a ...interface{}) (int, error) {
+	return Fprintln(os.Stdout, a...)
+}
+
+// Errorf is like fmt.Errorf, with redaction of the error message. Errors
+// wrapped with %w remain reachable through errors.Is and errors.As.
+func Errorf(format string, a ...interface{}) error {
+	return ss.DefaultRegistry().ScrubError(fmt.Errorf(format, redact(a)...))
+}
+
+// redact replaces SensitiveString arguments with their hash form, so that no
+// formatting verb can reach the underlying value.
+func redact(a []interface{}) []interface{} {
+	result := make([]interface{}, len(a))
+	for i, arg := range a {
+		switch v := arg.(type) {
+		case *ss.SensitiveString:
+			if v == nil {
+				result[i] = arg
+				continue
+			}
+			result[i] = v.String()
+		case ss.SensitiveString:
+			result[i] = v.String()
+		default:
+			result[i] = arg
+		}
+	}
+	return result
+}
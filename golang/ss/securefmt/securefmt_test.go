@@ -0,0 +1,78 @@
+package securefmt
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	ss "github.com/earlye/sensitive-strings/golang/ss"
+)
+
+// withRegistered registers secret in a fresh default registry for the duration of a test.
+func withRegistered(t *testing.T, secret *ss.SensitiveString) {
+	t.Helper()
+	previous := ss.DefaultRegistry()
+	ss.SetDefaultRegistry(ss.NewRegistry())
+	ss.Register(secret)
+	t.Cleanup(func() { ss.SetDefaultRegistry(previous) })
+}
+
+// TestSprintf_RedactsSecrets verifies every verb renders the hash
+func TestSprintf_RedactsSecrets(t *testing.T) {
+	secret := ss.New("hunter2")
+	for _, verb := range []string{"%s", "%v", "%+v", "%#v", "%q", "%x", "%d"} {
+		got := Sprintf(verb, secret)
+		if strings.Contains(got, "hunter2") || strings.Contains(got, "68756e74657232") {
+			t.Errorf("Sprintf(%s) leaked the value: %v", verb, got)
+		}
+	}
+}
+
+// TestSprintf_ScrubsRegistered verifies registered plaintext is scrubbed from plain strings
+func TestSprintf_ScrubsRegistered(t *testing.T) {
+	secret := ss.New("hunter2")
+	withRegistered(t, secret)
+
+	expected := "password=" + secret.String()
+	if got := Sprintf("password=%s", secret.Value()); got != expected {
+		t.Errorf("Sprintf() = %v, want %v", got, expected)
+	}
+	if got := Sprint("password=", secret.Value()); got != expected {
+		t.Errorf("Sprint() = %v, want %v", got, expected)
+	}
+	if got := Sprintln("password=" + secret.Value()); got != expected+"\n" {
+		t.Errorf("Sprintln() = %v, want %v", got, expected+"\n")
+	}
+
+	var buf bytes.Buffer
+	Fprintf(&buf, "a=%s ", secret.Value())
+	Fprint(&buf, "b=", secret.Value(), " ")
+	Fprintln(&buf, "c="+secret.Value())
+	if strings.Contains(buf.String(), "hunter2") {
+		t.Errorf("Fprint* leaked the value: %v", buf.String())
+	}
+}
+
+// TestErrorf verifies error messages are scrubbed and wrapping still works
+func TestErrorf(t *testing.T) {
+	secret := ss.New("hunter2")
+	withRegistered(t, secret)
+
+	err := Errorf("login with %s failed: %w", secret.Value(), io.EOF)
+	if got, expected := err.Error(), "login with "+secret.String()+" failed: EOF"; got != expected {
+		t.Errorf("Errorf() = %v, want %v", got, expected)
+	}
+	if !errors.Is(err, io.EOF) {
+		t.Errorf("Errorf() should wrap %%w arguments")
+	}
+
+	multi := Errorf("%w and %w", io.EOF, io.ErrClosedPipe)
+	if !errors.Is(multi, io.EOF) || !errors.Is(multi, io.ErrClosedPipe) {
+		t.Errorf("Errorf() should wrap multiple %%w arguments")
+	}
+	if errors.Unwrap(Errorf("plain")) != nil {
+		t.Errorf("Errorf() without %%w should not wrap anything")
+	}
+}
@@ -0,0 +1,91 @@
+package sensitivestring
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// SensitiveBytes holds binary secret material, such as encryption keys,
+// with the same redacted String, marshaling and logging behavior as
+// SensitiveString. The bytes are held in a package-owned buffer that Zero
+// overwrites. Its zero value is an empty secret.
+type SensitiveBytes struct {
+	s *SensitiveString
+}
+
+// NewBytes creates a new SensitiveBytes holding a copy of b. The caller may
+// zero b as soon as NewBytes returns.
+func NewBytes(b []byte) *SensitiveBytes {
+	return &SensitiveBytes{s: NewFromBytes(b)}
+}
+
+// secret returns the underlying SensitiveString, never nil.
+func (b *SensitiveBytes) secret() *SensitiveString {
+	if b == nil || b.s == nil {
+		return New("")
+	}
+	return b.s
+}
+
+// Bytes returns a copy of the secret bytes. The caller should clear the
+// copy once done with it; prefer Use, which makes no copy.
+func (b *SensitiveBytes) Bytes() []byte {
+	return b.secret().ValueBytes()
+}
+
+// Use calls fn with the secret bytes. fn must neither modify nor retain
+// the slice. See SensitiveString.Use.
+func (b *SensitiveBytes) Use(fn func(secret []byte)) {
+	b.secret().Use(fn)
+}
+
+// Len returns the number of secret bytes.
+func (b *SensitiveBytes) Len() int {
+	return b.secret().Len()
+}
+
+// Zero wipes the secret bytes. See SensitiveString.Zero.
+func (b *SensitiveBytes) Zero() {
+	if b != nil {
+		b.s.Zero()
+	}
+}
+
+// Equal reports whether b and other hold the same bytes, in constant time.
+// See SensitiveString.Equal.
+func (b *SensitiveBytes) Equal(other *SensitiveBytes) bool {
+	if b == nil || other == nil {
+		return b == other
+	}
+	return b.secret().Equal(other.secret())
+}
+
+// String returns the fingerprint of the bytes, implementing fmt.Stringer.
+func (b *SensitiveBytes) String() string {
+	return b.secret().String()
+}
+
+// GoString returns the fingerprint for %#v formatting.
+func (b *SensitiveBytes) GoString() string {
+	return fmt.Sprintf("sensitivestring.SensitiveBytes{value:%q}", b.String())
+}
+
+// Format implements fmt.Formatter like SensitiveString.Format.
+func (b *SensitiveBytes) Format(f fmt.State, verb rune) {
+	formatRedacted(f, verb, b.String(), b.GoString)
+}
+
+// MarshalJSON implements json.Marshaler like SensitiveString.MarshalJSON.
+func (b *SensitiveBytes) MarshalJSON() ([]byte, error) {
+	return b.secret().MarshalJSON()
+}
+
+// MarshalYAML implements yaml.Marshaler like SensitiveString.MarshalYAML.
+func (b *SensitiveBytes) MarshalYAML() (interface{}, error) {
+	return b.secret().MarshalYAML()
+}
+
+// LogValue implements slog.LogValuer like SensitiveString.LogValue.
+func (b *SensitiveBytes) LogValue() slog.Value {
+	return b.secret().LogValue()
+}
@@ -0,0 +1,43 @@
+package sensitivestring
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestSensitiveBytes verifies access to and redaction of binary secrets
+func TestSensitiveBytes(t *testing.T) {
+	src := []byte("foo")
+	b := NewBytes(src)
+	clear(src)
+	if got := b.Bytes(); !bytes.Equal(got, []byte("foo")) || b.Len() != 3 {
+		t.Errorf("Bytes() = %q, Len() = %d, want foo, 3", got, b.Len())
+	}
+	var used []byte
+	b.Use(func(secret []byte) { used = bytes.Clone(secret) })
+	if string(used) != "foo" {
+		t.Errorf("Use() got %q, want foo", used)
+	}
+	if !b.Equal(NewBytes([]byte("foo"))) || b.Equal(NewBytes([]byte("bar"))) {
+		t.Errorf("Equal() does not compare the bytes")
+	}
+
+	data, _ := json.Marshal(map[string]interface{}{"key": b})
+	for _, out := range []string{fmt.Sprintf("%v %s %d %#v", b, b, b, b), string(data)} {
+		if strings.Contains(out, "foo") || !strings.Contains(out, fooHashHex) {
+			t.Errorf("output = %s, want only the fingerprint", out)
+		}
+	}
+
+	b.Zero()
+	if b.Len() != 0 {
+		t.Errorf("Len() after Zero = %d, want 0", b.Len())
+	}
+	var zero SensitiveBytes
+	if zero.Len() != 0 || zero.String() != New("").String() {
+		t.Errorf("zero SensitiveBytes is not an empty secret")
+	}
+}
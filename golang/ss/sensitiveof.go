@@ -0,0 +1,167 @@
+package sensitivestring
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SensitiveOf wraps a structured secret of any type, such as a token
+// struct, a credential pair or a parsed JWK, and renders it like a
+// SensitiveString: String, GoString, marshaling and slog all emit a
+// fingerprint instead of the value. (Sensitive is the conversion function,
+// and compat.Secret is an alias of SensitiveString.)
+//
+// The fingerprint is the configured hash of the value's bytes: the value
+// itself for string and []byte, its JSON encoding otherwise. Use
+// WithFingerprint when the JSON encoding is not a stable identity for T,
+// e.g. when it contains timestamps or unexported state.
+type SensitiveOf[T any] struct {
+	value       T
+	label       string
+	fingerprint func(T) []byte
+}
+
+// NewSensitiveOf creates a new SensitiveOf holding value.
+func NewSensitiveOf[T any](value T) *SensitiveOf[T] {
+	return &SensitiveOf[T]{value: value}
+}
+
+// Value returns the wrapped value. Use this only when you explicitly need
+// access to the secret.
+func (s *SensitiveOf[T]) Value() T {
+	if s == nil {
+		var zero T
+		return zero
+	}
+	return s.value
+}
+
+// Label returns the label attached to the SensitiveOf, if any.
+func (s *SensitiveOf[T]) Label() string {
+	if s == nil {
+		return ""
+	}
+	return s.label
+}
+
+// WithLabel returns a copy of s carrying label. See NewLabeled.
+func (s *SensitiveOf[T]) WithLabel(label string) *SensitiveOf[T] {
+	if s == nil {
+		s = &SensitiveOf[T]{}
+	}
+	c := *s
+	c.label = label
+	return &c
+}
+
+// WithFingerprint returns a copy of s whose fingerprint is the hash of
+// fn(value). fn must be deterministic and should capture everything that
+// identifies the secret. A nil fn restores the default.
+func (s *SensitiveOf[T]) WithFingerprint(fn func(T) []byte) *SensitiveOf[T] {
+	if s == nil {
+		s = &SensitiveOf[T]{}
+	}
+	c := *s
+	c.fingerprint = fn
+	return &c
+}
+
+// fingerprintBytes returns the bytes the fingerprint is computed over.
+func (s SensitiveOf[T]) fingerprintBytes() []byte {
+	if s.fingerprint != nil {
+		return s.fingerprint(s.value)
+	}
+	switch v := any(s.value).(type) {
+	case string:
+		return []byte(v)
+	case []byte:
+		return v
+	}
+	if data, err := json.Marshal(s.value); err == nil {
+		return data
+	}
+	return fmt.Appendf(nil, "%#v", s.value)
+}
+
+// Redacted returns the structured representation of the value. Len is
+// always zero, since T has no meaningful length.
+// Uses a value receiver so it is callable on both value and pointer types.
+func (s SensitiveOf[T]) Redacted() Redacted {
+	alg, sum := digestOf("", s.fingerprintBytes())
+	return Redacted{Alg: alg, Hash: hex.EncodeToString(sum), Label: s.label}
+}
+
+// String returns the fingerprint, e.g. "sha256:<hex>", implementing
+// fmt.Stringer. The package-wide Redactor, if any, is not applied, since it
+// works on string plaintext.
+// Uses a value receiver so it is callable on both value and pointer types.
+func (s SensitiveOf[T]) String() string {
+	r := s.Redacted()
+	return r.Alg + ":" + r.Hash
+}
+
+// GoString returns the fingerprint for %#v formatting.
+// Uses a value receiver so it is callable on both value and pointer types.
+func (s SensitiveOf[T]) GoString() string {
+	return fmt.Sprintf("sensitivestring.SensitiveOf[%T]{value:%q}", s.value, s.String())
+}
+
+// Format implements fmt.Formatter like SensitiveString.Format.
+// Uses a value receiver so it is callable on both value and pointer types.
+func (s SensitiveOf[T]) Format(f fmt.State, verb rune) {
+	formatRedacted(f, verb, s.String(), s.GoString)
+}
+
+// marshalValue returns the value MarshalJSON and MarshalYAML should encode
+// for the current MarshalMode.
+func (s SensitiveOf[T]) marshalValue() interface{} {
+	if loadConfig().marshalMode == MarshalStructured {
+		return s.Redacted()
+	}
+	return s.String()
+}
+
+// MarshalJSON implements json.Marshaler, emitting the fingerprint instead
+// of the value.
+// Uses a value receiver so it is callable on both value and pointer types.
+func (s SensitiveOf[T]) MarshalJSON() ([]byte, error) {
+	recordRedaction(FormatJSON, s.label, 1)
+	return json.Marshal(s.marshalValue())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding the plaintext value
+// of T, e.g. from a configuration file. It is refused in DecodeRedacted
+// mode.
+func (s *SensitiveOf[T]) UnmarshalJSON(data []byte) error {
+	if loadConfig().decodeMode == DecodeRedacted {
+		return fmt.Errorf("%w: expected a redacted value", ErrPlaintextRefused)
+	}
+	return json.Unmarshal(data, &s.value)
+}
+
+// MarshalYAML implements yaml.Marshaler, emitting the fingerprint instead
+// of the value.
+// Uses a value receiver so it is callable on both value and pointer types.
+func (s SensitiveOf[T]) MarshalYAML() (interface{}, error) {
+	recordRedaction(FormatYAML, s.label, 1)
+	return s.marshalValue(), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler like UnmarshalJSON.
+func (s *SensitiveOf[T]) UnmarshalYAML(node *yaml.Node) error {
+	if loadConfig().decodeMode == DecodeRedacted {
+		return fmt.Errorf("%w: expected a redacted value", ErrPlaintextRefused)
+	}
+	return node.Decode(&s.value)
+}
+
+// LogValue implements slog.LogValuer, returning the fingerprint.
+// Uses a value receiver so it is callable on both value and pointer types.
+func (s SensitiveOf[T]) LogValue() slog.Value {
+	recordRedaction(FormatSlog, s.label, 1)
+	return slog.StringValue(s.String())
+}
@@ -0,0 +1,84 @@
+package sensitivestring
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+type credentialPair struct {
+	ID     string `json:"id"`
+	Secret string `json:"secret"`
+}
+
+// TestSensitiveOf_Redacts verifies every rendering of a structured secret hides it
+func TestSensitiveOf_Redacts(t *testing.T) {
+	s := NewSensitiveOf(credentialPair{ID: "client", Secret: "hunter2"})
+	if got := s.Value().Secret; got != "hunter2" {
+		t.Errorf("Value().Secret = %v, want hunter2", got)
+	}
+
+	data, _ := json.Marshal(credentialPair{ID: "client", Secret: "hunter2"})
+	want := New(string(data)).String()
+	if got := s.String(); got != want {
+		t.Errorf("String() = %v, want %v", got, want)
+	}
+
+	jsonOut, err := json.Marshal(struct{ Creds *SensitiveOf[credentialPair] }{s})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	yamlOut, err := yaml.Marshal(map[string]interface{}{"creds": s})
+	if err != nil {
+		t.Fatalf("yaml.Marshal() error = %v", err)
+	}
+	for _, out := range []string{fmt.Sprintf("%v %+v %#v %s", s, *s, s, s), string(jsonOut), string(yamlOut), s.LogValue().String()} {
+		if strings.Contains(out, "hunter2") {
+			t.Errorf("output leaks the secret: %s", out)
+		}
+	}
+}
+
+// TestSensitiveOf_Fingerprint verifies string values hash like SensitiveString and custom fingerprints
+func TestSensitiveOf_Fingerprint(t *testing.T) {
+	if got := NewSensitiveOf("foo").String(); got != "sha256:"+fooHashHex {
+		t.Errorf("NewSensitiveOf(string).String() = %v, want %v", got, "sha256:"+fooHashHex)
+	}
+
+	byID := func(c credentialPair) []byte { return []byte(c.ID) }
+	a := NewSensitiveOf(credentialPair{ID: "foo", Secret: "one"}).WithFingerprint(byID)
+	b := NewSensitiveOf(credentialPair{ID: "foo", Secret: "two"}).WithFingerprint(byID)
+	if a.String() != b.String() || a.String() != "sha256:"+fooHashHex {
+		t.Errorf("custom fingerprints = %v, %v, want both sha256 of the ID", a, b)
+	}
+
+	labeled := a.WithLabel("oauth")
+	if got := labeled.Redacted().Label; got != "oauth" || a.Label() != "" {
+		t.Errorf("WithLabel() label = %v, original = %v", got, a.Label())
+	}
+}
+
+// TestSensitiveOf_Unmarshal verifies plaintext decoding and DecodeRedacted refusal
+func TestSensitiveOf_Unmarshal(t *testing.T) {
+	var s SensitiveOf[credentialPair]
+	if err := json.Unmarshal([]byte(`{"id":"client","secret":"hunter2"}`), &s); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if got := s.Value(); got != (credentialPair{ID: "client", Secret: "hunter2"}) {
+		t.Errorf("Value() = %+v", got)
+	}
+
+	var y SensitiveOf[[]string]
+	if err := yaml.Unmarshal([]byte("[a, b]"), &y); err != nil || len(y.Value()) != 2 {
+		t.Errorf("yaml.Unmarshal() = %v, error %v", y.Value(), err)
+	}
+
+	withDecodeMode(t, DecodeRedacted)
+	if err := json.Unmarshal([]byte(`{}`), &s); !errors.Is(err, ErrPlaintextRefused) {
+		t.Errorf("json.Unmarshal() in DecodeRedacted mode error = %v, want ErrPlaintextRefused", err)
+	}
+}
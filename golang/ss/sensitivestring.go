@@ -1,32 +1,57 @@
 package sensitivestring
 
 import (
-	"crypto/sha256"
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
+
+	"gopkg.in/yaml.v3"
 )
 
 // SensitiveString wraps a string value and prevents accidental serialization
-// of secrets by returning a SHA256 hash instead of the raw value.
+// of secrets by returning a hash of it instead of the raw value.
+//
+// fmt prints unexported struct fields without calling their methods, so
+// an unexported SensitiveString field is printed raw, as is an unexported
+// *SensitiveString under verbs such as %s. Use SensitiveValue for
+// unexported fields.
 type SensitiveString struct {
-	value string
+	value    string
+	label    string
+	digest   *digestCache
+	store    storage
+	redactor Redactor
+	hashAlg  HashAlgorithm
 }
 
-// New creates a new SensitiveString from the given value.
-func New(value string) *SensitiveString {
-	return &SensitiveString{value: value}
+// New creates a new SensitiveString holding a package-owned copy of value,
+// applying opts in order.
+func New(value string, opts ...Option) *SensitiveString {
+	s := &SensitiveString{}
+	s.setPlaintext(value)
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
-// String returns the SHA256 hash of the value, implementing fmt.Stringer.
+// String returns the fingerprint of the value, "sha256:<hex>" by default,
+// implementing fmt.Stringer. See SetHashAlgorithm for other digests,
+// UseProcessSalt and SetFingerprintKey for keyed ones, and SetRedactor for
+// custom renderings.
 // This prevents accidental exposure in logs, string concatenation, etc.
 // Uses a value receiver so it is callable on both value and pointer types.
 func (s SensitiveString) String() string {
-	hash := sha256.Sum256([]byte(s.value))
-	return fmt.Sprintf("sha256:%x", hash)
+	if r := s.activeRedactor(); r != nil {
+		return r.Redact(s.plaintext())
+	}
+	alg, hash := s.hashHex()
+	return alg + ":" + hash
 }
 
-// GoString returns the SHA256 hash representation for %#v formatting.
+// GoString returns the fingerprint representation for %#v formatting.
 // This implements fmt.GoStringer to prevent accidental exposure even when
 // using Go-syntax formatting for debugging.
 // Uses a value receiver so it is callable on both value and pointer types.
@@ -34,23 +59,82 @@ func (s SensitiveString) GoString() string {
 	return fmt.Sprintf("sensitivestring.SensitiveString{value:%q}", s.String())
 }
 
+// Format implements fmt.Formatter so that every verb, not only those fmt
+// routes to String, renders the redacted form: %d, %x or %q on a
+// SensitiveString, or on a struct holding one, never print the plaintext.
+// Flags, width and precision apply to the redacted form.
+// Uses a value receiver so it is callable on both value and pointer types.
+func (s SensitiveString) Format(f fmt.State, verb rune) {
+	formatRedacted(f, verb, s.String(), s.GoString)
+}
+
+// formatRedacted writes redacted to f for verb, or goString() for %#v.
+// Verbs that have a meaning for strings keep it; any other verb is treated
+// as %s.
+func formatRedacted(f fmt.State, verb rune, redacted string, goString func() string) {
+	switch verb {
+	case 'v':
+		if f.Flag('#') {
+			io.WriteString(f, goString())
+			return
+		}
+	case 's', 'q', 'x', 'X':
+	default:
+		verb = 's'
+	}
+	fmt.Fprintf(f, fmt.FormatString(f, verb), redacted)
+}
+
 // Value returns the raw plaintext value. Use this only when you explicitly
 // need access to the secret value.
 func (s *SensitiveString) Value() string {
 	if s == nil {
 		return ""
 	}
-	return s.value
+	return s.plaintext()
+}
+
+// ValueBytes returns a copy of the raw plaintext value as a byte slice,
+// which, unlike the string returned by Value, the caller can overwrite
+// (e.g. with clear) once done with it.
+func (s *SensitiveString) ValueBytes() []byte {
+	if s == nil {
+		return nil
+	}
+	if b, ok := s.store.(*byteStorage); ok {
+		var value []byte
+		b.open(func(p []byte) { value = bytes.Clone(p) })
+		return value
+	}
+	return []byte(s.plaintext())
 }
 
 // PValue returns a pointer to the raw plaintext value. Use this when you
 // need to pass plaintext value to a function that expects a string pointer.
-// Common example is for Cobra string arguments.
+// Common example is for Cobra string arguments. Writes through the pointer
+// change the secret; PValue is Bind under its original name.
 func (s *SensitiveString) PValue() *string {
+	return s.Bind()
+}
+
+// Bind returns a pointer to a string holding the value and makes that
+// string the value, so later writes through the pointer change the secret.
+// Common example is for Cobra string arguments:
+//
+//	cmd.Flags().StringVar(secret.Bind(), "password", "", "database password")
+//
+// The secret then lives in an ordinary Go string, which Zero cannot
+// overwrite. Like Set, Bind must not be called concurrently with other
+// uses of s.
+func (s *SensitiveString) Bind() *string {
 	if s == nil {
 		return nil
 	}
-	return &s.value
+	value := s.plaintext()
+	s.value = ""
+	s.store = boundStorage{p: &value}
+	s.digest = nil
+	return &value
 }
 
 // Len returns the length of the underlying value without exposing it.
@@ -58,42 +142,112 @@ func (s *SensitiveString) Len() int {
 	if s == nil {
 		return 0
 	}
-	return len(s.value)
+	return s.size()
 }
 
 // MarshalJSON implements json.Marshaler, returning the SHA256 hash instead
 // of the raw value to prevent accidental serialization of secrets.
+// See SetMarshalMode for the structured alternative.
 // Uses a value receiver so it is callable on both value and pointer types.
 func (s SensitiveString) MarshalJSON() ([]byte, error) {
-	return json.Marshal(s.String())
+	recordRedaction(FormatJSON, s.label, 1)
+	return json.Marshal(s.marshalValue())
 }
 
 // UnmarshalJSON implements json.Unmarshaler.
 // Note: This unmarshals the SHA256 hash, not the original value.
 // This is intentional - you cannot recover the original value from the hash.
+// A structured Redacted object is accepted as well; its "alg:hash" form
+// becomes the value and its label is preserved. SetUnmarshalCompat enables
+// the forms written by other secret-wrapper libraries. By default any
+// string is stored as the value; SetDecodeMode selects whether plaintext or
+// redacted input is expected.
 func (s *SensitiveString) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		if value, ok, err := unmarshalCompatObject(trimmed); err != nil {
+			return err
+		} else if ok {
+			return s.decodePlaintext(value)
+		}
+		var r Redacted
+		if err := json.Unmarshal(trimmed, &r); err != nil {
+			return err
+		}
+		return s.decodeRedacted(r)
+	}
 	var str string
 	if err := json.Unmarshal(data, &str); err != nil {
 		return err
 	}
-	s.value = str
-	return nil
+	return s.decodeString(str)
 }
 
 // MarshalYAML implements yaml.Marshaler, returning the SHA256 hash instead
 // of the raw value to prevent accidental serialization of secrets.
+// See SetMarshalMode for the structured alternative.
 // Uses a value receiver so it is callable on both value and pointer types.
 func (s SensitiveString) MarshalYAML() (interface{}, error) {
-	return s.String(), nil
+	recordRedaction(FormatYAML, s.label, 1)
+	return s.marshalValue(), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler (yaml.v3), so configs can load
+// secrets directly into SensitiveString fields. A scalar is taken as the
+// plaintext to wrap, subject to the DecodeMode. A mapping in the Redacted form is accepted as well;
+// its "alg:hash" form becomes the value and its label is preserved.
+func (s *SensitiveString) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		var str string
+		if err := node.Decode(&str); err != nil {
+			return err
+		}
+		return s.decodeString(str)
+	case yaml.MappingNode:
+		var r Redacted
+		if err := node.Decode(&r); err != nil {
+			return err
+		}
+		return s.decodeRedacted(r)
+	case yaml.AliasNode:
+		return s.UnmarshalYAML(node.Alias)
+	}
+	return fmt.Errorf("sensitivestring: cannot unmarshal YAML %s into a SensitiveString", node.ShortTag())
 }
 
 // LogValue implements slog.LogValuer, returning the SHA256 hash so that
-// slog never logs the plaintext value regardless of handler type.
+// slog never logs the plaintext value regardless of handler type. In
+// MarshalStructured mode it returns a group with the Redacted fields
+// instead. slog resolves LogValuers inside groups and With attributes as
+// well, so secrets nested there are covered too.
 // Uses a value receiver so it is callable on both value and pointer types.
 func (s SensitiveString) LogValue() slog.Value {
+	recordRedaction(FormatSlog, s.label, 1)
+	if r, ok := s.RedactedForm(); ok && loadConfig().marshalMode == MarshalStructured {
+		attrs := []slog.Attr{
+			slog.String("alg", r.Alg),
+			slog.String("hash", r.Hash),
+		}
+		if r.Len != 0 {
+			attrs = append(attrs, slog.Int("len", r.Len))
+		}
+		if r.Label != "" {
+			attrs = append(attrs, slog.String("label", r.Label))
+		}
+		return slog.GroupValue(attrs...)
+	}
 	return slog.StringValue(s.String())
 }
 
+// MarshalLog implements logr.Marshaler, which klog honors as well, so
+// controller-runtime and klog structured logging render the same form as
+// MarshalJSON rather than the plaintext.
+// Uses a value receiver so it is callable on both value and pointer types.
+func (s SensitiveString) MarshalLog() interface{} {
+	recordRedaction(FormatLogr, s.label, 1)
+	return s.marshalValue()
+}
+
 // IsSensitiveString returns true if the input is a *SensitiveString.
 func IsSensitiveString(input interface{}) bool {
 	if input == nil {
@@ -175,26 +329,63 @@ func Sensitive(input interface{}) *SensitiveString {
 //	// To get plaintext:
 //	result := sensitivestring.PlaintextReplacer(data)
 //	json.Marshal(result) // password will be "secret123"
+//
+// Subtrees that contain no SensitiveString are returned as-is rather than
+// copied, so the result may share maps and slices with data. Treat the
+// result as read-only. MarshalJSON(data, Reveal) does both steps at once
+// and also reaches SensitiveStrings inside structs.
 func PlaintextReplacer(data interface{}) interface{} {
+	result, _ := replacePlaintext(data)
+	return result
+}
+
+// replacePlaintext implements PlaintextReplacer, reporting whether anything
+// was replaced so that unchanged maps and slices can be reused instead of
+// copied.
+func replacePlaintext(data interface{}) (interface{}, bool) {
 	switch v := data.(type) {
 	case *SensitiveString:
 		if v == nil {
-			return nil
+			return nil, true
 		}
-		return v.Value()
+		return v.Value(), true
 	case map[string]interface{}:
-		result := make(map[string]interface{}, len(v))
+		var result map[string]interface{}
 		for key, val := range v {
-			result[key] = PlaintextReplacer(val)
+			replaced, changed := replacePlaintext(val)
+			if !changed {
+				continue
+			}
+			if result == nil {
+				result = make(map[string]interface{}, len(v))
+				for k, original := range v {
+					result[k] = original
+				}
+			}
+			result[key] = replaced
 		}
-		return result
+		if result == nil {
+			return data, false
+		}
+		return result, true
 	case []interface{}:
-		result := make([]interface{}, len(v))
+		var result []interface{}
 		for i, val := range v {
-			result[i] = PlaintextReplacer(val)
+			replaced, changed := replacePlaintext(val)
+			if !changed {
+				continue
+			}
+			if result == nil {
+				result = make([]interface{}, len(v))
+				copy(result, v)
+			}
+			result[i] = replaced
+		}
+		if result == nil {
+			return data, false
 		}
-		return result
+		return result, true
 	default:
-		return v
+		return data, false
 	}
 }
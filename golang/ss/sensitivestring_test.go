@@ -224,6 +224,35 @@ func Test455A1E09_StringFormatting(t *testing.T) {
 	}
 }
 
+// TestFormat_AllVerbs verifies every verb, flag and width renders the redacted form
+func TestFormat_AllVerbs(t *testing.T) {
+	ss := New("foo")
+	holder := struct{ Secret SensitiveString }{*ss}
+	hexSecret := fmt.Sprintf("%x", "foo")
+	for _, format := range []string{"%d", "%x", "%X", "% x", "%c", "%U", "%t", "%08.3f", "%10s", "%-8v", "%+q"} {
+		for _, arg := range []interface{}{ss, *ss, holder, &holder} {
+			result := fmt.Sprintf(format, arg)
+			if strings.Contains(result, "foo") || strings.Contains(result, hexSecret) || strings.Contains(result, "102") {
+				t.Errorf("fmt.Sprintf(%q, %T) leaked raw value: %v", format, arg, result)
+			}
+		}
+	}
+
+	redacted := "sha256:" + fooHashHex
+	if got, want := fmt.Sprintf("%d", ss), redacted; got != want {
+		t.Errorf("fmt.Sprintf(%%d) = %v, want %v", got, want)
+	}
+	if got, want := fmt.Sprintf("%.6s|%12.3v|", ss, ss), "sha256|         sha|"; got != want {
+		t.Errorf("fmt.Sprintf(precision and width) = %v, want %v", got, want)
+	}
+	if got, want := fmt.Sprintf("%x", ss), fmt.Sprintf("%x", redacted); got != want {
+		t.Errorf("fmt.Sprintf(%%x) = %v, want %v", got, want)
+	}
+	if got, want := fmt.Sprintf("%#v", ss), ss.GoString(); got != want {
+		t.Errorf("fmt.Sprintf(%%#v) = %v, want %v", got, want)
+	}
+}
+
 // Test458ECC56_StructSerialization verifies struct fields don't leak
 func Test458ECC56_StructSerialization(t *testing.T) {
 	type Credentials struct {
@@ -290,6 +319,60 @@ func TestC9D43D4F_YAML(t *testing.T) {
 	}
 }
 
+// TestUnmarshalYAML verifies YAML configs load plaintext into SensitiveString fields
+func TestUnmarshalYAML(t *testing.T) {
+	type Config struct {
+		Username string           `yaml:"username"`
+		Password *SensitiveString `yaml:"password"`
+		Port     SensitiveString  `yaml:"port"`
+		Token    *SensitiveString `yaml:"token"`
+		Replica  *SensitiveString `yaml:"replica"`
+		Redacted *SensitiveString `yaml:"redacted"`
+		Missing  *SensitiveString `yaml:"missing"`
+	}
+	input := `
+username: alice
+password: &pw "hunter2"
+port: 5432
+token: |
+  multi
+  line
+replica: *pw
+redacted: {alg: sha256, hash: abc123, len: 7, label: db}
+`
+	var config Config
+	if err := yaml.Unmarshal([]byte(input), &config); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+	tests := []struct {
+		name string
+		got  *SensitiveString
+		want string
+	}{
+		{"password", config.Password, "hunter2"},
+		{"port", &config.Port, "5432"},
+		{"token", config.Token, "multi\nline\n"},
+		{"replica", config.Replica, "hunter2"},
+		{"redacted", config.Redacted, "sha256:abc123"},
+	}
+	for _, tt := range tests {
+		if tt.got.Value() != tt.want {
+			t.Errorf("%s = %q, want %q", tt.name, tt.got.Value(), tt.want)
+		}
+	}
+	if config.Redacted.Label() != "db" {
+		t.Errorf("redacted label = %q, want db", config.Redacted.Label())
+	}
+	if config.Missing != nil {
+		t.Errorf("missing = %v, want nil", config.Missing)
+	}
+
+	var s SensitiveString
+	if err := yaml.Unmarshal([]byte("[a, b]"), &s); err == nil {
+		t.Errorf("yaml.Unmarshal(sequence) error = nil, want error")
+	}
+}
+
 // Test404A48D7_YAML verifies PlaintextReplacer with YAML
 func Test404A48D7_YAML(t *testing.T) {
 	obj := map[string]interface{}{
@@ -387,6 +470,27 @@ func TestPValue_SetValue_b3f7a92c(t *testing.T) {
 	}
 }
 
+// TestBind verifies writes through Bind() reach the secret and its hash
+func TestBind(t *testing.T) {
+	ss := New("initial")
+	p := ss.Bind()
+	if *p != "initial" {
+		t.Errorf("*Bind() = %q, want %q", *p, "initial")
+	}
+
+	*p = "foo"
+
+	if got := ss.Value(); got != "foo" {
+		t.Errorf("Value() after *Bind() = %q, want %q", got, "foo")
+	}
+	if got := ss.String(); got != "sha256:"+fooHashHex {
+		t.Errorf("String() after *Bind() = %v, want %v", got, "sha256:"+fooHashHex)
+	}
+	if (*SensitiveString)(nil).Bind() != nil {
+		t.Errorf("nil Bind() != nil")
+	}
+}
+
 // TestPlaintextReplacer_Nil verifies PlaintextReplacer handles nil correctly
 func TestPlaintextReplacer_Nil(t *testing.T) {
 	var ss *SensitiveString
@@ -508,3 +612,119 @@ func TestSlog_JSONHandler_ValueStructField(t *testing.T) {
 		t.Errorf("JSONHandler (value struct field) leaked plaintext: %s", buf.String())
 	}
 }
+
+// TestSlog_Groups verifies secrets nested in groups and With attributes are redacted.
+func TestSlog_Groups(t *testing.T) {
+	for name, newHandler := range map[string]func(*bytes.Buffer) slog.Handler{
+		"text": func(b *bytes.Buffer) slog.Handler { return slog.NewTextHandler(b, nil) },
+		"json": func(b *bytes.Buffer) slog.Handler { return slog.NewJSONHandler(b, nil) },
+	} {
+		var buf bytes.Buffer
+		logger := slog.New(newHandler(&buf)).With("api", New(slogTestPlaintext)).WithGroup("req")
+		logger.Info("test",
+			slog.Group("db", "password", New(slogTestPlaintext), slog.Group("replica", "password", SensitiveString{value: slogTestPlaintext})),
+			slog.Any("nested", slog.GroupValue(slog.Any("token", New(slogTestPlaintext)))),
+		)
+		if strings.Contains(buf.String(), slogTestPlaintext) {
+			t.Errorf("%s handler (groups) leaked plaintext: %s", name, buf.String())
+		}
+		if got := strings.Count(buf.String(), New(slogTestPlaintext).String()); got != 4 {
+			t.Errorf("%s handler (groups) rendered %d hashes, want 4: %s", name, got, buf.String())
+		}
+	}
+}
+
+// TestSlog_StructuredMode verifies LogValue emits the Redacted fields in MarshalStructured mode.
+func TestSlog_StructuredMode(t *testing.T) {
+	withMarshalMode(t, MarshalStructured)
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Info("test", "password", NewLabeled("db", "secret"))
+
+	var record struct {
+		Password Redacted `json:"password"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if want := NewLabeled("db", "secret").Redacted(); record.Password != want {
+		t.Errorf("password = %+v, want %+v", record.Password, want)
+	}
+}
+
+// TestMarshalLog verifies MarshalLog returns the marshaled form, never the plaintext.
+func TestMarshalLog(t *testing.T) {
+	secret := NewLabeled("db", "secret")
+	if got := secret.MarshalLog(); got != secret.String() {
+		t.Errorf("MarshalLog() = %v, want %v", got, secret.String())
+	}
+
+	withMarshalMode(t, MarshalStructured)
+	if got := secret.MarshalLog(); got != secret.Redacted() {
+		t.Errorf("MarshalLog() = %v, want %v", got, secret.Redacted())
+	}
+}
+
+// TestPlaintextReplacer_ReusesUnchanged verifies subtrees without secrets are not copied
+func TestPlaintextReplacer_ReusesUnchanged(t *testing.T) {
+	plain := map[string]interface{}{"host": "db", "port": 5432}
+	list := []interface{}{"a", "b"}
+	obj := map[string]interface{}{
+		"plain":    plain,
+		"list":     list,
+		"password": New("secret"),
+	}
+
+	result := PlaintextReplacer(obj).(map[string]interface{})
+
+	if result["password"] != "secret" {
+		t.Errorf("PlaintextReplacer password = %v, want secret", result["password"])
+	}
+	if _, ok := obj["password"].(*SensitiveString); !ok {
+		t.Errorf("PlaintextReplacer modified its input")
+	}
+	if fmt.Sprintf("%p", result["plain"]) != fmt.Sprintf("%p", plain) {
+		t.Errorf("PlaintextReplacer copied a map containing no secrets")
+	}
+	if &result["list"].([]interface{})[0] != &list[0] {
+		t.Errorf("PlaintextReplacer copied a slice containing no secrets")
+	}
+
+	untouched := map[string]interface{}{"plain": plain}
+	if fmt.Sprintf("%p", PlaintextReplacer(untouched)) != fmt.Sprintf("%p", untouched) {
+		t.Errorf("PlaintextReplacer copied a tree containing no secrets")
+	}
+}
+
+// benchmarkConfigTree builds a config-like tree of the given breadth with an
+// optional secret in a single leaf.
+func benchmarkConfigTree(breadth int, withSecret bool) map[string]interface{} {
+	root := make(map[string]interface{}, breadth)
+	for i := 0; i < breadth; i++ {
+		section := make(map[string]interface{}, breadth)
+		for j := 0; j < breadth; j++ {
+			section[fmt.Sprintf("key%d", j)] = []interface{}{"value", j, true}
+		}
+		root[fmt.Sprintf("section%d", i)] = section
+	}
+	if withSecret {
+		root["section0"].(map[string]interface{})["password"] = New("secret")
+	}
+	return root
+}
+
+func BenchmarkPlaintextReplacer_NoSecrets(b *testing.B) {
+	tree := benchmarkConfigTree(100, false)
+	b.ReportAllocs()
+	for b.Loop() {
+		PlaintextReplacer(tree)
+	}
+}
+
+func BenchmarkPlaintextReplacer_OneSecret(b *testing.B) {
+	tree := benchmarkConfigTree(100, true)
+	b.ReportAllocs()
+	for b.Loop() {
+		PlaintextReplacer(tree)
+	}
+}
@@ -0,0 +1,77 @@
+// Package sensitivestringpb carries SensitiveString references in protobuf
+// messages, so gRPC services can expose redacted secrets in their APIs.
+// The message holds the fingerprint and, optionally, the secret sealed
+// under a key shared out of band.
+package sensitivestringpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative sensitivestring.proto
+
+import (
+	"errors"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	ss "github.com/earlye/sensitive-strings/golang/ss"
+)
+
+// ErrNoCiphertext is returned by Open for a message carrying only a
+// fingerprint.
+var ErrNoCiphertext = errors.New("sensitivestringpb: message has no ciphertext")
+
+// ToProto returns the fingerprint of s as a message. The plaintext is not
+// included.
+func ToProto(s *ss.SensitiveString) *SensitiveString {
+	if s == nil {
+		s = ss.New("")
+	}
+	r := s.Redacted()
+	return &SensitiveString{Alg: r.Alg, Hash: r.Hash, Len: uint32(r.Len), Label: r.Label}
+}
+
+// ToProtoSealed is like ToProto, and also seals the plaintext under key
+// (see SensitiveString.Seal) into the ciphertext field.
+func ToProtoSealed(s *ss.SensitiveString, key []byte) (*SensitiveString, error) {
+	m := ToProto(s)
+	sealed, err := s.Seal(key)
+	if err != nil {
+		return nil, err
+	}
+	m.Ciphertext = sealed
+	return m, nil
+}
+
+// FromProto returns the secret referenced by m in its redacted form: like
+// UnmarshalJSON of a structured value, the "alg:hash" string becomes the
+// value and the label is preserved.
+func FromProto(m *SensitiveString) *ss.SensitiveString {
+	return ss.NewLabeled(m.GetLabel(), m.GetAlg()+":"+m.GetHash())
+}
+
+// Open decrypts the ciphertext of m with key, returning the secret
+// labeled with the message's label.
+func Open(m *SensitiveString, key []byte) (*ss.SensitiveString, error) {
+	if len(m.GetCiphertext()) == 0 {
+		return nil, ErrNoCiphertext
+	}
+	opened, err := ss.Unseal(m.GetCiphertext(), key)
+	if err != nil {
+		return nil, err
+	}
+	return ss.NewLabeled(m.GetLabel(), opened.Value()), nil
+}
+
+// MarshalJSON encodes the fingerprint of s with protojson. The result has
+// the same shape as s in ss.MarshalStructured mode.
+func MarshalJSON(s *ss.SensitiveString) ([]byte, error) {
+	return protojson.Marshal(ToProto(s))
+}
+
+// UnmarshalJSON decodes protojson written by MarshalJSON, or a structured
+// SensitiveString, into its redacted form.
+func UnmarshalJSON(data []byte) (*ss.SensitiveString, error) {
+	var m SensitiveString
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return FromProto(&m), nil
+}
@@ -0,0 +1,75 @@
+package sensitivestringpb
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	ss "github.com/earlye/sensitive-strings/golang/ss"
+)
+
+// TestToProto verifies only the fingerprint is carried
+func TestToProto(t *testing.T) {
+	secret := ss.NewLabeled("db", "hunter2")
+	data, err := proto.Marshal(ToProto(secret))
+	if err != nil {
+		t.Fatalf("proto.Marshal() error = %v", err)
+	}
+	if bytes.Contains(data, []byte("hunter2")) {
+		t.Errorf("proto encoding contains plaintext")
+	}
+
+	var m SensitiveString
+	if err := proto.Unmarshal(data, &m); err != nil {
+		t.Fatalf("proto.Unmarshal() error = %v", err)
+	}
+	if got := FromProto(&m); got.Value() != secret.String() || got.Label() != "db" {
+		t.Errorf("FromProto() = %v/%v, want %v/db", got.Value(), got.Label(), secret.String())
+	}
+	if _, err := Open(&m, make([]byte, 32)); !errors.Is(err, ErrNoCiphertext) {
+		t.Errorf("Open() error = %v, want ErrNoCiphertext", err)
+	}
+}
+
+// TestToProtoSealed verifies the sealed secret opens with the right key only
+func TestToProtoSealed(t *testing.T) {
+	key := make([]byte, 32)
+	rand.Read(key)
+	m, err := ToProtoSealed(ss.NewLabeled("db", "hunter2"), key)
+	if err != nil {
+		t.Fatalf("ToProtoSealed() error = %v", err)
+	}
+	if bytes.Contains(m.GetCiphertext(), []byte("hunter2")) {
+		t.Errorf("ciphertext contains plaintext")
+	}
+
+	opened, err := Open(m, key)
+	if err != nil || opened.Value() != "hunter2" || opened.Label() != "db" {
+		t.Errorf("Open() = %v/%v, %v, want hunter2/db", opened.Value(), opened.Label(), err)
+	}
+	if _, err := Open(m, make([]byte, 32)); !errors.Is(err, ss.ErrInvalidEnvelope) {
+		t.Errorf("Open(wrong key) error = %v, want ErrInvalidEnvelope", err)
+	}
+}
+
+// TestMarshalJSON verifies protojson output matches the structured form
+func TestMarshalJSON(t *testing.T) {
+	secret := ss.NewLabeled("db", "hunter2")
+	data, err := MarshalJSON(secret)
+	if err != nil {
+		t.Fatalf("MarshalJSON() error = %v", err)
+	}
+	var r ss.Redacted
+	if err := json.Unmarshal(data, &r); err != nil || r != secret.Redacted() {
+		t.Errorf("MarshalJSON() = %s, want the structured form %+v", data, secret.Redacted())
+	}
+
+	got, err := UnmarshalJSON(data)
+	if err != nil || got.Value() != secret.String() || got.Label() != "db" {
+		t.Errorf("UnmarshalJSON() = %v/%v, %v, want %v/db", got.Value(), got.Label(), err, secret.String())
+	}
+}
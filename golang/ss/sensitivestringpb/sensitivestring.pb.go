@@ -0,0 +1,169 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: sensitivestring.proto
+
+package sensitivestringpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SensitiveString is a reference to a secret that never carries its
+// plaintext. The fingerprint fields match the structured JSON form of a
+// SensitiveString, so protojson output lines up with MarshalStructured.
+type SensitiveString struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Digest algorithm of hash, e.g. "sha256" or "salted-sha256".
+	Alg string `protobuf:"bytes,1,opt,name=alg,proto3" json:"alg,omitempty"`
+	// Hex-encoded digest of the plaintext.
+	Hash string `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	// Length of the plaintext in bytes.
+	Len uint32 `protobuf:"varint,3,opt,name=len,proto3" json:"len,omitempty"`
+	// Non-secret label identifying the secret.
+	Label string `protobuf:"bytes,4,opt,name=label,proto3" json:"label,omitempty"`
+	// Optional binary envelope holding the plaintext encrypted under a key
+	// shared out of band. Empty when only the fingerprint is carried.
+	Ciphertext    []byte `protobuf:"bytes,5,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SensitiveString) Reset() {
+	*x = SensitiveString{}
+	mi := &file_sensitivestring_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SensitiveString) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SensitiveString) ProtoMessage() {}
+
+func (x *SensitiveString) ProtoReflect() protoreflect.Message {
+	mi := &file_sensitivestring_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SensitiveString.ProtoReflect.Descriptor instead.
+func (*SensitiveString) Descriptor() ([]byte, []int) {
+	return file_sensitivestring_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SensitiveString) GetAlg() string {
+	if x != nil {
+		return x.Alg
+	}
+	return ""
+}
+
+func (x *SensitiveString) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *SensitiveString) GetLen() uint32 {
+	if x != nil {
+		return x.Len
+	}
+	return 0
+}
+
+func (x *SensitiveString) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *SensitiveString) GetCiphertext() []byte {
+	if x != nil {
+		return x.Ciphertext
+	}
+	return nil
+}
+
+var File_sensitivestring_proto protoreflect.FileDescriptor
+
+const file_sensitivestring_proto_rawDesc = "" +
+	"\n" +
+	"\x15sensitivestring.proto\x12\x12sensitivestring.v1\"\x7f\n" +
+	"\x0fSensitiveString\x12\x10\n" +
+	"\x03alg\x18\x01 \x01(\tR\x03alg\x12\x12\n" +
+	"\x04hash\x18\x02 \x01(\tR\x04hash\x12\x10\n" +
+	"\x03len\x18\x03 \x01(\rR\x03len\x12\x14\n" +
+	"\x05label\x18\x04 \x01(\tR\x05label\x12\x1e\n" +
+	"\n" +
+	"ciphertext\x18\x05 \x01(\fR\n" +
+	"ciphertextBAZ?github.com/earlye/sensitive-strings/golang/ss/sensitivestringpbb\x06proto3"
+
+var (
+	file_sensitivestring_proto_rawDescOnce sync.Once
+	file_sensitivestring_proto_rawDescData []byte
+)
+
+func file_sensitivestring_proto_rawDescGZIP() []byte {
+	file_sensitivestring_proto_rawDescOnce.Do(func() {
+		file_sensitivestring_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_sensitivestring_proto_rawDesc), len(file_sensitivestring_proto_rawDesc)))
+	})
+	return file_sensitivestring_proto_rawDescData
+}
+
+var file_sensitivestring_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_sensitivestring_proto_goTypes = []any{
+	(*SensitiveString)(nil), // 0: sensitivestring.v1.SensitiveString
+}
+var file_sensitivestring_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_sensitivestring_proto_init() }
+func file_sensitivestring_proto_init() {
+	if File_sensitivestring_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_sensitivestring_proto_rawDesc), len(file_sensitivestring_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_sensitivestring_proto_goTypes,
+		DependencyIndexes: file_sensitivestring_proto_depIdxs,
+		MessageInfos:      file_sensitivestring_proto_msgTypes,
+	}.Build()
+	File_sensitivestring_proto = out.File
+	file_sensitivestring_proto_goTypes = nil
+	file_sensitivestring_proto_depIdxs = nil
+}
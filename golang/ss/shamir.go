@@ -0,0 +1,160 @@
+package sensitivestring
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidShares is returned by Combine when the shares are malformed or
+// inconsistent.
+var ErrInvalidShares = errors.New("sensitivestring: invalid shares")
+
+// Split divides the secret into n shares using Shamir's secret sharing, so
+// that any threshold of them recover it with Combine while fewer reveal
+// nothing about it, e.g. to distribute a root key across operators. Each
+// share is one byte longer than the secret. It requires
+// 2 <= threshold <= n <= 255 and a non-empty secret.
+func (s *SensitiveString) Split(n, threshold int) ([]*SensitiveBytes, error) {
+	var shares []*SensitiveBytes
+	var err error
+	s.Use(func(secret []byte) { shares, err = shamirSplit(secret, n, threshold) })
+	return shares, err
+}
+
+// Split divides the bytes into n shares like SensitiveString.Split.
+func (b *SensitiveBytes) Split(n, threshold int) ([]*SensitiveBytes, error) {
+	return b.secret().Split(n, threshold)
+}
+
+// Combine recovers a secret from shares produced by Split. Given fewer
+// shares than the threshold it returns an unrelated value rather than an
+// error, since the shares carry no record of the threshold.
+func Combine(shares ...*SensitiveBytes) (*SensitiveBytes, error) {
+	if len(shares) < 2 {
+		return nil, fmt.Errorf("%w: at least 2 shares are required", ErrInvalidShares)
+	}
+	parts := make([][]byte, len(shares))
+	defer func() {
+		for _, p := range parts {
+			clear(p)
+		}
+	}()
+	for i, share := range shares {
+		parts[i] = share.Bytes()
+	}
+	secret, err := shamirCombine(parts)
+	if err != nil {
+		return nil, err
+	}
+	return &SensitiveBytes{s: &SensitiveString{store: &byteStorage{buf: secret}}}, nil
+}
+
+// shamirSplit returns n shares of secret. Each share holds, for every
+// secret byte, the value at x of a random polynomial of degree
+// threshold-1 over GF(2^8) whose constant term is that byte, followed by x
+// itself.
+func shamirSplit(secret []byte, n, threshold int) ([]*SensitiveBytes, error) {
+	switch {
+	case len(secret) == 0:
+		return nil, errors.New("sensitivestring: cannot split an empty secret")
+	case threshold < 2 || threshold > n || n > 255:
+		return nil, fmt.Errorf("sensitivestring: invalid split of %d shares with threshold %d", n, threshold)
+	}
+	shares := make([][]byte, n)
+	for i := range shares {
+		shares[i] = make([]byte, len(secret)+1)
+		shares[i][len(secret)] = byte(i + 1)
+	}
+	coefficients := make([]byte, threshold)
+	defer clear(coefficients)
+	for j, b := range secret {
+		coefficients[0] = b
+		rand.Read(coefficients[1:])
+		for _, share := range shares {
+			share[j] = gfEvaluate(coefficients, share[len(secret)])
+		}
+	}
+	result := make([]*SensitiveBytes, n)
+	for i, share := range shares {
+		result[i] = &SensitiveBytes{s: &SensitiveString{store: &byteStorage{buf: share}}}
+	}
+	return result, nil
+}
+
+// shamirCombine interpolates the polynomials through shares at x = 0.
+func shamirCombine(shares [][]byte) ([]byte, error) {
+	size := len(shares[0])
+	if size < 2 {
+		return nil, fmt.Errorf("%w: share too short", ErrInvalidShares)
+	}
+	xs := make([]byte, len(shares))
+	for i, share := range shares {
+		if len(share) != size {
+			return nil, fmt.Errorf("%w: shares differ in length", ErrInvalidShares)
+		}
+		xs[i] = share[size-1]
+		if xs[i] == 0 {
+			return nil, fmt.Errorf("%w: share has no x-coordinate", ErrInvalidShares)
+		}
+		for _, x := range xs[:i] {
+			if x == xs[i] {
+				return nil, fmt.Errorf("%w: duplicate share", ErrInvalidShares)
+			}
+		}
+	}
+
+	// basis[i] is the Lagrange basis polynomial for share i evaluated at 0.
+	basis := make([]byte, len(shares))
+	for i, xi := range xs {
+		num, den := byte(1), byte(1)
+		for k, xk := range xs {
+			if k != i {
+				num = gfMul(num, xk)
+				den = gfMul(den, xi^xk)
+			}
+		}
+		basis[i] = gfMul(num, gfInv(den))
+	}
+	secret := make([]byte, size-1)
+	for j := range secret {
+		for i, share := range shares {
+			secret[j] ^= gfMul(share[j], basis[i])
+		}
+	}
+	return secret, nil
+}
+
+// gfEvaluate evaluates the polynomial with the given coefficients, lowest
+// degree first, at x using Horner's method.
+func gfEvaluate(coefficients []byte, x byte) byte {
+	var y byte
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		y = gfMul(y, x) ^ coefficients[i]
+	}
+	return y
+}
+
+// gfMul multiplies in GF(2^8) with the AES polynomial, without
+// data-dependent branches or table lookups, so timing does not reveal the
+// operands.
+func gfMul(a, b byte) byte {
+	var p byte
+	for range 8 {
+		p ^= a & -(b & 1)
+		a = a<<1 ^ 0x1b&-(a>>7)
+		b >>= 1
+	}
+	return p
+}
+
+// gfInv returns the multiplicative inverse of a non-zero a in GF(2^8), as
+// a^254.
+func gfInv(a byte) byte {
+	result := byte(1)
+	for range 7 {
+		a = gfMul(a, a)
+		result = gfMul(result, a)
+	}
+	return result
+}
@@ -0,0 +1,69 @@
+package sensitivestring
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestSplit_Combine verifies any threshold of shares recovers the secret
+func TestSplit_Combine(t *testing.T) {
+	shares, err := New("root key").Split(5, 3)
+	if err != nil {
+		t.Fatalf("Split() error = %v", err)
+	}
+	if len(shares) != 5 || shares[0].Len() != len("root key")+1 {
+		t.Fatalf("Split() = %d shares of %d bytes, want 5 of %d", len(shares), shares[0].Len(), len("root key")+1)
+	}
+	for _, subset := range [][]int{{0, 1, 2}, {4, 2, 0}, {1, 3, 4}, {0, 1, 2, 3, 4}} {
+		picked := make([]*SensitiveBytes, len(subset))
+		for i, idx := range subset {
+			picked[i] = shares[idx]
+		}
+		secret, err := Combine(picked...)
+		if err != nil {
+			t.Fatalf("Combine(%v) error = %v", subset, err)
+		}
+		if got := string(secret.Bytes()); got != "root key" {
+			t.Errorf("Combine(%v) = %q, want root key", subset, got)
+		}
+	}
+	if secret, _ := Combine(shares[0], shares[1]); string(secret.Bytes()) == "root key" {
+		t.Errorf("Combine() below the threshold recovered the secret")
+	}
+}
+
+// TestSplit_Invalid verifies bad parameters and malformed shares are rejected
+func TestSplit_Invalid(t *testing.T) {
+	for _, tc := range []struct{ n, threshold int }{{3, 1}, {2, 3}, {256, 2}} {
+		if _, err := NewBytes([]byte{1}).Split(tc.n, tc.threshold); err == nil {
+			t.Errorf("Split(%d, %d) error = nil", tc.n, tc.threshold)
+		}
+	}
+	if _, err := New("").Split(3, 2); err == nil {
+		t.Errorf("Split() of an empty secret error = nil")
+	}
+
+	shares, _ := New("secret").Split(3, 2)
+	for name, input := range map[string][]*SensitiveBytes{
+		"single":    {shares[0]},
+		"duplicate": {shares[0], shares[0]},
+		"length":    {shares[0], NewBytes([]byte{1, 2})},
+		"zero x":    {shares[0], NewBytes(make([]byte, shares[0].Len()))},
+	} {
+		if _, err := Combine(input...); !errors.Is(err, ErrInvalidShares) {
+			t.Errorf("Combine(%s) error = %v, want ErrInvalidShares", name, err)
+		}
+	}
+}
+
+// TestGF verifies multiplication and inversion in GF(2^8)
+func TestGF(t *testing.T) {
+	if got := gfMul(0x57, 0x83); got != 0xc1 {
+		t.Errorf("gfMul(0x57, 0x83) = %#x, want 0xc1", got)
+	}
+	for a := 1; a < 256; a++ {
+		if got := gfMul(byte(a), gfInv(byte(a))); got != 1 {
+			t.Fatalf("%#x * gfInv(%#x) = %#x, want 1", a, a, got)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package sensitivestring
+
+import (
+	"context"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// flightGroup collapses concurrent resolutions of the same key into one
+// call, to protect rate-limited backends such as Vault when many
+// goroutines ask for the same secret at once. The zero value is ready to
+// use.
+type flightGroup struct {
+	group singleflight.Group
+}
+
+// resolve calls fn once for all concurrent callers with the same key and
+// gives each the shared result. fn runs with a context that is not
+// canceled when the caller that started it gives up, so one caller's
+// cancellation does not fail the others; each caller still returns as soon
+// as its own ctx is done. fn must therefore bound itself, e.g. with a
+// resolve timeout.
+func (g *flightGroup) resolve(ctx context.Context, key string, fn func(context.Context) (*SensitiveString, error)) (*SensitiveString, error) {
+	shared := context.WithoutCancel(ctx)
+	ch := g.group.DoChan(key, func() (interface{}, error) {
+		return fn(shared)
+	})
+	select {
+	case r := <-ch:
+		s, _ := r.Val.(*SensitiveString)
+		return s, r.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Dedupe returns a Provider that collapses concurrent resolutions of the
+// same name through provider into one call. A Resolver already does this
+// for every provider registered with it.
+func Dedupe(provider Provider) Provider {
+	return dedupeProvider{provider: provider, flights: new(flightGroup)}
+}
+
+// dedupeProvider is the Provider returned by Dedupe.
+type dedupeProvider struct {
+	provider Provider
+	flights  *flightGroup
+}
+
+// Resolve implements Provider.
+func (d dedupeProvider) Resolve(ctx context.Context, name string) (*SensitiveString, error) {
+	return d.flights.resolve(ctx, name, func(ctx context.Context) (*SensitiveString, error) {
+		return d.provider.Resolve(ctx, name)
+	})
+}
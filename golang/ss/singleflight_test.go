@@ -0,0 +1,83 @@
+package sensitivestring
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// blockingProvider counts calls and blocks each until release is closed.
+type blockingProvider struct {
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (p *blockingProvider) Resolve(ctx context.Context, name string) (*SensitiveString, error) {
+	p.calls.Add(1)
+	<-p.release
+	return New("value-of-" + name), nil
+}
+
+// TestResolver_Dedupe verifies concurrent resolutions of one ref share a single call
+func TestResolver_Dedupe(t *testing.T) {
+	backend := &blockingProvider{release: make(chan struct{})}
+	r := NewResolver()
+	r.Register("vault", backend)
+
+	var wg sync.WaitGroup
+	results := make([]*SensitiveString, 10)
+	for i := range results {
+		wg.Go(func() { results[i], _ = r.Resolve(context.Background(), "vault:db") })
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(backend.release)
+	wg.Wait()
+
+	if got := backend.calls.Load(); got != 1 {
+		t.Errorf("provider called %d times, want 1", got)
+	}
+	for i, s := range results {
+		if s.Value() != "value-of-db" {
+			t.Errorf("result %d = %v, want value-of-db", i, s.Value())
+		}
+	}
+	r.Resolve(context.Background(), "vault:db")
+	if got := backend.calls.Load(); got != 2 {
+		t.Errorf("provider called %d times after a later resolve, want 2", got)
+	}
+}
+
+// TestDedupe_CancelIndependent verifies one caller giving up does not fail the others
+func TestDedupe_CancelIndependent(t *testing.T) {
+	backend := &blockingProvider{release: make(chan struct{})}
+	p := Dedupe(backend)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := p.Resolve(ctx, "db")
+		first <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	second := make(chan *SensitiveString, 1)
+	go func() {
+		s, _ := p.Resolve(context.Background(), "db")
+		second <- s
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("canceled caller error = %v, want context.Canceled", err)
+	}
+	close(backend.release)
+	if s := <-second; s.Value() != "value-of-db" {
+		t.Errorf("other caller = %v, want value-of-db", s)
+	}
+	if got := backend.calls.Load(); got != 1 {
+		t.Errorf("provider called %d times, want 1", got)
+	}
+}
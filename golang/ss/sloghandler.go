@@ -0,0 +1,87 @@
+package sensitivestring
+
+import (
+	"context"
+	"log/slog"
+)
+
+// scrubbingHandler is a slog.Handler that scrubs registered secrets from
+// every record before passing it on.
+type scrubbingHandler struct {
+	next     slog.Handler
+	registry *Registry
+}
+
+// NewScrubbingHandler wraps next so that the plaintext of every secret in
+// registry is replaced by its hash form in record messages and attribute
+// values, including values inside groups, slices and maps. This catches
+// secrets that were extracted to plain strings before being logged, which
+// LogValue cannot see. A nil registry means the DefaultRegistry at the
+// time each record is handled; attributes added with WithAttrs are scrubbed
+// when they are added.
+func NewScrubbingHandler(next slog.Handler, registry *Registry) slog.Handler {
+	return &scrubbingHandler{next: next, registry: registry}
+}
+
+// Enabled implements slog.Handler.
+func (h *scrubbingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler.
+func (h *scrubbingHandler) Handle(ctx context.Context, record slog.Record) error {
+	sc := h.snapshot()
+	scrubbed := slog.NewRecord(record.Time, record.Level, sc.scrub(record.Message), record.PC)
+	record.Attrs(func(a slog.Attr) bool {
+		scrubbed.AddAttrs(scrubAttr(sc, a))
+		return true
+	})
+	return h.next.Handle(ctx, scrubbed)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *scrubbingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	sc := h.snapshot()
+	scrubbed := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		scrubbed[i] = scrubAttr(sc, a)
+	}
+	return &scrubbingHandler{next: h.next.WithAttrs(scrubbed), registry: h.registry}
+}
+
+// WithGroup implements slog.Handler.
+func (h *scrubbingHandler) WithGroup(name string) slog.Handler {
+	return &scrubbingHandler{next: h.next.WithGroup(name), registry: h.registry}
+}
+
+// snapshot returns the scrubber to apply.
+func (h *scrubbingHandler) snapshot() *scrubber {
+	if h.registry == nil {
+		return DefaultRegistry().snapshot()
+	}
+	return h.registry.snapshot()
+}
+
+// scrubAttr returns a with secrets removed from its value.
+func scrubAttr(sc *scrubber, a slog.Attr) slog.Attr {
+	if sc == nil {
+		return a
+	}
+	v := a.Value.Resolve()
+	switch v.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, sc.scrub(v.String()))
+	case slog.KindGroup:
+		group := v.Group()
+		scrubbed := make([]slog.Attr, len(group))
+		for i, ga := range group {
+			scrubbed[i] = scrubAttr(sc, ga)
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(scrubbed...)}
+	case slog.KindAny:
+		if scrubbed, changed := scrubAny(sc, v.Any()); changed {
+			return slog.Any(a.Key, scrubbed)
+		}
+	}
+	return slog.Attr{Key: a.Key, Value: v}
+}
@@ -0,0 +1,54 @@
+package sensitivestring
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// TestScrubbingHandler verifies plaintext secrets are scrubbed throughout a record
+func TestScrubbingHandler(t *testing.T) {
+	registry := NewRegistry()
+	secret := New("handler-secret")
+	registry.Register(secret)
+
+	var buf bytes.Buffer
+	logger := slog.New(NewScrubbingHandler(slog.NewJSONHandler(&buf, nil), registry))
+	logger = logger.With("ctx", "handler-secret").WithGroup("req")
+	logger.Info("token handler-secret",
+		"raw", "handler-secret",
+		"err", errors.New("auth failed: handler-secret"),
+		"list", []string{"ok", "handler-secret"},
+		"map", map[string]interface{}{"nested": []interface{}{"handler-secret"}},
+		slog.Group("db", "password", "handler-secret", "port", 5432),
+		"wrapped", secret,
+	)
+
+	out := buf.String()
+	if strings.Contains(out, "handler-secret") {
+		t.Errorf("ScrubbingHandler leaked plaintext: %s", out)
+	}
+	if got := strings.Count(out, secret.String()); got != 8 {
+		t.Errorf("ScrubbingHandler rendered %d hashes, want 8: %s", got, out)
+	}
+	for _, kept := range []string{`"port":5432`, `"list":["ok",`} {
+		if !strings.Contains(out, kept) {
+			t.Errorf("ScrubbingHandler output = %s, want %s", out, kept)
+		}
+	}
+}
+
+// TestScrubAny_Unchanged verifies values without secrets are returned as-is
+func TestScrubAny_Unchanged(t *testing.T) {
+	sc := newScrubber([]*SensitiveString{New("handler-secret")})
+	list := []string{"a", "b"}
+	if got, changed := scrubAny(sc, list); changed || &got.([]string)[0] != &list[0] {
+		t.Errorf("scrubAny(clean slice) = %v, %v, want the original slice", got, changed)
+	}
+	m := map[string]int{"a": 1}
+	if _, changed := scrubAny(sc, m); changed {
+		t.Errorf("scrubAny(clean map) changed = true, want false")
+	}
+}
@@ -0,0 +1,46 @@
+package sensitivestring
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"runtime"
+	"runtime/pprof"
+)
+
+// ScrubStack returns buf, a goroutine stack dump, with the plaintext of
+// every secret in the DefaultRegistry replaced by its hash form.
+func ScrubStack(buf []byte) []byte {
+	return DefaultRegistry().ScrubBytes(buf)
+}
+
+// Stack is like runtime.Stack, returning the stack of the calling goroutine,
+// or of all goroutines when all is true, passed through ScrubStack. Use it
+// in debug endpoints and custom SIGQUIT handlers in place of the runtime's
+// own dump.
+func Stack(all bool) []byte {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, all)
+		if n < len(buf) {
+			return ScrubStack(buf[:n])
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// WriteGoroutineProfile writes the text form of the goroutine profile, as
+// pprof.Lookup("goroutine").WriteTo(w, debug) would, passed through
+// ScrubStack. debug must be 1 or 2; the binary form (debug 0) cannot be
+// scrubbed safely.
+func WriteGoroutineProfile(w io.Writer, debug int) error {
+	if debug < 1 {
+		return errors.New("sensitivestring: goroutine profile must be written in text form (debug >= 1)")
+	}
+	var buf bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&buf, debug); err != nil {
+		return err
+	}
+	_, err := w.Write(ScrubStack(buf.Bytes()))
+	return err
+}
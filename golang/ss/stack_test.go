@@ -0,0 +1,63 @@
+package sensitivestring
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// withDefaultRegistry installs a fresh default registry holding secrets for the duration of a test.
+func withDefaultRegistry(t *testing.T, secrets ...*SensitiveString) {
+	t.Helper()
+	previous := DefaultRegistry()
+	SetDefaultRegistry(NewRegistry())
+	Register(secrets...)
+	t.Cleanup(func() { SetDefaultRegistry(previous) })
+}
+
+// TestScrubStack verifies registered secrets are removed from a dump
+func TestScrubStack(t *testing.T) {
+	secret := New("stack-secret")
+	withDefaultRegistry(t, secret)
+
+	dump := []byte("goroutine 1 [running]:\nmain.login(\"stack-secret\")\n")
+	got := string(ScrubStack(dump))
+	if strings.Contains(got, "stack-secret") || !strings.Contains(got, secret.String()) {
+		t.Errorf("ScrubStack() = %v", got)
+	}
+}
+
+// TestStack verifies the scrubbed dump of the current goroutine
+func TestStack(t *testing.T) {
+	// The test function name appears in the dump, so registering it proves
+	// the dump is scrubbed.
+	secret := New("TestStack")
+	withDefaultRegistry(t, secret)
+
+	for _, all := range []bool{false, true} {
+		got := string(Stack(all))
+		if !strings.Contains(got, "goroutine") {
+			t.Errorf("Stack(%v) = %v, want a goroutine dump", all, got)
+		}
+		if strings.Contains(got, "TestStack") {
+			t.Errorf("Stack(%v) was not scrubbed: %v", all, got)
+		}
+	}
+}
+
+// TestWriteGoroutineProfile verifies the scrubbed text profile and rejection of binary output
+func TestWriteGoroutineProfile(t *testing.T) {
+	secret := New("TestWriteGoroutineProfile")
+	withDefaultRegistry(t, secret)
+
+	var buf bytes.Buffer
+	if err := WriteGoroutineProfile(&buf, 2); err != nil {
+		t.Fatalf("WriteGoroutineProfile() error = %v", err)
+	}
+	if strings.Contains(buf.String(), "TestWriteGoroutineProfile") {
+		t.Errorf("WriteGoroutineProfile() was not scrubbed")
+	}
+	if err := WriteGoroutineProfile(&buf, 0); err == nil {
+		t.Errorf("WriteGoroutineProfile(debug=0) error = nil, want error")
+	}
+}
@@ -0,0 +1,114 @@
+package sensitivestring
+
+import "runtime"
+
+// storage is a backend holding a secret's plaintext somewhere other than an
+// ordinary Go string, e.g. encrypted in memory or in the kernel. The
+// plaintext is only materialized when revealed.
+type storage interface {
+	reveal() string
+	size() int
+}
+
+// opener is implemented by storage backends that can lend out the
+// plaintext bytes for the duration of a call without materializing a
+// string.
+type opener interface {
+	open(fn func([]byte))
+}
+
+// byteStorage is the default backend: the plaintext in a byte slice owned
+// by the package, which Zero can overwrite, unlike a Go string. Copies of a
+// SensitiveString share it. With WithMemoryLock, buf lives in locked
+// memory outside the Go heap.
+type byteStorage struct {
+	buf    []byte
+	locked bool
+}
+
+// A cleanup attached to a byteStorage (WithMemoryLock, WithZeroOnFinalize)
+// releases or wipes buf once b is unreachable, which can happen while buf is
+// still being read. Every access to buf must therefore keep b alive until
+// it is done, as the methods below do; buf must not escape them.
+
+func (b *byteStorage) size() int      { return len(b.buf) }
+func (b *byteStorage) isLocked() bool { return b.locked }
+
+func (b *byteStorage) reveal() string {
+	value := string(b.buf)
+	runtime.KeepAlive(b)
+	return value
+}
+
+func (b *byteStorage) wipe() {
+	clear(b.buf)
+	runtime.KeepAlive(b)
+}
+
+func (b *byteStorage) open(fn func([]byte)) {
+	fn(b.buf)
+	runtime.KeepAlive(b)
+}
+
+// setPlaintext replaces the secret held by s with a package-owned copy of
+// value.
+func (s *SensitiveString) setPlaintext(value string) {
+	s.value = ""
+	s.store = &byteStorage{buf: []byte(value)}
+}
+
+// boundStorage holds a secret bound to a caller's string with Bind. The
+// string is read at every use, so writes through the pointer reach it.
+type boundStorage struct {
+	p *string
+}
+
+func (b boundStorage) reveal() string { return *b.p }
+func (b boundStorage) size() int      { return len(*b.p) }
+func (b boundStorage) wipe()          { *b.p = "" }
+
+// plaintext returns the raw value, revealing it from the storage backend if
+// the SensitiveString has one.
+func (s SensitiveString) plaintext() string {
+	if s.store != nil {
+		return s.store.reveal()
+	}
+	return s.value
+}
+
+// usePlaintext calls fn with the raw value as bytes, for hashing. For the
+// default backend it is the stored slice itself, so no copy of the secret
+// is made; fn must neither modify nor retain it.
+func (s SensitiveString) usePlaintext(fn func([]byte)) {
+	if b, ok := s.store.(*byteStorage); ok {
+		b.open(fn)
+		return
+	}
+	fn([]byte(s.plaintext()))
+}
+
+// Use calls fn with the raw plaintext value as bytes, without leaving a
+// copy behind: backends that keep the secret encrypted (e.g. NewGuarded)
+// decrypt it only for the duration of the call. fn must neither modify nor
+// retain the slice.
+func (s *SensitiveString) Use(fn func(plaintext []byte)) {
+	if s == nil {
+		fn(nil)
+		return
+	}
+	if o, ok := s.store.(opener); ok {
+		o.open(fn)
+		return
+	}
+	b := []byte(s.plaintext())
+	defer clear(b)
+	fn(b)
+}
+
+// size returns the length of the raw value without revealing it.
+func (s SensitiveString) size() int {
+	if s.store != nil {
+		return s.store.size()
+	}
+	return len(s.value)
+}
@@ -0,0 +1,195 @@
+package sensitivestring
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// StreamRedactor rewrites the values found at configured paths of JSON
+// documents into their hash form, for sanitizing exported datasets and
+// audit dumps. It streams, so its memory use does not grow with the input.
+//
+// Paths are dot-separated object keys and array indexes, where "*" matches
+// any single key or index, e.g. "users.*.password". When a path selects an
+// object or array, every scalar beneath it is redacted.
+type StreamRedactor struct {
+	paths [][]string
+}
+
+// NewStreamRedactor creates a StreamRedactor for the given paths.
+func NewStreamRedactor(paths ...string) *StreamRedactor {
+	sr := &StreamRedactor{}
+	for _, path := range paths {
+		sr.paths = append(sr.paths, strings.Split(path, "."))
+	}
+	return sr
+}
+
+// matches reports whether the value at path, or one of its ancestors, is
+// selected by a configured path.
+func (sr *StreamRedactor) matches(path []string) bool {
+	for _, pattern := range sr.paths {
+		if len(pattern) > len(path) {
+			continue
+		}
+		matched := true
+		for i, segment := range pattern {
+			if segment != "*" && segment != path[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// redactScalar returns the hash form of a scalar's text.
+func redactScalar(text string) string {
+	return New(text).String()
+}
+
+// jsonFrame tracks an open JSON object or array.
+type jsonFrame struct {
+	object    bool
+	count     int
+	expectKey bool
+}
+
+// jsonStreamWriter buffers RedactJSON output and quotes strings without the
+// HTML escaping json.Marshal applies, so unredacted strings are copied
+// verbatim.
+type jsonStreamWriter struct {
+	*bufio.Writer
+	scratch bytes.Buffer
+	enc     *json.Encoder
+}
+
+// writeString writes s as a JSON string.
+func (w *jsonStreamWriter) writeString(s string) {
+	w.scratch.Reset()
+	_ = w.enc.Encode(s)
+	w.Write(bytes.TrimSuffix(w.scratch.Bytes(), []byte("\n")))
+}
+
+// RedactJSON copies the stream of JSON values read from r to w, redacting
+// the configured paths. It works token by token, so memory use does not grow
+// with document size. Output is compact, with one top-level value per line.
+func (sr *StreamRedactor) RedactJSON(w io.Writer, r io.Reader) error {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	out := &jsonStreamWriter{Writer: bufio.NewWriter(w)}
+	out.enc = json.NewEncoder(&out.scratch)
+	out.enc.SetEscapeHTML(false)
+
+	var frames []jsonFrame
+	var path []string
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			if len(frames) != 0 {
+				return io.ErrUnexpectedEOF
+			}
+			return out.Flush()
+		}
+		if err != nil {
+			return err
+		}
+
+		// Object keys are written as-is and become the current path segment.
+		if len(frames) > 0 && frames[len(frames)-1].expectKey {
+			top := &frames[len(frames)-1]
+			if delim, ok := tok.(json.Delim); ok && delim == '}' {
+				frames = frames[:len(frames)-1]
+				path = path[:len(path)-1]
+				out.WriteByte('}')
+				sr.endJSONValue(out, frames)
+				continue
+			}
+			if top.count > 0 {
+				out.WriteByte(',')
+			}
+			out.writeString(tok.(string))
+			out.WriteByte(':')
+			path[len(path)-1] = tok.(string)
+			top.expectKey = false
+			continue
+		}
+
+		if len(frames) > 0 && !frames[len(frames)-1].object {
+			top := &frames[len(frames)-1]
+			if delim, ok := tok.(json.Delim); !ok || delim != ']' {
+				if top.count > 0 {
+					out.WriteByte(',')
+				}
+				path[len(path)-1] = strconv.Itoa(top.count)
+			}
+		}
+
+		switch v := tok.(type) {
+		case json.Delim:
+			switch v {
+			case '{', '[':
+				out.WriteByte(byte(v))
+				frames = append(frames, jsonFrame{object: v == '{', expectKey: v == '{'})
+				path = append(path, "")
+				continue
+			default:
+				frames = frames[:len(frames)-1]
+				path = path[:len(path)-1]
+				out.WriteByte(byte(v))
+			}
+		case nil:
+			sr.writeJSONScalar(out, path, "null", nil)
+		case bool:
+			sr.writeJSONScalar(out, path, strconv.FormatBool(v), nil)
+		case json.Number:
+			sr.writeJSONScalar(out, path, v.String(), nil)
+		case string:
+			sr.writeJSONScalar(out, path, v, &v)
+		default:
+			return fmt.Errorf("sensitivestring: unexpected JSON token %T", tok)
+		}
+		sr.endJSONValue(out, frames)
+	}
+}
+
+// writeJSONScalar writes a scalar, redacting it when its path matches. str
+// is non-nil for string scalars.
+func (sr *StreamRedactor) writeJSONScalar(out *jsonStreamWriter, path []string, text string, str *string) {
+	if sr.matches(path) {
+		if str == nil && text == "null" {
+			out.WriteString(text)
+			return
+		}
+		out.writeString(redactScalar(text))
+		return
+	}
+	if str != nil {
+		out.writeString(*str)
+		return
+	}
+	out.WriteString(text)
+}
+
+// endJSONValue records that a value was completed in the enclosing
+// container, or ends the line after a top-level value.
+func (sr *StreamRedactor) endJSONValue(out *jsonStreamWriter, frames []jsonFrame) {
+	if len(frames) == 0 {
+		out.WriteByte('\n')
+		return
+	}
+	top := &frames[len(frames)-1]
+	top.count++
+	if top.object {
+		top.expectKey = true
+	}
+}
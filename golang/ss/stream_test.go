@@ -0,0 +1,39 @@
+package sensitivestring
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestStreamRedactor_JSON verifies paths, wildcards, subtrees and NDJSON streams
+func TestStreamRedactor_JSON(t *testing.T) {
+	input := `{"users":[{"name":"a","password":"foo"},{"name":"b","password":"bar","tags":[]}],` +
+		`"db":{"auth":{"user":"u","port":5432,"tls":true,"ca":null}},"note":"<ok>"}
+{"users":[{"password":"foo"}]}`
+
+	sr := NewStreamRedactor("users.*.password", "db.auth")
+	var out bytes.Buffer
+	if err := sr.RedactJSON(&out, strings.NewReader(input)); err != nil {
+		t.Fatalf("RedactJSON() error = %v", err)
+	}
+
+	foo := New("foo").String()
+	expected := `{"users":[{"name":"a","password":"` + foo + `"},{"name":"b","password":"` + New("bar").String() + `","tags":[]}],` +
+		`"db":{"auth":{"user":"` + New("u").String() + `","port":"` + New("5432").String() + `","tls":"` + New("true").String() + `","ca":null}},"note":"<ok>"}
+{"users":[{"password":"` + foo + `"}]}
+`
+	if got := out.String(); got != expected {
+		t.Errorf("RedactJSON() =\n%v\nwant\n%v", got, expected)
+	}
+}
+
+// TestStreamRedactor_JSON_Errors verifies malformed input is reported
+func TestStreamRedactor_JSON_Errors(t *testing.T) {
+	sr := NewStreamRedactor("a")
+	for _, input := range []string{`{"a":`, `{"a":1`, `[1,`} {
+		if err := sr.RedactJSON(&bytes.Buffer{}, strings.NewReader(input)); err == nil {
+			t.Errorf("RedactJSON(%q) error = nil, want error", input)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+//go:build !windows && !plan9
+
+package sensitivestring
+
+import "log/syslog"
+
+// SyslogWriter wraps a *syslog.Writer and scrubs the secrets in the
+// DefaultRegistry from every message before forwarding it, so daemons that
+// log to syslog can adopt the package without changing their logging code.
+// It has the same methods as *syslog.Writer and can be passed to
+// log.New or log.SetOutput. Each call sends one message, so a secret is
+// never split across messages.
+type SyslogWriter struct {
+	w *syslog.Writer
+}
+
+// NewSyslogWriter is like syslog.New, with scrubbing.
+func NewSyslogWriter(priority syslog.Priority, tag string) (*SyslogWriter, error) {
+	w, err := syslog.New(priority, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &SyslogWriter{w: w}, nil
+}
+
+// DialSyslog is like syslog.Dial, with scrubbing.
+func DialSyslog(network, raddr string, priority syslog.Priority, tag string) (*SyslogWriter, error) {
+	w, err := syslog.Dial(network, raddr, priority, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &SyslogWriter{w: w}, nil
+}
+
+// WrapSyslog returns a SyslogWriter forwarding to an existing writer.
+func WrapSyslog(w *syslog.Writer) *SyslogWriter {
+	return &SyslogWriter{w: w}
+}
+
+// Write sends a message at the writer's default priority. It reports
+// len(b) on success regardless of the length of the scrubbed message.
+func (s *SyslogWriter) Write(b []byte) (int, error) {
+	if _, err := s.w.Write(DefaultRegistry().ScrubBytes(b)); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// Close closes the underlying writer.
+func (s *SyslogWriter) Close() error { return s.w.Close() }
+
+// Emerg logs a message with severity LOG_EMERG.
+func (s *SyslogWriter) Emerg(m string) error { return s.w.Emerg(Scrub(m)) }
+
+// Alert logs a message with severity LOG_ALERT.
+func (s *SyslogWriter) Alert(m string) error { return s.w.Alert(Scrub(m)) }
+
+// Crit logs a message with severity LOG_CRIT.
+func (s *SyslogWriter) Crit(m string) error { return s.w.Crit(Scrub(m)) }
+
+// Err logs a message with severity LOG_ERR.
+func (s *SyslogWriter) Err(m string) error { return s.w.Err(Scrub(m)) }
+
+// Warning logs a message with severity LOG_WARNING.
+func (s *SyslogWriter) Warning(m string) error { return s.w.Warning(Scrub(m)) }
+
+// Notice logs a message with severity LOG_NOTICE.
+func (s *SyslogWriter) Notice(m string) error { return s.w.Notice(Scrub(m)) }
+
+// Info logs a message with severity LOG_INFO.
+func (s *SyslogWriter) Info(m string) error { return s.w.Info(Scrub(m)) }
+
+// Debug logs a message with severity LOG_DEBUG.
+func (s *SyslogWriter) Debug(m string) error { return s.w.Debug(Scrub(m)) }
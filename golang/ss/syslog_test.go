@@ -0,0 +1,48 @@
+//go:build !windows && !plan9
+
+package sensitivestring
+
+import (
+	"log"
+	"log/syslog"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSyslogWriter verifies messages are scrubbed before reaching syslog
+func TestSyslogWriter(t *testing.T) {
+	secret := New("syslog-secret")
+	withDefaultRegistry(t, secret)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen on UDP: %v", err)
+	}
+	defer conn.Close()
+
+	w, err := DialSyslog("udp", conn.LocalAddr().String(), syslog.LOG_INFO|syslog.LOG_DAEMON, "sstest")
+	if err != nil {
+		t.Fatalf("DialSyslog() error = %v", err)
+	}
+	defer w.Close()
+
+	log.New(w, "", 0).Print("login with syslog-secret")
+	if err := w.Err("failed: syslog-secret"); err != nil {
+		t.Fatalf("Err() error = %v", err)
+	}
+
+	buf := make([]byte, 2048)
+	for i := 0; i < 2; i++ {
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("ReadFrom() error = %v", err)
+		}
+		msg := string(buf[:n])
+		if strings.Contains(msg, "syslog-secret") || !strings.Contains(msg, secret.String()) {
+			t.Errorf("syslog message = %q, want the secret scrubbed", msg)
+		}
+	}
+}
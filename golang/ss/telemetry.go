@@ -0,0 +1,96 @@
+package sensitivestring
+
+import "sync"
+
+// Formats reported in RedactionEvents.
+const (
+	FormatJSON   = "json"
+	FormatYAML   = "yaml"
+	FormatSlog   = "slog"
+	FormatLogr   = "logr"
+	FormatBinary = "binary"
+	FormatScrub  = "scrub"
+)
+
+// RedactionEvent reports that Count values carrying Label were redacted
+// while producing output in Format. Events never carry secret values.
+type RedactionEvent struct {
+	Format string
+	Label  string
+	Count  int
+}
+
+// RedactionObserver receives RedactionEvents. It is called synchronously on
+// the redacting goroutine, so it must be fast and safe for concurrent use.
+type RedactionObserver func(RedactionEvent)
+
+// SetRedactionObserver installs fn to be told about every redaction
+// performed by marshaling, slog and scrubbing, so teams can prove that
+// redaction actually happens on the paths they care about. Telemetry is off
+// by default; a nil fn turns it off again.
+func SetRedactionObserver(fn RedactionObserver) {
+	updateConfig(func(c *config) { c.observer = fn })
+}
+
+// recordRedaction reports a redaction to the installed observer, if any.
+func recordRedaction(format string, label string, count int) {
+	if observer := loadConfig().observer; observer != nil {
+		observer(RedactionEvent{Format: format, Label: label, Count: count})
+	}
+}
+
+// recordScrub reports the secrets a scrubber is about to replace in s, one
+// event per label. Finding them costs an extra pass, which is only paid
+// while an observer is installed.
+func recordScrub[T string | []byte](sc *scrubber, s T) {
+	observer := loadConfig().observer
+	if observer == nil {
+		return
+	}
+	findings, _ := scanWindow(sc, s, 0, len(s), nil)
+	counts := make(map[string]int)
+	var labels []string
+	for _, f := range findings {
+		if counts[f.Label] == 0 {
+			labels = append(labels, f.Label)
+		}
+		counts[f.Label]++
+	}
+	for _, label := range labels {
+		observer(RedactionEvent{Format: FormatScrub, Label: label, Count: counts[label]})
+	}
+}
+
+// RedactionKey identifies a RedactionCounter bucket.
+type RedactionKey struct {
+	Format string
+	Label  string
+}
+
+// RedactionCounter is a RedactionObserver that totals redactions per format
+// and label, e.g. for export as metrics.
+type RedactionCounter struct {
+	mu     sync.Mutex
+	counts map[RedactionKey]int64
+}
+
+// Observe records e. Pass counter.Observe to SetRedactionObserver.
+func (c *RedactionCounter) Observe(e RedactionEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.counts == nil {
+		c.counts = make(map[RedactionKey]int64)
+	}
+	c.counts[RedactionKey{Format: e.Format, Label: e.Label}] += int64(e.Count)
+}
+
+// Snapshot returns a copy of the current totals.
+func (c *RedactionCounter) Snapshot() map[RedactionKey]int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	result := make(map[RedactionKey]int64, len(c.counts))
+	for k, v := range c.counts {
+		result[k] = v
+	}
+	return result
+}
@@ -0,0 +1,60 @@
+package sensitivestring
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// withRedactionCounter installs a RedactionCounter for the duration of a test.
+func withRedactionCounter(t *testing.T) *RedactionCounter {
+	t.Helper()
+	counter := &RedactionCounter{}
+	SetRedactionObserver(counter.Observe)
+	t.Cleanup(func() { SetRedactionObserver(nil) })
+	return counter
+}
+
+// TestRedactionTelemetry verifies marshal, slog and scrub redactions are counted
+func TestRedactionTelemetry(t *testing.T) {
+	counter := withRedactionCounter(t)
+	db := NewLabeled("db", "hunter2")
+	api := NewLabeled("api", "token-123")
+
+	json.Marshal(map[string]interface{}{"db": db, "api": api})
+	yaml.Marshal(map[string]interface{}{"db": db})
+	slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)).Info("x", "db", db)
+
+	r := NewRegistry()
+	r.Register(db, api)
+	r.Scrub("hunter2 hunter2 token-123 nothing")
+	r.ScrubBytes([]byte("hunter2"))
+	r.Scrub("no secrets here")
+
+	expected := map[RedactionKey]int64{
+		{Format: FormatJSON, Label: "db"}:   1,
+		{Format: FormatJSON, Label: "api"}:  1,
+		{Format: FormatYAML, Label: "db"}:   1,
+		{Format: FormatSlog, Label: "db"}:   1,
+		{Format: FormatScrub, Label: "db"}:  3,
+		{Format: FormatScrub, Label: "api"}: 1,
+	}
+	if got := counter.Snapshot(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Snapshot() = %v, want %v", got, expected)
+	}
+}
+
+// TestRedactionTelemetry_Disabled verifies nothing is reported without an observer
+func TestRedactionTelemetry_Disabled(t *testing.T) {
+	counter := withRedactionCounter(t)
+	SetRedactionObserver(nil)
+
+	json.Marshal(New("foo"))
+	if got := counter.Snapshot(); len(got) != 0 {
+		t.Errorf("Snapshot() = %v, want empty", got)
+	}
+}
@@ -0,0 +1,119 @@
+package sensitivestring
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SensitiveValue is a SensitiveString meant to be embedded by value.
+//
+// SensitiveString's rendering methods all have value receivers, so an
+// exported SensitiveString field is redacted whether it is held by value or
+// by pointer. fmt, however, cannot call methods on unexported fields and
+// prints their raw contents instead, so an unexported SensitiveString held
+// by value leaks its plaintext with %v. SensitiveValue keeps the secret
+// two pointers away, so in that case fmt prints only an address, even for
+// verbs such as %s that make fmt dereference the first pointer when
+// reporting a bad verb. Its zero value is an empty secret.
+type SensitiveValue struct {
+	box *valueBox
+}
+
+// valueBox adds the second level of indirection described on
+// SensitiveValue.
+type valueBox struct {
+	s *SensitiveString
+}
+
+// NewValue creates a new SensitiveValue from the given value.
+func NewValue(value string, opts ...Option) SensitiveValue {
+	return ValueOf(New(value, opts...))
+}
+
+// ValueOf returns a SensitiveValue sharing s. A nil s gives the zero
+// SensitiveValue.
+func ValueOf(s *SensitiveString) SensitiveValue {
+	if s == nil {
+		return SensitiveValue{}
+	}
+	return SensitiveValue{box: &valueBox{s: s}}
+}
+
+// SensitiveString returns the underlying *SensitiveString, never nil.
+func (v SensitiveValue) SensitiveString() *SensitiveString {
+	if v.box == nil {
+		return New("")
+	}
+	return v.box.s
+}
+
+// Value returns the raw plaintext value. Use this only when you explicitly
+// need access to the secret value.
+func (v SensitiveValue) Value() string {
+	return v.SensitiveString().Value()
+}
+
+// Len returns the length of the underlying value without exposing it.
+func (v SensitiveValue) Len() int {
+	return v.SensitiveString().Len()
+}
+
+// Label returns the label attached to the secret, if any.
+func (v SensitiveValue) Label() string {
+	return v.SensitiveString().Label()
+}
+
+// String returns the redacted form, implementing fmt.Stringer.
+func (v SensitiveValue) String() string {
+	return v.SensitiveString().String()
+}
+
+// GoString returns the redacted form for %#v formatting.
+func (v SensitiveValue) GoString() string {
+	return v.SensitiveString().GoString()
+}
+
+// Format implements fmt.Formatter like SensitiveString.Format.
+func (v SensitiveValue) Format(f fmt.State, verb rune) {
+	v.SensitiveString().Format(f, verb)
+}
+
+// MarshalJSON implements json.Marshaler like SensitiveString.MarshalJSON.
+func (v SensitiveValue) MarshalJSON() ([]byte, error) {
+	return v.SensitiveString().MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler like
+// SensitiveString.UnmarshalJSON.
+func (v *SensitiveValue) UnmarshalJSON(data []byte) error {
+	s := new(SensitiveString)
+	if err := json.Unmarshal(data, s); err != nil {
+		return err
+	}
+	*v = ValueOf(s)
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler like SensitiveString.MarshalYAML.
+func (v SensitiveValue) MarshalYAML() (interface{}, error) {
+	return v.SensitiveString().MarshalYAML()
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler like
+// SensitiveString.UnmarshalYAML.
+func (v *SensitiveValue) UnmarshalYAML(node *yaml.Node) error {
+	s := new(SensitiveString)
+	if err := s.UnmarshalYAML(node); err != nil {
+		return err
+	}
+	*v = ValueOf(s)
+	return nil
+}
+
+// LogValue implements slog.LogValuer like SensitiveString.LogValue.
+func (v SensitiveValue) LogValue() slog.Value {
+	return v.SensitiveString().LogValue()
+}
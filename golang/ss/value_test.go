@@ -0,0 +1,57 @@
+package sensitivestring
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestSensitiveValue_UnexportedField verifies fmt cannot reach the plaintext of an unexported field
+func TestSensitiveValue_UnexportedField(t *testing.T) {
+	type settings struct {
+		password SensitiveValue
+		Token    SensitiveValue
+	}
+	s := settings{password: NewValue("hunter2"), Token: NewValue("hunter3")}
+	for _, format := range []string{"%v", "%+v", "%#v", "%s", "%x", "%q"} {
+		out := fmt.Sprintf(format, s)
+		if strings.Contains(out, "hunter") || strings.Contains(out, fmt.Sprintf("%x", "hunter")) {
+			t.Errorf("Sprintf(%q) = %v, leaks the secret", format, out)
+		}
+	}
+}
+
+// TestSensitiveValue_Methods verifies the value type behaves like a SensitiveString
+func TestSensitiveValue_Methods(t *testing.T) {
+	var zero SensitiveValue
+	if zero.Value() != "" || zero.Len() != 0 || zero.String() != New("").String() {
+		t.Errorf("zero SensitiveValue = %q/%d/%v, want an empty secret", zero.Value(), zero.Len(), zero)
+	}
+
+	v := NewValue("foo", WithLabel("api"))
+	if v.Value() != "foo" || v.Len() != 3 || v.Label() != "api" || v.String() != "sha256:"+fooHashHex {
+		t.Errorf("NewValue() = %q/%d/%q/%v", v.Value(), v.Len(), v.Label(), v)
+	}
+	if ValueOf(v.SensitiveString()).String() != v.String() {
+		t.Errorf("ValueOf(SensitiveString()) does not round-trip")
+	}
+
+	data, err := json.Marshal(struct{ V SensitiveValue }{v})
+	if err != nil || string(data) != `{"V":"sha256:`+fooHashHex+`"}` {
+		t.Errorf("json.Marshal() = %s, %v", data, err)
+	}
+	var decoded struct{ V SensitiveValue }
+	if err := json.Unmarshal([]byte(`{"V":"bar"}`), &decoded); err != nil || decoded.V.Value() != "bar" {
+		t.Errorf("json.Unmarshal() = %q, %v", decoded.V.Value(), err)
+	}
+	var y struct{ V SensitiveValue }
+	if err := yaml.Unmarshal([]byte("v: baz"), &y); err != nil || y.V.Value() != "baz" {
+		t.Errorf("yaml.Unmarshal() = %q, %v", y.V.Value(), err)
+	}
+	if got := v.LogValue().String(); got != "sha256:"+fooHashHex {
+		t.Errorf("LogValue() = %v", got)
+	}
+}
@@ -0,0 +1,150 @@
+package sensitivestring
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+	"unicode/utf8"
+)
+
+// vectorsPath is the location of the canonical test vectors shared with the
+// other language implementations in this repository.
+const vectorsPath = "../../test-vectors/sensitive-strings.json"
+
+type testVectors struct {
+	Hash []struct {
+		Value  string `json:"value"`
+		String string `json:"string"`
+	} `json:"hash"`
+	Structured []struct {
+		Value    string          `json:"value"`
+		Label    string          `json:"label"`
+		Expected json.RawMessage `json:"expected"`
+	} `json:"structured"`
+	Mask []struct {
+		Value   string `json:"value"`
+		Options struct {
+			ShowFirst int    `json:"showFirst"`
+			ShowLast  int    `json:"showLast"`
+			MaskChar  string `json:"maskChar"`
+			MinMasked int    `json:"minMasked"`
+		} `json:"options"`
+		Expected string `json:"expected"`
+	} `json:"mask"`
+	PlaintextReplacer []struct {
+		Input     interface{}     `json:"input"`
+		Redacted  json.RawMessage `json:"redacted"`
+		Plaintext json.RawMessage `json:"plaintext"`
+	} `json:"plaintextReplacer"`
+}
+
+// loadVectors reads the shared test vectors, skipping the test when they are
+// not available (e.g. when run from a module download).
+func loadVectors(t *testing.T) testVectors {
+	t.Helper()
+	data, err := os.ReadFile(vectorsPath)
+	if os.IsNotExist(err) {
+		t.Skipf("test vectors not found at %s", vectorsPath)
+	}
+	if err != nil {
+		t.Fatalf("os.ReadFile() error = %v", err)
+	}
+	var vectors testVectors
+	if err := json.Unmarshal(data, &vectors); err != nil {
+		t.Fatalf("json.Unmarshal(vectors) error = %v", err)
+	}
+	return vectors
+}
+
+// sensitiveMarkers replaces {"$sensitive": "..."} markers with *SensitiveString.
+func sensitiveMarkers(input interface{}) interface{} {
+	switch v := input.(type) {
+	case map[string]interface{}:
+		if value, ok := v["$sensitive"].(string); ok && len(v) == 1 {
+			return New(value)
+		}
+		result := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			result[key] = sensitiveMarkers(val)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, val := range v {
+			result[i] = sensitiveMarkers(val)
+		}
+		return result
+	default:
+		return v
+	}
+}
+
+// assertSameJSON compares two JSON documents structurally.
+func assertSameJSON(t *testing.T, name string, got []byte, want []byte) {
+	t.Helper()
+	var gotValue, wantValue interface{}
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf("%s: json.Unmarshal(got) error = %v", name, err)
+	}
+	if err := json.Unmarshal(want, &wantValue); err != nil {
+		t.Fatalf("%s: json.Unmarshal(want) error = %v", name, err)
+	}
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("%s = %s, want %s", name, got, want)
+	}
+}
+
+// TestVectors_Hash verifies String() against the shared vectors
+func TestVectors_Hash(t *testing.T) {
+	for _, v := range loadVectors(t).Hash {
+		if got := New(v.Value).String(); got != v.String {
+			t.Errorf("New(%q).String() = %v, want %v", v.Value, got, v.String)
+		}
+	}
+}
+
+// TestVectors_Structured verifies MarshalStructured output against the shared vectors
+func TestVectors_Structured(t *testing.T) {
+	withMarshalMode(t, MarshalStructured)
+	for _, v := range loadVectors(t).Structured {
+		got, err := json.Marshal(NewLabeled(v.Label, v.Value))
+		if err != nil {
+			t.Fatalf("json.Marshal() error = %v", err)
+		}
+		assertSameJSON(t, "structured "+v.Value, got, v.Expected)
+	}
+}
+
+// TestVectors_Mask verifies Mask() against the shared vectors
+func TestVectors_Mask(t *testing.T) {
+	for _, v := range loadVectors(t).Mask {
+		opts := MaskOptions{ShowFirst: v.Options.ShowFirst, ShowLast: v.Options.ShowLast, MinMasked: v.Options.MinMasked}
+		if v.Options.MaskChar != "" {
+			opts.MaskRune, _ = utf8.DecodeRuneInString(v.Options.MaskChar)
+		}
+		if got := New(v.Value).Mask(opts); got != v.Expected {
+			t.Errorf("New(%q).Mask(%+v) = %q, want %q", v.Value, v.Options, got, v.Expected)
+		}
+	}
+}
+
+// TestVectors_PlaintextReplacer verifies redacted and plaintext output against the shared vectors
+func TestVectors_PlaintextReplacer(t *testing.T) {
+	for i, v := range loadVectors(t).PlaintextReplacer {
+		input := sensitiveMarkers(v.Input)
+
+		redacted, err := json.Marshal(input)
+		if err != nil {
+			t.Fatalf("json.Marshal() error = %v", err)
+		}
+		assertSameJSON(t, fmt.Sprintf("redacted #%d", i), redacted, v.Redacted)
+
+		plaintext, err := json.Marshal(PlaintextReplacer(input))
+		if err != nil {
+			t.Fatalf("json.Marshal(PlaintextReplacer) error = %v", err)
+		}
+		assertSameJSON(t, fmt.Sprintf("plaintext #%d", i), plaintext, v.Plaintext)
+	}
+}
@@ -0,0 +1,110 @@
+package sensitivestring
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultPollInterval is how often Watch re-resolves a secret from a
+// provider that does not implement Notifier.
+const DefaultPollInterval = time.Minute
+
+// Notifier is implemented by providers that can push change notifications,
+// such as file watchers or backends with event streams. Notify returns a
+// channel that receives a value whenever the secret called name may have
+// changed, until ctx is done.
+type Notifier interface {
+	Notify(ctx context.Context, name string) (<-chan struct{}, error)
+}
+
+// WatchOption configures Watch.
+type WatchOption func(*watchOptions)
+
+type watchOptions struct {
+	interval time.Duration
+	onError  func(error)
+}
+
+// WithPollInterval sets how often the secret is re-resolved, instead of
+// DefaultPollInterval.
+func WithPollInterval(d time.Duration) WatchOption {
+	return func(o *watchOptions) { o.interval = d }
+}
+
+// WithWatchErrors calls fn with the errors of failed re-resolutions, which
+// are otherwise ignored; the last secret resolved stays current.
+func WithWatchErrors(fn func(error)) WatchOption {
+	return func(o *watchOptions) { o.onError = fn }
+}
+
+// Watch resolves ref and then keeps watching it in the background until
+// ctx is done, calling onChange with the previous and the new secret
+// whenever the secret's fingerprint changes, e.g. to reload a database pool
+// after a rotation. Providers implementing Notifier are re-resolved when
+// they signal a change; others are polled on the package Clock. onChange is
+// called from a single goroutine, never concurrently.
+//
+// Watch returns the initial secret, or the error if it cannot be resolved,
+// in which case nothing is watched.
+func (r *Resolver) Watch(ctx context.Context, ref string, onChange func(old, new *SensitiveString), opts ...WatchOption) (*SensitiveString, error) {
+	options := watchOptions{interval: DefaultPollInterval}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	parsed, err := ParseRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	current, err := r.ResolveRef(ctx, parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	var notifications <-chan struct{}
+	if p, ok := r.Provider(parsed.Provider); ok {
+		if n, ok := p.(Notifier); ok {
+			if notifications, err = n.Notify(ctx, parsed.Name); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	go func() {
+		for {
+			if notifications != nil {
+				select {
+				case _, ok := <-notifications:
+					if !ok {
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+			} else {
+				select {
+				case <-GetClock().After(options.interval):
+				case <-ctx.Done():
+					return
+				}
+			}
+			next, err := r.ResolveRef(ctx, parsed)
+			if err != nil {
+				if options.onError != nil && ctx.Err() == nil {
+					options.onError(err)
+				}
+				continue
+			}
+			if !next.Equal(current) {
+				previous := current
+				current = next
+				onChange(previous, next)
+			}
+		}
+	}()
+	return current, nil
+}
+
+// Watch watches ref through the DefaultResolver. See Resolver.Watch.
+func Watch(ctx context.Context, ref string, onChange func(old, new *SensitiveString), opts ...WatchOption) (*SensitiveString, error) {
+	return DefaultResolver().Watch(ctx, ref, onChange, opts...)
+}
@@ -0,0 +1,117 @@
+package sensitivestring
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// switchableProvider serves value, or err when set.
+type switchableProvider struct {
+	mu    sync.Mutex
+	value string
+	err   error
+}
+
+func (p *switchableProvider) set(value string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.value, p.err = value, err
+}
+
+func (p *switchableProvider) Resolve(ctx context.Context, name string) (*SensitiveString, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return nil, p.err
+	}
+	return New(p.value), nil
+}
+
+// pollOnce advances the clock past one poll interval once Watch is waiting on it.
+func pollOnce(t *testing.T, clock *ManualClock) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for clock.Waiters() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Watch never waited on the clock")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	clock.Advance(time.Minute)
+}
+
+// TestWatch_Poll verifies callbacks fire only when the polled secret changes
+func TestWatch_Poll(t *testing.T) {
+	clock := withManualClock(t)
+	backend := &switchableProvider{value: "v1"}
+	r := NewResolver()
+	r.Register("vault", backend)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan [2]string, 10)
+	errs := make(chan error, 10)
+	initial, err := r.Watch(ctx, "vault:db", func(old, new *SensitiveString) {
+		changes <- [2]string{old.Value(), new.Value()}
+	}, WithWatchErrors(func(err error) { errs <- err }))
+	if err != nil || initial.Value() != "v1" {
+		t.Fatalf("Watch() = %v, %v, want v1", initial, err)
+	}
+
+	pollOnce(t, clock) // unchanged
+	backend.set("v2", nil)
+	pollOnce(t, clock)
+	if got := <-changes; got != [2]string{"v1", "v2"} {
+		t.Errorf("onChange(%v), want v1 -> v2", got)
+	}
+	backend.set("", errors.New("vault sealed"))
+	pollOnce(t, clock)
+	if err := <-errs; err == nil {
+		t.Errorf("WithWatchErrors received nil")
+	}
+	backend.set("v3", nil)
+	pollOnce(t, clock)
+	if got := <-changes; got != [2]string{"v2", "v3"} {
+		t.Errorf("onChange(%v), want v2 -> v3 after the outage", got)
+	}
+	if len(changes) != 0 {
+		t.Errorf("unexpected extra changes: %d", len(changes))
+	}
+
+	if _, err := r.Watch(ctx, "vault:", nil); err == nil {
+		t.Errorf("Watch(malformed) error = nil")
+	}
+}
+
+// notifyingProvider pushes a notification for every value it is given.
+type notifyingProvider struct {
+	switchableProvider
+	ch chan struct{}
+}
+
+func (p *notifyingProvider) Notify(ctx context.Context, name string) (<-chan struct{}, error) {
+	return p.ch, nil
+}
+
+// TestWatch_Notify verifies Notifier providers are re-resolved on notification
+func TestWatch_Notify(t *testing.T) {
+	backend := &notifyingProvider{switchableProvider: switchableProvider{value: "v1"}, ch: make(chan struct{})}
+	r := NewResolver()
+	r.Register("files", backend)
+	withDefaultResolver(t, r)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan string, 10)
+	if _, err := Watch(ctx, "files:db", func(old, new *SensitiveString) { changes <- new.Value() }); err != nil {
+		t.Fatal(err)
+	}
+	backend.set("v2", nil)
+	backend.ch <- struct{}{}
+	if got := <-changes; got != "v2" {
+		t.Errorf("onChange new = %v, want v2", got)
+	}
+}
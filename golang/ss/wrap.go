@@ -0,0 +1,53 @@
+package sensitivestring
+
+// WrapAll wraps each value in a *SensitiveString.
+// A nil input returns nil.
+func WrapAll(values []string) []*SensitiveString {
+	if values == nil {
+		return nil
+	}
+	result := make([]*SensitiveString, len(values))
+	for i, value := range values {
+		result[i] = New(value)
+	}
+	return result
+}
+
+// UnwrapAll returns the plaintext of each SensitiveString. nil elements
+// become empty strings. A nil input returns nil.
+func UnwrapAll(secrets []*SensitiveString) []string {
+	if secrets == nil {
+		return nil
+	}
+	result := make([]string, len(secrets))
+	for i, ss := range secrets {
+		result[i] = ss.Value()
+	}
+	return result
+}
+
+// WrapMapValues wraps each value of a map in a *SensitiveString labeled with
+// its key. A nil input returns nil.
+func WrapMapValues(values map[string]string) map[string]*SensitiveString {
+	if values == nil {
+		return nil
+	}
+	result := make(map[string]*SensitiveString, len(values))
+	for key, value := range values {
+		result[key] = NewLabeled(key, value)
+	}
+	return result
+}
+
+// UnwrapMapValues returns a map of the plaintext of each SensitiveString.
+// nil values become empty strings. A nil input returns nil.
+func UnwrapMapValues(secrets map[string]*SensitiveString) map[string]string {
+	if secrets == nil {
+		return nil
+	}
+	result := make(map[string]string, len(secrets))
+	for key, ss := range secrets {
+		result[key] = ss.Value()
+	}
+	return result
+}
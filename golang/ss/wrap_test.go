@@ -0,0 +1,41 @@
+package sensitivestring
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestWrapAll_RoundTrip verifies slice wrapping and unwrapping
+func TestWrapAll_RoundTrip(t *testing.T) {
+	values := []string{"foo", "bar"}
+	wrapped := WrapAll(values)
+
+	if len(wrapped) != 2 || wrapped[0].String() != "sha256:"+fooHashHex {
+		t.Errorf("WrapAll() = %v, want wrapped foo and bar", wrapped)
+	}
+	if got := UnwrapAll(wrapped); !reflect.DeepEqual(got, values) {
+		t.Errorf("UnwrapAll() = %v, want %v", got, values)
+	}
+	if got := UnwrapAll([]*SensitiveString{nil}); !reflect.DeepEqual(got, []string{""}) {
+		t.Errorf("UnwrapAll([nil]) = %v, want [\"\"]", got)
+	}
+	if WrapAll(nil) != nil || UnwrapAll(nil) != nil {
+		t.Errorf("WrapAll(nil)/UnwrapAll(nil) should return nil")
+	}
+}
+
+// TestWrapMapValues_RoundTrip verifies map wrapping and unwrapping
+func TestWrapMapValues_RoundTrip(t *testing.T) {
+	values := map[string]string{"db": "foo", "api": "bar"}
+	wrapped := WrapMapValues(values)
+
+	if got := wrapped["db"].Label(); got != "db" {
+		t.Errorf("WrapMapValues()[db].Label() = %v, want db", got)
+	}
+	if got := UnwrapMapValues(wrapped); !reflect.DeepEqual(got, values) {
+		t.Errorf("UnwrapMapValues() = %v, want %v", got, values)
+	}
+	if WrapMapValues(nil) != nil || UnwrapMapValues(nil) != nil {
+		t.Errorf("WrapMapValues(nil)/UnwrapMapValues(nil) should return nil")
+	}
+}
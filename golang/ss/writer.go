@@ -0,0 +1,132 @@
+package sensitivestring
+
+import (
+	"io"
+	"sync"
+)
+
+// scrubbingWriter replaces secrets in everything written through it. The
+// tail of each write that could be the start of a secret completed by the
+// next write is held back until more data arrives or the writer is closed.
+type scrubbingWriter struct {
+	mu       sync.Mutex
+	w        io.Writer
+	secrets  []*SensitiveString
+	scrubber *scrubber
+	pending  []byte
+}
+
+// ScrubbingWriter returns a writer that replaces every occurrence of the
+// plaintext of secrets, and its common encodings, with its String form
+// before writing to w. With no secrets it scrubs the secrets in the
+// DefaultRegistry at the time of each write.
+//
+// Secrets split across writes are still caught, so up to one secret's
+// length of output may be held back; Close writes it out. Close does not
+// close w. The writer is safe for concurrent use.
+func ScrubbingWriter(w io.Writer, secrets ...*SensitiveString) io.WriteCloser {
+	if len(secrets) == 0 {
+		return &scrubbingWriter{w: w}
+	}
+	return &scrubbingWriter{w: w, secrets: secrets, scrubber: newScrubber(secrets)}
+}
+
+// Write implements io.Writer. It reports len(p) on success even when part
+// of p is held back. When w fails, it reports how much of p reached w;
+// the rest of p is not held back, so it can be written again.
+func (sw *scrubbingWriter) Write(p []byte) (int, error) {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	sc := sw.snapshot()
+	held := len(sw.pending)
+	sw.pending = append(sw.pending, p...)
+	limit := len(sw.pending)
+	if sc != nil {
+		limit -= sc.maxLen - 1
+	}
+	written, err := sw.emit(sc, limit)
+	if err != nil {
+		keep := max(written, held)
+		clear(sw.pending[keep:])
+		sw.pending = sw.pending[:keep]
+		sw.discard(written)
+		return max(written-held, 0), err
+	}
+	return len(p), nil
+}
+
+// Close writes out any held-back output. If w fails, what did not reach it
+// stays held back.
+func (sw *scrubbingWriter) Close() error {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	written, err := sw.emit(sw.snapshot(), len(sw.pending))
+	if err != nil {
+		sw.discard(written)
+	}
+	return err
+}
+
+// snapshot returns the scrubber to apply to the next write.
+func (sw *scrubbingWriter) snapshot() *scrubber {
+	if sw.secrets == nil {
+		return DefaultRegistry().snapshot()
+	}
+	if sw.scrubber.stale() {
+		sw.scrubber = newScrubber(sw.secrets)
+	}
+	return sw.scrubber
+}
+
+// emit scrubs and writes the pending bytes whose matches start before
+// limit, keeping the rest pending. It returns the number of pending bytes
+// written. If w fails, pending is left as it was and the count covers the
+// bytes whose scrubbed output reached w in full.
+func (sw *scrubbingWriter) emit(sc *scrubber, limit int) (int, error) {
+	findings, next := scanWindow(sc, sw.pending, 0, limit, nil)
+	if next == 0 {
+		return 0, nil
+	}
+	out := make([]byte, 0, next)
+	previous := 0
+	for _, f := range findings {
+		out = append(out, sw.pending[previous:f.Offset]...)
+		out = append(out, f.Redacted...)
+		previous = int(f.Offset) + f.Length
+		recordRedaction(FormatScrub, f.Label, 1)
+	}
+	out = append(out, sw.pending[previous:next]...)
+
+	n, err := sw.w.Write(out)
+	if err != nil {
+		return consumed(findings, next, n), err
+	}
+	sw.discard(next)
+	return next, nil
+}
+
+// consumed maps n bytes of scrubbed output back to the number of input
+// bytes, out of next, they were produced from. A replacement counts only
+// once written in full.
+func consumed(findings []Finding, next, n int) int {
+	in, out := 0, 0
+	for _, f := range findings {
+		plain := int(f.Offset) - in
+		if n < out+plain {
+			return in + n - out
+		}
+		in, out = int(f.Offset), out+plain
+		if n < out+len(f.Redacted) {
+			return in
+		}
+		in, out = in+f.Length, out+len(f.Redacted)
+	}
+	return min(in+n-out, next)
+}
+
+// discard zeroes and drops the first n pending bytes.
+func (sw *scrubbingWriter) discard(n int) {
+	remaining := copy(sw.pending, sw.pending[n:])
+	clear(sw.pending[remaining:])
+	sw.pending = sw.pending[:remaining]
+}
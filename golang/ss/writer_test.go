@@ -0,0 +1,135 @@
+package sensitivestring
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// shortWriter accepts up to limit bytes and then fails.
+type shortWriter struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+func (w *shortWriter) Write(p []byte) (int, error) {
+	n := min(len(p), w.limit-w.buf.Len())
+	w.buf.Write(p[:n])
+	if n < len(p) {
+		return n, errors.New("short write")
+	}
+	return n, nil
+}
+
+// TestScrubbingWriter verifies secrets are scrubbed from a single write
+func TestScrubbingWriter(t *testing.T) {
+	secret := New("hunter2")
+	var buf bytes.Buffer
+	w := ScrubbingWriter(&buf, secret)
+
+	input := []byte("password=hunter2 b64=" + base64.StdEncoding.EncodeToString([]byte("hunter2")) + "\n")
+	if n, err := w.Write(input); err != nil || n != len(input) {
+		t.Fatalf("Write() = %v, %v, want %v, nil", n, err, len(input))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	want := "password=" + secret.String() + " b64=" + secret.String() + "\n"
+	if buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+}
+
+// TestScrubbingWriter_SplitWrites verifies secrets split across writes are caught
+func TestScrubbingWriter_SplitWrites(t *testing.T) {
+	secret := New("hunter2")
+	input := "a hunter2 b hunter2hunter2 c hunte"
+	for size := 1; size <= len(input); size++ {
+		var buf bytes.Buffer
+		w := ScrubbingWriter(&buf, secret)
+		for i := 0; i < len(input); i += size {
+			w.Write([]byte(input[i:min(i+size, len(input))]))
+		}
+		w.Close()
+
+		want := strings.ReplaceAll(input, "hunter2", secret.String())
+		if buf.String() != want {
+			t.Errorf("chunk size %d: output = %q, want %q", size, buf.String(), want)
+		}
+	}
+}
+
+// TestScrubbingWriter_HoldsBackPartialSecret verifies a possible prefix is not written early
+func TestScrubbingWriter_HoldsBackPartialSecret(t *testing.T) {
+	var buf bytes.Buffer
+	w := ScrubbingWriter(&buf, New("hunter2"))
+	w.Write([]byte("line one\nhunt"))
+	if strings.Contains(buf.String(), "hunt") {
+		t.Errorf("output = %q, want the possible secret prefix held back", buf.String())
+	}
+	w.Write([]byte("ing\n"))
+	w.Close()
+	if buf.String() != "line one\nhunting\n" {
+		t.Errorf("output = %q, want the input unchanged", buf.String())
+	}
+}
+
+// TestScrubbingWriter_DefaultRegistry verifies the default registry is used without secrets
+func TestScrubbingWriter_DefaultRegistry(t *testing.T) {
+	secret := New("hunter2")
+	withDefaultRegistry(t, secret)
+
+	var buf bytes.Buffer
+	w := ScrubbingWriter(&buf)
+	w.Write([]byte("token hunter2"))
+	w.Close()
+	if buf.String() != "token "+secret.String() {
+		t.Errorf("output = %q, want the secret scrubbed", buf.String())
+	}
+}
+
+// TestScrubbingWriter_WriteError verifies a failed write reports the input bytes that reached the writer
+func TestScrubbingWriter_WriteError(t *testing.T) {
+	secret := New("hunter2")
+	for _, tt := range []struct {
+		limit int
+		want  int
+	}{
+		{limit: 2, want: 2},
+		{limit: 6, want: 4},
+		{limit: 4 + len(secret.String()) + 1, want: 12},
+	} {
+		dst := &shortWriter{limit: tt.limit}
+		w := ScrubbingWriter(dst, secret)
+		n, err := w.Write([]byte("abc hunter2 " + strings.Repeat("x", 100)))
+		if err == nil || n != tt.want {
+			t.Errorf("limit %d: Write() = %v, %v, want %v and an error", tt.limit, n, err, tt.want)
+		}
+		if err := w.Close(); err != nil {
+			t.Errorf("limit %d: Close() error = %v, want the rest of the input dropped", tt.limit, err)
+		}
+	}
+}
+
+// TestScrubbingWriter_Concurrent verifies concurrent writes are safe and each secret is scrubbed
+func TestScrubbingWriter_Concurrent(t *testing.T) {
+	secret := New("hunter2")
+	var buf bytes.Buffer
+	w := ScrubbingWriter(&buf, secret)
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for range 100 {
+				w.Write([]byte("hunter2\n"))
+			}
+		})
+	}
+	wg.Wait()
+	w.Close()
+	if strings.Contains(buf.String(), "hunter2") || strings.Count(buf.String(), secret.String()) != 800 {
+		t.Errorf("output has %d redactions, want 800 and no plaintext", strings.Count(buf.String(), secret.String()))
+	}
+}
@@ -0,0 +1,117 @@
+// Package zapx integrates SensitiveString with go.uber.org/zap. Secret and
+// Object render a SensitiveString as its hash through zap's own encoders,
+// without relying on fmt fallbacks, and NewScrubbingEncoder wraps any
+// zapcore.Encoder so that the plaintext of registered secrets never reaches
+// the output even when it was logged as a plain string.
+package zapx
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+
+	ss "github.com/earlye/sensitive-strings/golang/ss"
+)
+
+// Secret returns a zap field rendering s as MarshalJSON does: as its hash,
+// or as a Redacted object in ss.MarshalStructured mode unless a Redactor
+// is in effect.
+func Secret(key string, s *ss.SensitiveString) zap.Field {
+	if s == nil {
+		s = ss.New("")
+	}
+	if _, ok := s.RedactedForm(); ok && ss.GetMarshalMode() == ss.MarshalStructured {
+		return zap.Object(key, Object(s))
+	}
+	return zap.String(key, s.String())
+}
+
+// Secrets returns a zap field rendering each of secrets as its hash.
+func Secrets(key string, secrets []*ss.SensitiveString) zap.Field {
+	return zap.Array(key, secretArray(secrets))
+}
+
+// Object returns a zapcore.ObjectMarshaler encoding s as its Redacted
+// fields: alg, hash, len and, when set, label. While a Redactor is in
+// effect, which the hash would defeat, it encodes a single "value" field
+// holding the String form instead.
+func Object(s *ss.SensitiveString) zapcore.ObjectMarshaler {
+	if s == nil {
+		s = ss.New("")
+	}
+	return secretObject{s}
+}
+
+// secretObject implements zapcore.ObjectMarshaler for a SensitiveString.
+type secretObject struct {
+	s *ss.SensitiveString
+}
+
+func (o secretObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	r, ok := o.s.RedactedForm()
+	if !ok {
+		enc.AddString("value", o.s.String())
+		return nil
+	}
+	enc.AddString("alg", r.Alg)
+	enc.AddString("hash", r.Hash)
+	if r.Len != 0 {
+		enc.AddInt("len", r.Len)
+	}
+	if r.Label != "" {
+		enc.AddString("label", r.Label)
+	}
+	return nil
+}
+
+// secretArray implements zapcore.ArrayMarshaler for SensitiveStrings.
+type secretArray []*ss.SensitiveString
+
+func (a secretArray) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, s := range a {
+		if s == nil {
+			s = ss.New("")
+		}
+		enc.AppendString(s.String())
+	}
+	return nil
+}
+
+// scrubbingEncoder scrubs registered secrets from every encoded entry.
+type scrubbingEncoder struct {
+	zapcore.Encoder
+	registry *ss.Registry
+}
+
+// NewScrubbingEncoder wraps enc so that every encoded entry, including its
+// message, fields and fields added with Logger.With, has the plaintext of
+// each secret in registry replaced by its hash form. A nil registry means
+// the ss.DefaultRegistry at the time each entry is written.
+func NewScrubbingEncoder(enc zapcore.Encoder, registry *ss.Registry) zapcore.Encoder {
+	return &scrubbingEncoder{Encoder: enc, registry: registry}
+}
+
+// Clone implements zapcore.Encoder.
+func (e *scrubbingEncoder) Clone() zapcore.Encoder {
+	return &scrubbingEncoder{Encoder: e.Encoder.Clone(), registry: e.registry}
+}
+
+// EncodeEntry implements zapcore.Encoder.
+func (e *scrubbingEncoder) EncodeEntry(entry zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	buf, err := e.Encoder.EncodeEntry(entry, fields)
+	if err != nil {
+		return nil, err
+	}
+	registry := e.registry
+	if registry == nil {
+		registry = ss.DefaultRegistry()
+	}
+	original := buf.Bytes()
+	scrubbed := registry.ScrubBytes(original)
+	if len(original) == 0 || &scrubbed[0] == &original[0] {
+		return buf, nil
+	}
+	buf.Reset()
+	buf.Write(scrubbed)
+	return buf, nil
+}
@@ -0,0 +1,104 @@
+package zapx
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	ss "github.com/earlye/sensitive-strings/golang/ss"
+)
+
+const plaintext = "zap-secret-plaintext"
+
+// newLogger returns a JSON logger writing to buf, optionally wrapping its encoder
+func newLogger(buf *bytes.Buffer, wrap func(zapcore.Encoder) zapcore.Encoder) *zap.Logger {
+	enc := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	if wrap != nil {
+		enc = wrap(enc)
+	}
+	return zap.New(zapcore.NewCore(enc, zapcore.AddSync(buf), zapcore.DebugLevel))
+}
+
+// TestSecret verifies the field helpers render hashes
+func TestSecret(t *testing.T) {
+	var buf bytes.Buffer
+	secret := ss.New(plaintext)
+	newLogger(&buf, nil).Info("login",
+		Secret("password", secret),
+		Secrets("keys", []*ss.SensitiveString{secret, nil}),
+		Secret("missing", nil),
+	)
+
+	out := buf.String()
+	if strings.Contains(out, plaintext) {
+		t.Errorf("Secret() leaked plaintext: %s", out)
+	}
+	if !strings.Contains(out, `"password":"`+secret.String()+`"`) {
+		t.Errorf("Secret() output = %s, want the hash form", out)
+	}
+	if !strings.Contains(out, `"keys":["`+secret.String()+`","`) {
+		t.Errorf("Secrets() output = %s, want hash forms", out)
+	}
+}
+
+// TestObject verifies the ObjectMarshaler emits the Redacted fields
+func TestObject(t *testing.T) {
+	var buf bytes.Buffer
+	secret := ss.NewLabeled("db", plaintext)
+	newLogger(&buf, nil).Info("login", zap.Object("password", Object(secret)))
+
+	r := secret.Redacted()
+	want := `"password":{"alg":"sha256","hash":"` + r.Hash + `","len":20,"label":"db"}`
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("Object() output = %s, want %s", buf.String(), want)
+	}
+}
+
+// TestNewScrubbingEncoder verifies plaintext logged as plain strings is scrubbed
+func TestNewScrubbingEncoder(t *testing.T) {
+	registry := ss.NewRegistry()
+	secret := ss.New(plaintext)
+	registry.Register(secret)
+
+	var buf bytes.Buffer
+	logger := newLogger(&buf, func(enc zapcore.Encoder) zapcore.Encoder {
+		return NewScrubbingEncoder(enc, registry)
+	})
+	logger.With(zap.String("ctx", plaintext)).Info("token is "+plaintext, zap.String("raw", plaintext), zap.String("other", "fine"))
+
+	out := buf.String()
+	if strings.Contains(out, plaintext) {
+		t.Errorf("scrubbing encoder leaked plaintext: %s", out)
+	}
+	if got := strings.Count(out, secret.String()); got != 3 {
+		t.Errorf("scrubbing encoder replaced %d occurrences, want 3: %s", got, out)
+	}
+	if !strings.Contains(out, `"other":"fine"`) {
+		t.Errorf("scrubbing encoder altered unrelated fields: %s", out)
+	}
+}
+
+// TestSecret_Redactor verifies a Redactor suppresses the structured hash
+func TestSecret_Redactor(t *testing.T) {
+	ss.SetMarshalMode(ss.MarshalStructured)
+	ss.SetRedactor(ss.Placeholder(""))
+	t.Cleanup(func() {
+		ss.SetMarshalMode(ss.MarshalHash)
+		ss.SetRedactor(nil)
+	})
+
+	var buf bytes.Buffer
+	secret := ss.New(plaintext)
+	newLogger(&buf, nil).Info("login", Secret("password", secret), zap.Object("token", Object(secret)))
+
+	out := buf.String()
+	if strings.Contains(out, secret.Redacted().Hash) || strings.Contains(out, plaintext) {
+		t.Errorf("output = %s, leaks the hash or plaintext", out)
+	}
+	if !strings.Contains(out, `"password":"[REDACTED]"`) || !strings.Contains(out, `"token":{"value":"[REDACTED]"}`) {
+		t.Errorf("output = %s, want the placeholder", out)
+	}
+}
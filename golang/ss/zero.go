@@ -0,0 +1,69 @@
+package sensitivestring
+
+import "errors"
+
+// ErrDestroyed is returned by Reveal for a secret wiped with Zero.
+var ErrDestroyed = errors.New("sensitivestring: secret has been destroyed")
+
+// wiper is implemented by storage backends that can erase the plaintext
+// they hold.
+type wiper interface {
+	wipe()
+}
+
+// fallible is implemented by storage backends whose reveal can fail, e.g.
+// because a kernel key was revoked elsewhere. Their reveal panics on
+// failure; tryReveal reports it.
+type fallible interface {
+	tryReveal() (string, error)
+}
+
+// destroyedStorage is the storage of a secret wiped with Zero.
+type destroyedStorage struct{}
+
+func (destroyedStorage) reveal() string { return "" }
+func (destroyedStorage) size() int      { return 0 }
+
+// Zero wipes the secret and marks it destroyed, ending its lifecycle:
+// afterwards Value() returns "" and Reveal returns ErrDestroyed. Storage
+// backends that own their memory are erased (buffers overwritten, kernel
+// keys revoked); a Go string cannot be overwritten, so for a secret bound
+// to one with Bind Zero only drops the reference and wiping is best-effort.
+// Copies made earlier, e.g. by WithRedactor, share backend storage and so
+// lose the plaintext too, but are not themselves marked destroyed.
+func (s *SensitiveString) Zero() {
+	if s == nil {
+		return
+	}
+	if w, ok := s.store.(wiper); ok {
+		w.wipe()
+	}
+	s.value = ""
+	s.digest = nil
+	s.store = destroyedStorage{}
+}
+
+// Destroyed reports whether Zero has been called on s.
+func (s *SensitiveString) Destroyed() bool {
+	if s == nil {
+		return false
+	}
+	_, destroyed := s.store.(destroyedStorage)
+	return destroyed
+}
+
+// Reveal returns the raw plaintext value like Value, or ErrDestroyed if the
+// secret has been wiped with Zero. For storage backends that can fail to
+// produce the plaintext, such as NewInKeyring, it returns their error where
+// Value would panic.
+func (s *SensitiveString) Reveal() (string, error) {
+	if s.Destroyed() {
+		return "", ErrDestroyed
+	}
+	if s != nil {
+		if f, ok := s.store.(fallible); ok {
+			return f.tryReveal()
+		}
+	}
+	return s.Value(), nil
+}
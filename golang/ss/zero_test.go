@@ -0,0 +1,88 @@
+package sensitivestring
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestZero verifies a zeroed secret is empty and reports ErrDestroyed
+func TestZero(t *testing.T) {
+	ss := New("hunter2")
+	if got, err := ss.Reveal(); got != "hunter2" || err != nil {
+		t.Errorf("Reveal() = %q, %v, want hunter2, nil", got, err)
+	}
+
+	ss.Zero()
+	if got := ss.Value(); got != "" {
+		t.Errorf("Value() after Zero = %q, want empty", got)
+	}
+	if got := ss.Len(); got != 0 {
+		t.Errorf("Len() after Zero = %d, want 0", got)
+	}
+	if !ss.Destroyed() {
+		t.Errorf("Destroyed() = false, want true")
+	}
+	if _, err := ss.Reveal(); !errors.Is(err, ErrDestroyed) {
+		t.Errorf("Reveal() error = %v, want ErrDestroyed", err)
+	}
+
+	var nilSS *SensitiveString
+	nilSS.Zero()
+	if nilSS.Destroyed() {
+		t.Errorf("nil Destroyed() = true, want false")
+	}
+}
+
+// TestZero_WipesStorage verifies backend buffers are overwritten, including for earlier copies
+func TestZero_WipesStorage(t *testing.T) {
+	ss := New("hunter2", WithZeroOnFinalize())
+	buf := ss.store.(*byteStorage).buf
+	copied := ss.WithRedactor(Placeholder(""))
+
+	ss.Zero()
+	if string(buf) != "\x00\x00\x00\x00\x00\x00\x00" {
+		t.Errorf("buffer after Zero = %q, want zeros", buf)
+	}
+	if got := copied.Value(); got == "hunter2" {
+		t.Errorf("copy Value() after Zero = %q, want the plaintext gone", got)
+	}
+}
+
+// TestValueBytes verifies the returned bytes are a copy the caller can wipe
+func TestValueBytes(t *testing.T) {
+	ss := New("hunter2")
+	b := ss.ValueBytes()
+	if string(b) != "hunter2" {
+		t.Errorf("ValueBytes() = %q, want hunter2", b)
+	}
+	clear(b)
+	if got := ss.Value(); got != "hunter2" {
+		t.Errorf("Value() after clearing ValueBytes() = %q, want hunter2", got)
+	}
+
+	buf := ss.store.(*byteStorage).buf
+	ss.Zero()
+	if string(buf) != "\x00\x00\x00\x00\x00\x00\x00" {
+		t.Errorf("default storage after Zero = %q, want zeros", buf)
+	}
+	if (*SensitiveString)(nil).ValueBytes() != nil {
+		t.Errorf("nil ValueBytes() != nil")
+	}
+}
+
+// TestUse verifies the callback sees the plaintext of every backend
+func TestUse(t *testing.T) {
+	for _, ss := range []*SensitiveString{New("foo"), {value: "foo"}} {
+		var got string
+		ss.Use(func(b []byte) { got = string(b) })
+		if got != "foo" {
+			t.Errorf("Use() got %q, want foo", got)
+		}
+	}
+	var nilSS *SensitiveString
+	nilSS.Use(func(b []byte) {
+		if b != nil {
+			t.Errorf("nil Use() got %q, want nil", b)
+		}
+	})
+}
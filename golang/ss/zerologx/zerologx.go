@@ -0,0 +1,102 @@
+// Package zerologx integrates SensitiveString with github.com/rs/zerolog.
+// Object and Secret render a SensitiveString as its hash, and
+// NewScrubbingWriter wraps a zerolog output so that the plaintext of
+// registered secrets never reaches it, even when it was logged as a plain
+// string.
+package zerologx
+
+import (
+	"io"
+
+	"github.com/rs/zerolog"
+
+	ss "github.com/earlye/sensitive-strings/golang/ss"
+)
+
+// Secret adds s to e under key as MarshalJSON renders it: as its hash, or
+// as a Redacted object in ss.MarshalStructured mode unless a Redactor is in
+// effect. It returns e for chaining.
+func Secret(e *zerolog.Event, key string, s *ss.SensitiveString) *zerolog.Event {
+	if s == nil {
+		s = ss.New("")
+	}
+	if _, ok := s.RedactedForm(); ok && ss.GetMarshalMode() == ss.MarshalStructured {
+		return e.Object(key, Object(s))
+	}
+	return e.Str(key, s.String())
+}
+
+// Object returns a zerolog.LogObjectMarshaler encoding s as its Redacted
+// fields: alg, hash, len and, when set, label. While a Redactor is in
+// effect, which the hash would defeat, it encodes a single "value" field
+// holding the String form instead.
+func Object(s *ss.SensitiveString) zerolog.LogObjectMarshaler {
+	if s == nil {
+		s = ss.New("")
+	}
+	return secretObject{s}
+}
+
+// secretObject implements zerolog.LogObjectMarshaler for a SensitiveString.
+type secretObject struct {
+	s *ss.SensitiveString
+}
+
+func (o secretObject) MarshalZerologObject(e *zerolog.Event) {
+	r, ok := o.s.RedactedForm()
+	if !ok {
+		e.Str("value", o.s.String())
+		return
+	}
+	e.Str("alg", r.Alg).Str("hash", r.Hash)
+	if r.Len != 0 {
+		e.Int("len", r.Len)
+	}
+	if r.Label != "" {
+		e.Str("label", r.Label)
+	}
+}
+
+// scrubbingWriter scrubs registered secrets from every event written.
+type scrubbingWriter struct {
+	w        io.Writer
+	registry *ss.Registry
+}
+
+// NewScrubbingWriter wraps w so that the plaintext of each secret in
+// registry is replaced by its hash form. zerolog writes each event with a
+// single call, so secrets are never split across writes. A nil registry
+// means the ss.DefaultRegistry at the time each event is written. If w is
+// a zerolog.LevelWriter, levels are passed through.
+func NewScrubbingWriter(w io.Writer, registry *ss.Registry) zerolog.LevelWriter {
+	return &scrubbingWriter{w: w, registry: registry}
+}
+
+// Write implements io.Writer.
+func (sw *scrubbingWriter) Write(p []byte) (int, error) {
+	if _, err := sw.w.Write(sw.scrub(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// WriteLevel implements zerolog.LevelWriter.
+func (sw *scrubbingWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	lw, ok := sw.w.(zerolog.LevelWriter)
+	if !ok {
+		return sw.Write(p)
+	}
+	if _, err := lw.WriteLevel(level, sw.scrub(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// scrub returns p with registered secrets replaced.
+func (sw *scrubbingWriter) scrub(p []byte) []byte {
+	registry := sw.registry
+	if registry == nil {
+		registry = ss.DefaultRegistry()
+	}
+	return registry.ScrubBytes(p)
+}
@@ -0,0 +1,96 @@
+package zerologx
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	ss "github.com/earlye/sensitive-strings/golang/ss"
+)
+
+const plaintext = "zerolog-secret-plaintext"
+
+// TestSecret verifies Secret renders the hash form
+func TestSecret(t *testing.T) {
+	var buf bytes.Buffer
+	secret := ss.New(plaintext)
+	logger := zerolog.New(&buf)
+	Secret(logger.Info(), "password", secret).Msg("login")
+
+	if strings.Contains(buf.String(), plaintext) {
+		t.Errorf("Secret() leaked plaintext: %s", buf.String())
+	}
+	if !strings.Contains(buf.String(), `"password":"`+secret.String()+`"`) {
+		t.Errorf("Secret() output = %s, want the hash form", buf.String())
+	}
+}
+
+// TestObject verifies the LogObjectMarshaler emits the Redacted fields
+func TestObject(t *testing.T) {
+	var buf bytes.Buffer
+	secret := ss.NewLabeled("db", plaintext)
+	logger := zerolog.New(&buf)
+	logger.Info().Object("password", Object(secret)).Msg("login")
+
+	want := `"password":{"alg":"sha256","hash":"` + secret.Redacted().Hash + `","len":24,"label":"db"}`
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("Object() output = %s, want %s", buf.String(), want)
+	}
+}
+
+// levelRecorder records the level of each write
+type levelRecorder struct {
+	bytes.Buffer
+	levels []zerolog.Level
+}
+
+func (r *levelRecorder) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	r.levels = append(r.levels, level)
+	return r.Write(p)
+}
+
+// TestNewScrubbingWriter verifies plaintext is scrubbed and levels pass through
+func TestNewScrubbingWriter(t *testing.T) {
+	registry := ss.NewRegistry()
+	secret := ss.New(plaintext)
+	registry.Register(secret)
+
+	var out levelRecorder
+	logger := zerolog.New(NewScrubbingWriter(&out, registry))
+	logger.Warn().Str("raw", plaintext).Msg("token " + plaintext)
+
+	if strings.Contains(out.String(), plaintext) {
+		t.Errorf("scrubbing writer leaked plaintext: %s", out.String())
+	}
+	if got := strings.Count(out.String(), secret.String()); got != 2 {
+		t.Errorf("scrubbing writer replaced %d occurrences, want 2: %s", got, out.String())
+	}
+	if len(out.levels) != 1 || out.levels[0] != zerolog.WarnLevel {
+		t.Errorf("levels = %v, want [warn]", out.levels)
+	}
+}
+
+// TestSecret_Redactor verifies a Redactor suppresses the structured hash
+func TestSecret_Redactor(t *testing.T) {
+	ss.SetMarshalMode(ss.MarshalStructured)
+	ss.SetRedactor(ss.Placeholder(""))
+	t.Cleanup(func() {
+		ss.SetMarshalMode(ss.MarshalHash)
+		ss.SetRedactor(nil)
+	})
+
+	var buf bytes.Buffer
+	secret := ss.New(plaintext)
+	logger := zerolog.New(&buf)
+	Secret(logger.Info(), "password", secret).Object("token", Object(secret)).Msg("login")
+
+	out := buf.String()
+	if strings.Contains(out, secret.Redacted().Hash) || strings.Contains(out, plaintext) {
+		t.Errorf("output = %s, leaks the hash or plaintext", out)
+	}
+	if !strings.Contains(out, `"password":"[REDACTED]"`) || !strings.Contains(out, `"token":{"value":"[REDACTED]"}`) {
+		t.Errorf("output = %s, want the placeholder", out)
+	}
+}
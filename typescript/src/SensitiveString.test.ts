@@ -6,6 +6,7 @@ import assert from "node:assert";
 import util from "node:util";
 import _ from "lodash";
 import yaml from "yaml";
+import fs from "node:fs";
 console.log("Testing")
 
 test("[af5a2178] - SensitiveString Hides value by default", () => {
@@ -414,3 +415,51 @@ test("[404A48D7-YAML] - yaml.stringify() with PlaintextReplacer should serialize
   // Verify the hash is NOT present (should be raw values)
   assert(!yamlStr.includes("sha256:"), "yaml.stringify() with PlaintextReplacer should NOT show SHA hash!");
 });
+
+// Canonical vectors shared with the other language implementations.
+const vectors = JSON.parse(fs.readFileSync(new URL("../../test-vectors/sensitive-strings.json", import.meta.url), "utf8"));
+
+// sensitiveMarkers replaces {"$sensitive": "..."} markers with SensitiveString instances.
+function sensitiveMarkers(input: any): any {
+  if (Array.isArray(input)) {
+    return input.map(sensitiveMarkers);
+  }
+  if (input instanceof Object) {
+    const keys = Object.keys(input);
+    if (keys.length == 1 && typeof input["$sensitive"] === "string") {
+      return new SensitiveString(input["$sensitive"]);
+    }
+    let result: Record<string, any> = {};
+    for (const key of keys) {
+      result[key] = sensitiveMarkers(input[key]);
+    }
+    return result;
+  }
+  return input;
+}
+
+test("[vectors] - toString matches the shared hash vectors", () => {
+  for (const v of vectors.hash) {
+    assert.equal(new SensitiveString(v.value).toString(), v.string);
+  }
+});
+
+test("[vectors] - redacted matches the shared structured vectors", () => {
+  for (const v of vectors.structured) {
+    assert.deepEqual(new SensitiveString(v.value).redacted(v.label), v.expected);
+  }
+});
+
+test("[vectors] - mask matches the shared mask vectors", () => {
+  for (const v of vectors.mask) {
+    assert.equal(new SensitiveString(v.value).mask(v.options), v.expected);
+  }
+});
+
+test("[vectors] - PlaintextReplacer matches the shared replacer vectors", () => {
+  for (const v of vectors.plaintextReplacer) {
+    const input = sensitiveMarkers(v.input);
+    assert.deepEqual(JSON.parse(JSON.stringify(input)), v.redacted);
+    assert.deepEqual(JSON.parse(JSON.stringify(input, SensitiveString.PlaintextReplacer())), v.plaintext);
+  }
+});
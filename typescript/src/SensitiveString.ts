@@ -28,6 +28,41 @@ export function sha256(content:string): string {
  */
 export type JSONReplacer = (key: string, value: any) => any;
 
+/**
+ * Redacted is the structured representation of a SensitiveString, as
+ * emitted by the Go implementation in its MarshalStructured mode, so that
+ * log processors can recognize redacted fields programmatically.
+ */
+export interface Redacted {
+  alg: string;
+  hash: string;
+  len?: number;
+  label?: string;
+}
+
+/**
+ * DEFAULT_MASK_CHAR is the character mask() uses when MaskOptions.maskChar
+ * is not set.
+ */
+export const DEFAULT_MASK_CHAR = "•";
+
+/**
+ * MaskOptions configures mask(). Lengths are counted in Unicode code
+ * points, so a masked preview never splits a character.
+ */
+export interface MaskOptions {
+  /** Number of leading characters left visible. */
+  showFirst?: number;
+  /** Number of trailing characters left visible. If showFirst and showLast
+   * together would reveal the whole value, nothing is revealed. */
+  showLast?: number;
+  /** Replaces each hidden character; defaults to DEFAULT_MASK_CHAR. */
+  maskChar?: string;
+  /** Minimum number of mask characters emitted, so that short values do
+   * not reveal their length. */
+  minMasked?: number;
+}
+
 /**
  * SensitiveString is a type that helps avoid accidental persistence of secrets.
  * It wraps a string value and provides a sha256 hash of the value instead of the
@@ -66,6 +101,40 @@ export class SensitiveString {
     return this[VALUE_SYMBOL];
   }
 
+  /**
+   * redacted returns the structured representation of the value. len is
+   * the UTF-8 byte length and is omitted for empty values, as is an empty
+   * label.
+   */
+  redacted(label: string = ""): Redacted {
+    const result: Redacted = { alg: "sha256", hash: sha256(this[VALUE_SYMBOL]) };
+    const len = utf8ToBytes(this[VALUE_SYMBOL]).length;
+    if (len > 0)
+      result.len = len;
+    if (label !== "")
+      result.label = label;
+    return result;
+  }
+
+  /**
+   * mask returns a preview of the value with all but the characters
+   * selected by options replaced by the mask character, e.g. "••••••3456"
+   * for { showLast: 4 }. Unlike toString, the result reveals part of the
+   * plaintext; use it only where that is acceptable.
+   */
+  mask(options: MaskOptions = {}): string {
+    const chars = Array.from(this[VALUE_SYMBOL]);
+    let first = Math.max(options.showFirst ?? 0, 0);
+    let last = Math.max(options.showLast ?? 0, 0);
+    if (first + last >= chars.length) {
+      first = 0;
+      last = 0;
+    }
+    const hidden = Math.max(chars.length - first - last, options.minMasked ?? 0);
+    const maskChar = options.maskChar || DEFAULT_MASK_CHAR;
+    return chars.slice(0, first).join("") + maskChar.repeat(hidden) + chars.slice(chars.length - last).join("");
+  }
+
   /**
    * IsSensitiveString returns true if and only if `input` is a
    * SensitiveString (from any version of the SensitiveString library, not just this one)
@@ -130,6 +199,11 @@ export class SensitiveString {
       (key : string , value: any ): any => value :
       replacerFunction;
     return (key:any, value:any) : any => {
+      if (Array.isArray(value)) {
+        const result = value.map((element: any) =>
+          SensitiveString.IsSensitiveString(element) ? element.getValue() : element);
+        return otherReplacerFunction(key, result);
+      }
       if (value instanceof Object) {
         let result: Record<string, any> = {};
         for (const child of Object.keys(value)) {